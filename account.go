@@ -0,0 +1,68 @@
+package gosparkpost
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// https://developers.sparkpost.com/api/account
+var accountPathFormat = "/api/v%d/account"
+
+// Account is the JSON structure returned from the SparkPost Account API.
+type Account struct {
+	CustomerID   int    `json:"customer_id,omitempty"`
+	Status       string `json:"status,omitempty"`
+	StatusReason string `json:"status_reason,omitempty"`
+	Created      string `json:"created,omitempty"`
+	Updated      string `json:"updated,omitempty"`
+
+	Subscription struct {
+		Code         string `json:"code,omitempty"`
+		Name         string `json:"name,omitempty"`
+		Type         string `json:"type,omitempty"`
+		PlanVolume   int    `json:"plan_volume,omitempty"`
+		SelfServe    bool   `json:"self_serve,omitempty"`
+		RecurringFee int    `json:"recurring_charge,omitempty"`
+	} `json:"subscription,omitempty"`
+
+	Options map[string]interface{} `json:"options,omitempty"`
+}
+
+// ApiStatus is the result of Ping.
+type ApiStatus struct {
+	// ApiVersion is the version that was requested, from Config.ApiVersion.
+	ApiVersion int
+	StatusCode int
+	Latency    time.Duration
+	Account    *Account
+}
+
+// Account retrieves information about the account the API key belongs to.
+func (c *Client) Account() (*Account, *Response, error) {
+	path := fmt.Sprintf(accountPathFormat, c.Config.ApiVersion)
+	res, err := c.doJSON(context.Background(), "GET", c.Config.BaseUrl+path, nil, "Account", "retrieve")
+	if err != nil {
+		return nil, res, err
+	}
+	account := &Account{}
+	if err = res.DecodeResults(account); err != nil {
+		return nil, res, err
+	}
+	return account, res, nil
+}
+
+// Ping checks that the API can be reached, that the API key is valid, and that the
+// configured API version is served, by making a lightweight Account request.
+// It's intended as a fail-fast check at startup.
+func (c *Client) Ping() (*ApiStatus, error) {
+	status := &ApiStatus{ApiVersion: c.Config.ApiVersion}
+	start := time.Now()
+	account, res, err := c.Account()
+	status.Latency = time.Since(start)
+	if res != nil && res.HTTP != nil {
+		status.StatusCode = res.HTTP.StatusCode
+	}
+	status.Account = account
+	return status, err
+}
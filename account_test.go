@@ -0,0 +1,64 @@
+package gosparkpost
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+var accountResponse string = `{
+  "results": {
+    "customer_id": 123456,
+    "status": "active",
+    "subscription": {
+      "code": "bronze1",
+      "name": "Bronze 1",
+      "plan_volume": 10000
+    }
+  }
+}`
+
+func TestPing(t *testing.T) {
+	testSetup(t)
+	defer testTeardown()
+
+	// set up the response handler
+	path := fmt.Sprintf(accountPathFormat, testClient.Config.ApiVersion)
+	testMux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		w.Header().Set("Content-Type", "application/json; charset=utf8")
+		w.Write([]byte(accountResponse))
+	})
+
+	status, err := testClient.Ping()
+	if err != nil {
+		t.Fatalf("Ping returned error: %v", err)
+	}
+	if status.StatusCode != 200 || status.ApiVersion != 1 {
+		t.Errorf("unexpected status: %+v", status)
+	}
+	if status.Account == nil || status.Account.CustomerID != 123456 || status.Account.Subscription.Code != "bronze1" {
+		t.Errorf("unexpected account: %+v", status.Account)
+	}
+}
+
+func TestPing_unauthorized(t *testing.T) {
+	testSetup(t)
+	defer testTeardown()
+
+	// set up the response handler
+	path := fmt.Sprintf(accountPathFormat, testClient.Config.ApiVersion)
+	testMux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json; charset=utf8")
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"errors":[{"message":"Unauthorized."}]}`))
+	})
+
+	status, err := testClient.Ping()
+	if err == nil {
+		t.Fatal("Ping didn't return an error for a bad API key")
+	}
+	if status.StatusCode != 401 {
+		t.Errorf("status code is %d, expected 401", status.StatusCode)
+	}
+}
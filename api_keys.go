@@ -0,0 +1,182 @@
+package gosparkpost
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+)
+
+// https://developers.sparkpost.com/api/#/reference/api-keys
+var apiKeysPathFormat = "/api/v%d/api-keys"
+
+// ApiKey is the JSON structure accepted by and returned from the SparkPost API Keys API.
+// Key is only returned when the ApiKey is created; after that only ShortKey identifies it.
+type ApiKey struct {
+	ID           string   `json:"id,omitempty"`
+	Label        string   `json:"label,omitempty"`
+	Grants       []string `json:"grants,omitempty"`
+	Key          string   `json:"key,omitempty"`
+	ShortKey     string   `json:"short_key,omitempty"`
+	SubaccountID int      `json:"subaccount_id,omitempty"`
+	// ValidIPs restricts use of the key to these IP addresses or CIDR ranges.
+	ValidIPs []string `json:"valid_ips,omitempty"`
+}
+
+// ApiKeyResults is the "results" object returned when an ApiKey is created.
+type ApiKeyResults struct {
+	ID       string `json:"id"`
+	Key      string `json:"key"`
+	Label    string `json:"label"`
+	ShortKey string `json:"short_key"`
+}
+
+// Validate runs sanity checks on an ApiKey struct.
+// This should catch most errors before attempting a doomed API call.
+func (k *ApiKey) Validate() error {
+	if k == nil {
+		return fmt.Errorf("Can't Validate a nil ApiKey")
+	}
+
+	// enforce required parameters
+	if k.Label == "" {
+		return fmt.Errorf("ApiKey requires a non-empty Label")
+	} else if len(k.Grants) == 0 {
+		return fmt.Errorf("ApiKey requires at least one of Grants")
+	}
+
+	// enforce max lengths
+	if len(k.Label) > 1024 {
+		return fmt.Errorf("ApiKey label may not be longer than 1024 bytes")
+	}
+
+	for _, ip := range k.ValidIPs {
+		if net.ParseIP(ip) == nil {
+			if _, _, err := net.ParseCIDR(ip); err != nil {
+				return fmt.Errorf("ApiKey valid IP [%s] isn't an IP address or CIDR range", ip)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Create accepts a populated ApiKey object, validates it, and performs an API call against
+// the configured endpoint. The new key is set on k, and is only available from this call.
+// Use WithSubaccount, or SubaccountApiKeyCreate, to create a key for a subaccount.
+func (c *Client) ApiKeyCreate(k *ApiKey) (res *Response, err error) {
+	err = k.Validate()
+	if err != nil {
+		return
+	}
+	sub := c.subaccount()
+	if sub != "" {
+		if err = validateSubaccountGrants(k.Grants); err != nil {
+			return
+		}
+	}
+
+	path := fmt.Sprintf(apiKeysPathFormat, c.Config.ApiVersion)
+	res, err = c.doJSON(context.Background(), "POST", c.Config.BaseUrl+path, k, "ApiKey", "create")
+	if err != nil {
+		return
+	}
+
+	var results ApiKeyResults
+	if err = res.DecodeResults(&results); err != nil {
+		return res, err
+	}
+	if results.ID == "" || results.Key == "" {
+		err = res.unexpected("Unexpected response to ApiKey creation")
+	}
+	k.ID = results.ID
+	k.Key = results.Key
+	k.ShortKey = results.ShortKey
+	if id, err := strconv.Atoi(sub); err == nil {
+		k.SubaccountID = id
+	}
+
+	return
+}
+
+// List returns metadata for all ApiKeys, optionally only those with the specified grant.
+// Use WithSubaccount, or SubaccountApiKeys, to list the keys of a subaccount.
+func (c *Client) ApiKeys(grant string) ([]ApiKey, *Response, error) {
+	path := fmt.Sprintf(apiKeysPathFormat, c.Config.ApiVersion)
+	res, err := c.doJSON(context.Background(), "GET", NewParams().Set("grant", grant).Url(c.Config.BaseUrl+path), nil, "ApiKey", "list")
+	if err != nil {
+		return nil, res, err
+	}
+
+	var list []ApiKey
+	if err = res.DecodeResults(&list); err != nil {
+		return nil, res, err
+	}
+	return list, res, nil
+}
+
+// ApiKey returns metadata for the ApiKey with the specified id.
+func (c *Client) ApiKey(id string) (*ApiKey, *Response, error) {
+	if id == "" {
+		return nil, nil, fmt.Errorf("Retrieve called with blank id")
+	}
+
+	path := fmt.Sprintf(apiKeysPathFormat, c.Config.ApiVersion)
+	res, err := c.doJSON(context.Background(), "GET", fmt.Sprintf("%s%s/%s", c.Config.BaseUrl, path, id), nil, "ApiKey", "retrieve")
+	if err != nil {
+		return nil, res, err
+	}
+
+	k := &ApiKey{}
+	if err = res.DecodeResults(k); err != nil {
+		return nil, res, err
+	}
+	if k.ID == "" {
+		k.ID = id
+	}
+	return k, res, nil
+}
+
+// Delete removes the ApiKey with the specified id. Requests using it fail from then on.
+func (c *Client) ApiKeyDelete(id string) (res *Response, err error) {
+	if id == "" {
+		err = fmt.Errorf("Delete called with blank id")
+		return
+	}
+
+	path := fmt.Sprintf(apiKeysPathFormat, c.Config.ApiVersion)
+	res, err = c.doJSON(context.Background(), "DELETE", fmt.Sprintf("%s%s/%s", c.Config.BaseUrl, path, id), nil, "ApiKey", "delete")
+	return
+}
+
+// SubaccountApiKeyCreate creates k as a key of the specified subaccount.
+// Subaccount keys may only have the grants allowed for subaccounts.
+func (c *Client) SubaccountApiKeyCreate(subaccountID int, k *ApiKey) (*Response, error) {
+	if subaccountID == 0 {
+		return nil, fmt.Errorf("SubaccountApiKeyCreate called with zero subaccount id")
+	}
+	return c.WithSubaccount(subaccountID).ApiKeyCreate(k)
+}
+
+// SubaccountApiKeys returns metadata for the ApiKeys of the specified subaccount.
+func (c *Client) SubaccountApiKeys(subaccountID int) ([]ApiKey, *Response, error) {
+	if subaccountID == 0 {
+		return nil, nil, fmt.Errorf("SubaccountApiKeys called with zero subaccount id")
+	}
+	return c.WithSubaccount(subaccountID).ApiKeys("")
+}
+
+func validateSubaccountGrants(grants []string) error {
+	for _, g := range grants {
+		found := false
+		for _, v := range availableGrants {
+			if g == v {
+				found = true
+			}
+		}
+		if !found {
+			return fmt.Errorf("Grant [%s] isn't available to subaccounts", g)
+		}
+	}
+	return nil
+}
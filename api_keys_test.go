@@ -0,0 +1,147 @@
+package gosparkpost
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestApiKeyCreate_subaccount(t *testing.T) {
+	testSetup(t)
+	defer testTeardown()
+
+	// set up the response handler
+	var sub string
+	var sent ApiKey
+	path := fmt.Sprintf(apiKeysPathFormat, testClient.Config.ApiVersion)
+	testMux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		sub = r.Header.Get(SubaccountHeader)
+		json.NewDecoder(r.Body).Decode(&sent)
+		w.Header().Set("Content-Type", "application/json; charset=utf8")
+		w.Write([]byte(`{"results": {"id": "fe7a5e4b5d5a8a5f3b1e3e0a2d7f6b3b9c8d1e2f",
+			"key": "a2c4e6g8i0k2m4o6q8s0u2w4y6a8c0e2g4i6k8m0", "label": "tenant key", "short_key": "a2c4"}}`))
+	})
+
+	k := &ApiKey{Label: "tenant key", Grants: []string{"smtp/inject", "transmissions/modify"}}
+	res, err := testClient.SubaccountApiKeyCreate(42, k)
+	if err != nil {
+		testFailVerbose(t, res, "SubaccountApiKeyCreate returned error: %v", err)
+	}
+	if sub != "42" {
+		t.Errorf("server saw subaccount header %q", sub)
+	}
+	if sent.Label != "tenant key" || len(sent.Grants) != 2 {
+		t.Errorf("server saw %+v", sent)
+	}
+	if k.Key != "a2c4e6g8i0k2m4o6q8s0u2w4y6a8c0e2g4i6k8m0" || k.ShortKey != "a2c4" || k.SubaccountID != 42 {
+		t.Errorf("created key is %+v", k)
+	}
+
+	// subaccounts can't have account-wide grants
+	if _, err = testClient.SubaccountApiKeyCreate(42, &ApiKey{Label: "bad", Grants: []string{"subaccounts/manage"}}); err == nil {
+		t.Error("SubaccountApiKeyCreate accepted a grant subaccounts can't have")
+	}
+	if _, err = testClient.ApiKeyCreate(&ApiKey{Label: "no grants"}); err == nil {
+		t.Error("ApiKeyCreate accepted a key without grants")
+	}
+
+	// the subaccount may also come from the Config, or a header set on the client
+	bad := []string{"subaccounts/manage"}
+	testClient.Config.SubaccountID = 42
+	if _, err = testClient.ApiKeyCreate(&ApiKey{Label: "bad", Grants: bad}); err == nil {
+		t.Error("ApiKeyCreate accepted a grant subaccounts can't have, with Config.SubaccountID set")
+	}
+	testClient.Config.SubaccountID = 0
+	testClient.SetHeader("x-msys-subaccount", "42")
+	if _, err = testClient.ApiKeyCreate(&ApiKey{Label: "bad", Grants: bad}); err == nil {
+		t.Error("ApiKeyCreate accepted a grant subaccounts can't have, with the subaccount header set")
+	}
+}
+
+func TestApiKeys_subaccount(t *testing.T) {
+	testSetup(t)
+	defer testTeardown()
+
+	// set up the response handler
+	var sub, query string
+	path := fmt.Sprintf(apiKeysPathFormat, testClient.Config.ApiVersion)
+	testMux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		sub = r.Header.Get(SubaccountHeader)
+		query = r.URL.RawQuery
+		w.Header().Set("Content-Type", "application/json; charset=utf8")
+		w.Write([]byte(`{"results": [{"id": "fe7a5e4b", "label": "tenant key", "subaccount_id": 42,
+			"grants": ["smtp/inject"], "short_key": "a2c4"}]}`))
+	})
+
+	list, res, err := testClient.SubaccountApiKeys(42)
+	if err != nil {
+		testFailVerbose(t, res, "SubaccountApiKeys returned error: %v", err)
+	}
+	if sub != "42" || query != "" {
+		t.Errorf("server saw subaccount %q and query %q", sub, query)
+	}
+	if len(list) != 1 || list[0].SubaccountID != 42 || list[0].ShortKey != "a2c4" {
+		t.Errorf("SubaccountApiKeys returned %+v", list)
+	}
+
+	if _, _, err = testClient.ApiKeys("smtp/inject"); err != nil {
+		t.Error(err)
+	}
+	if sub != "" || query != "grant=smtp%2Finject" {
+		t.Errorf("server saw subaccount %q and query %q", sub, query)
+	}
+}
+
+func TestApiKey_retrieveDelete(t *testing.T) {
+	testSetup(t)
+	defer testTeardown()
+
+	// set up the response handler
+	var methods []string
+	path := fmt.Sprintf(apiKeysPathFormat, testClient.Config.ApiVersion)
+	testMux.HandleFunc(path+"/fe7a5e4b", func(w http.ResponseWriter, r *http.Request) {
+		methods = append(methods, r.Method)
+		if r.Method == "DELETE" {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json; charset=utf8")
+		w.Write([]byte(`{"results": {"label": "Test Key", "grants": ["smtp/inject", "metrics/view"],
+			"valid_ips": ["10.20.30.40", "10.20.30.0/24"], "short_key": "a2c4"}}`))
+	})
+
+	k, res, err := testClient.ApiKey("fe7a5e4b")
+	if err != nil {
+		testFailVerbose(t, res, "ApiKey returned error: %v", err)
+	}
+	if k == nil || k.ID != "fe7a5e4b" || len(k.ValidIPs) != 2 || k.Grants[1] != "metrics/view" {
+		t.Errorf("ApiKey returned %+v", k)
+	}
+
+	if res, err = testClient.ApiKeyDelete("fe7a5e4b"); err != nil {
+		testFailVerbose(t, res, "ApiKeyDelete returned error: %v", err)
+	}
+	if fmt.Sprint(methods) != "[GET DELETE]" {
+		t.Errorf("server saw methods %v", methods)
+	}
+}
+
+func TestApiKey_validIPs(t *testing.T) {
+	for idx, test := range []struct {
+		ips []string
+		ok  bool
+	}{
+		{nil, true},
+		{[]string{"10.20.30.40", "10.20.30.0/24", "2001:db8::/32"}, true},
+		{[]string{"10.20.30.400"}, false},
+		{[]string{"example.com"}, false},
+	} {
+		k := &ApiKey{Label: "key", Grants: []string{"smtp/inject"}, ValidIPs: test.ips}
+		if err := k.Validate(); (err == nil) != test.ok {
+			t.Errorf("ApiKey.Validate (%d) => %v, expected ok=%t", idx, err, test.ok)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package gosparkpost
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned, without sending a request, while a CircuitBreaker is open.
+var ErrCircuitOpen = errors.New("circuit breaker is open, request not sent")
+
+// CircuitBreaker makes requests fail fast once the API looks unavailable.
+// After Threshold consecutive failures (connection errors or 5xx responses)
+// the breaker opens, and requests return ErrCircuitOpen for OpenFor.
+// Once that has elapsed a single trial request is let through; its outcome
+// decides whether the breaker closes again or stays open for another OpenFor.
+type CircuitBreaker struct {
+	Threshold int
+	OpenFor   time.Duration
+
+	mu       sync.Mutex
+	failures int
+	openedAt time.Time
+	trial    bool
+}
+
+// NewCircuitBreaker returns a CircuitBreaker which opens after threshold consecutive failures.
+func NewCircuitBreaker(threshold int, openFor time.Duration) *CircuitBreaker {
+	return &CircuitBreaker{Threshold: threshold, OpenFor: openFor}
+}
+
+// Allow reports whether a request may be sent right now.
+func (b *CircuitBreaker) Allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.openedAt.IsZero() {
+		return true
+	}
+	if b.trial || time.Since(b.openedAt) < b.OpenFor {
+		return false
+	}
+	// half-open: let one request through to see if the API has recovered
+	b.trial = true
+	return true
+}
+
+// Open reports whether the breaker is currently rejecting requests.
+// It's false once OpenFor has elapsed, until the trial request has been let through.
+func (b *CircuitBreaker) Open() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.openedAt.IsZero() {
+		return false
+	}
+	return b.trial || time.Since(b.openedAt) < b.OpenFor
+}
+
+// Success records a request that reached the API and didn't fail server-side.
+func (b *CircuitBreaker) Success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures = 0
+	b.openedAt = time.Time{}
+	b.trial = false
+}
+
+// Failure records a request that failed to connect or got a 5xx response.
+func (b *CircuitBreaker) Failure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures++
+	if b.trial || (b.Threshold > 0 && b.failures >= b.Threshold) {
+		b.openedAt = time.Now()
+		b.trial = false
+	}
+}
+
+// record updates the breaker based on the outcome of a request sent with ctx.
+// Requests the caller canceled, or which ran past the caller's deadline, say nothing about
+// the API, so they count as neither; a trial request that ends that way frees the slot
+// for the next one.
+func (b *CircuitBreaker) record(ctx context.Context, statusCode int, err error) {
+	if err != nil && (errors.Is(err, context.Canceled) || ctx.Err() != nil) {
+		b.mu.Lock()
+		b.trial = false
+		b.mu.Unlock()
+		return
+	}
+	if err != nil || statusCode >= 500 {
+		b.Failure()
+	} else {
+		b.Success()
+	}
+}
@@ -0,0 +1,98 @@
+package gosparkpost
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestCircuitBreaker_opens(t *testing.T) {
+	testSetup(t)
+	defer testTeardown()
+	testClient.Config.CircuitBreaker = NewCircuitBreaker(2, time.Hour)
+
+	calls := 0
+	testMux.HandleFunc("/outage", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "application/json; charset=utf8")
+		w.WriteHeader(http.StatusServiceUnavailable)
+		w.Write([]byte(`{"errors":[{"message":"Service Unavailable"}]}`))
+	})
+
+	u := testClient.Config.BaseUrl + "/outage"
+	for i := 0; i < 2; i++ {
+		if _, err := testClient.HttpGet(u); err != nil {
+			t.Fatalf("request %d returned error: %v", i, err)
+		}
+	}
+	if _, err := testClient.HttpGet(u); err != ErrCircuitOpen {
+		t.Fatalf("expected ErrCircuitOpen, got %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("server saw %d requests, expected 2", calls)
+	}
+}
+
+func TestCircuitBreaker_halfOpen(t *testing.T) {
+	b := NewCircuitBreaker(1, time.Millisecond)
+	b.Failure()
+	if b.Allow() {
+		t.Fatal("breaker allowed a request right after opening")
+	}
+
+	time.Sleep(2 * time.Millisecond)
+	if !b.Allow() {
+		t.Fatal("breaker didn't allow a trial request")
+	}
+	if b.Allow() {
+		t.Fatal("breaker allowed a second request while half-open")
+	}
+
+	b.Success()
+	if b.Open() || !b.Allow() {
+		t.Fatal("breaker didn't close after a successful trial")
+	}
+}
+
+func TestCircuitBreaker_openFor(t *testing.T) {
+	b := NewCircuitBreaker(1, time.Millisecond)
+	b.Failure()
+	if !b.Open() {
+		t.Fatal("breaker isn't open right after opening")
+	}
+
+	time.Sleep(2 * time.Millisecond)
+	if b.Open() {
+		t.Fatal("breaker is still open after OpenFor")
+	}
+	b.Allow()
+	if !b.Open() {
+		t.Fatal("breaker isn't open while its trial request is out")
+	}
+}
+
+func TestCircuitBreaker_canceled(t *testing.T) {
+	testSetup(t)
+	defer testTeardown()
+	testClient.Config.CircuitBreaker = NewCircuitBreaker(1, time.Hour)
+
+	testMux.HandleFunc("/slow", func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	u := testClient.Config.BaseUrl + "/slow"
+	if _, err := testClient.DoRequestContext(ctx, "GET", u, nil); err == nil {
+		t.Fatal("expected an error from a canceled request")
+	}
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := testClient.DoRequestContext(ctx, "GET", u, nil); err == nil {
+		t.Fatal("expected an error from a request past its deadline")
+	}
+	if testClient.Config.CircuitBreaker.Open() {
+		t.Error("requests the caller gave up on opened the breaker")
+	}
+}
@@ -0,0 +1,86 @@
+package gosparkpost
+
+import (
+	"container/list"
+	"fmt"
+	"strconv"
+	"sync"
+)
+
+// ClientPool hands out Clients for many tenants, either subaccounts of one master
+// account or separate API keys. All of them share a single http.Client (and so one set
+// of idle connections). At most Size tenants are kept; the least recently used one is
+// evicted to make room for a new one.
+type ClientPool struct {
+	Size int
+
+	base  Client
+	mu    sync.Mutex
+	lru   *list.List
+	items map[string]*list.Element
+}
+
+type poolEntry struct {
+	key    string
+	client *Client
+}
+
+// NewClientPool returns a ClientPool whose Clients are built from cfg, holding at most size tenants.
+func NewClientPool(cfg *Config, size int) (*ClientPool, error) {
+	if size <= 0 {
+		return nil, fmt.Errorf("ClientPool size must be positive")
+	}
+	p := &ClientPool{
+		Size:  size,
+		lru:   list.New(),
+		items: make(map[string]*list.Element),
+	}
+	if err := p.base.Init(cfg); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// Subaccount returns a Client which acts on behalf of the specified subaccount,
+// using the API key from the pool's Config.
+func (p *ClientPool) Subaccount(id int) *Client {
+	return p.get("subaccount:"+strconv.Itoa(id), func() *Client {
+		return p.base.WithSubaccount(id)
+	})
+}
+
+// ApiKey returns a Client which authenticates with the specified API key.
+// All other settings come from the pool's Config.
+func (p *ClientPool) ApiKey(key string) *Client {
+	return p.get("apikey:"+key, func() *Client {
+		cfg := *p.base.Config
+		cfg.ApiKey = key
+		return &Client{Config: &cfg, Client: p.base.Client, headers: map[string]string{}}
+	})
+}
+
+// Len returns the number of tenants currently held by the pool.
+func (p *ClientPool) Len() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.lru.Len()
+}
+
+func (p *ClientPool) get(key string, build func() *Client) *Client {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if el, ok := p.items[key]; ok {
+		p.lru.MoveToFront(el)
+		return el.Value.(*poolEntry).client
+	}
+
+	entry := &poolEntry{key: key, client: build()}
+	p.items[key] = p.lru.PushFront(entry)
+	for p.lru.Len() > p.Size {
+		oldest := p.lru.Back()
+		p.lru.Remove(oldest)
+		delete(p.items, oldest.Value.(*poolEntry).key)
+	}
+	return entry.client
+}
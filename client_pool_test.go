@@ -0,0 +1,38 @@
+package gosparkpost
+
+import (
+	"testing"
+)
+
+func TestClientPool(t *testing.T) {
+	pool, err := NewClientPool(&Config{ApiKey: "master"}, 2)
+	if err != nil {
+		t.Fatalf("NewClientPool returned error: %v", err)
+	}
+
+	a := pool.Subaccount(1)
+	if pool.Subaccount(1) != a {
+		t.Error("expected the same Client for the same subaccount")
+	}
+	if a.subaccountID != 1 || a.Config.ApiKey != "master" {
+		t.Errorf("unexpected subaccount Client: %d, %s", a.subaccountID, a.Config.ApiKey)
+	}
+
+	k := pool.ApiKey("tenant")
+	if k.Config.ApiKey != "tenant" || pool.base.Config.ApiKey != "master" {
+		t.Errorf("unexpected api key Client: %s (base %s)", k.Config.ApiKey, pool.base.Config.ApiKey)
+	}
+	if k.Client != a.Client {
+		t.Error("expected pooled Clients to share an http.Client")
+	}
+
+	// touch subaccount 1 so the api key client is least recently used
+	pool.Subaccount(1)
+	pool.Subaccount(2)
+	if pool.Len() != 2 {
+		t.Fatalf("pool holds %d tenants, expected 2", pool.Len())
+	}
+	if pool.ApiKey("tenant") == k {
+		t.Error("expected the least recently used Client to be evicted")
+	}
+}
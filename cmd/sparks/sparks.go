@@ -268,8 +268,7 @@ func main() {
 		if err != nil {
 			log.Fatal(err)
 		}
-		start := sp.RFC3339(time.Now().Add(dur))
-		tx.Options.StartTime = &start
+		tx.Options.StartTime = sp.StartTime(time.Now().Add(dur))
 	}
 
 	if *inline != false {
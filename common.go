@@ -2,27 +2,101 @@ package gosparkpost
 
 import (
 	"bytes"
+	"compress/gzip"
+	"context"
 	"crypto/tls"
+	"crypto/x509"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
-	"io/ioutil"
+	"io"
+	"net"
 	"net/http"
+	"net/http/httptrace"
 	"net/http/httputil"
+	"reflect"
 	"regexp"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	certifi "github.com/certifi/gocertifi"
 )
 
 // Config includes all information necessary to make an API request.
 type Config struct {
-	BaseUrl    string
+	BaseUrl string
+	// Region selects the preset BaseUrl for a SparkPost region ("us" or "eu"),
+	// if BaseUrl isn't set. API keys only work in the region they were created in.
+	Region     string
 	ApiKey     string
 	Username   string
 	Password   string
 	ApiVersion int
 	Verbose    bool
+
+	// AuthScheme, if set, is prepended to ApiKey in the auth header, as in "Bearer <key>".
+	// SparkPost itself expects the bare key; this is for gateways in front of the API.
+	AuthScheme string
+	// AuthHeader is the header the credentials are sent in, "Authorization" by default.
+	AuthHeader string
+
+	// SubaccountID, if non-zero, is sent as the X-MSYS-SUBACCOUNT header with every request,
+	// so a master account's API key can act on behalf of that subaccount.
+	SubaccountID int
+
+	// CorrelationHeader is the header a correlation ID set with WithCorrelationID is sent in,
+	// DefaultCorrelationHeader by default.
+	CorrelationHeader string
+
+	// Timeouts applied to the http.Client built by Init.
+	// They're ignored if the caller provides their own http.Client.
+	// A value of zero means no timeout.
+	DialTimeout           time.Duration
+	TLSHandshakeTimeout   time.Duration
+	ResponseHeaderTimeout time.Duration
+	Timeout               time.Duration
+
+	// Connection reuse settings for the http.Client built by Init.
+	// Zero MaxIdleConns and IdleConnTimeout use the values of http.DefaultTransport,
+	// 100 connections idle for up to 90 seconds, and a zero MaxIdleConnsPerHost
+	// means http.DefaultMaxIdleConnsPerHost.
+	MaxIdleConns        int
+	MaxIdleConnsPerHost int
+	IdleConnTimeout     time.Duration
+	DisableKeepAlives   bool
+	// EnableHTTP2 allows HTTP/2 to be negotiated with the API.
+	EnableHTTP2 bool
+
+	// Request bodies of at least GzipThreshold bytes are sent gzip-compressed.
+	// A value of zero disables request compression.
+	GzipThreshold int
+
+	// ErrorBodyLimit caps how many bytes of the response body are included in error messages.
+	// Zero means DefaultErrorBodyLimit, and a negative value means no limit.
+	ErrorBodyLimit int
+
+	// StrictDecoding makes decoding fail when the API returns fields this package doesn't know about,
+	// for results decoded with DecodeResults or DoJSON, iterators, and streamed message events.
+	// It's meant for integration tests, to catch API changes early.
+	StrictDecoding bool
+
+	// CircuitBreaker, if set, is consulted before every request made using this Config.
+	CircuitBreaker *CircuitBreaker
+
+	// Stats, if set, is called after every request made using this Config.
+	Stats StatsHook
+
+	// Tracer, if set, starts a span around every request made using this Config.
+	Tracer Tracer
+
+	// MetricsCache, if set, holds the responses to metrics and Signals queries.
+	// Queries with a fresh response in the cache don't make a request.
+	MetricsCache MetricsCache
+
+	// client is built by Init and shared by every Client using this Config.
+	client *http.Client
 }
 
 // Client contains connection, configuration, and authentication information.
@@ -31,18 +105,54 @@ type Client struct {
 	Config  *Config
 	Client  *http.Client
 	headers map[string]string
+
+	// subaccountID overrides Config.SubaccountID when non-zero, see WithSubaccount.
+	subaccountID int
+	// correlationID is sent with every request when set, see WithCorrelationID.
+	correlationID string
+}
+
+// SubaccountHeader is the request header used to act on behalf of a subaccount.
+const SubaccountHeader = "X-MSYS-SUBACCOUNT"
+
+// DefaultCorrelationHeader is the header correlation IDs are sent in unless Config.CorrelationHeader is set.
+const DefaultCorrelationHeader = "X-Request-ID"
+
+// Base urls for the SparkPost regions.
+const (
+	BaseUrlUS = "https://api.sparkpost.com"
+	BaseUrlEU = "https://api.eu.sparkpost.com"
+)
+
+var regionBaseUrls = map[string]string{
+	"us": BaseUrlUS,
+	"eu": BaseUrlEU,
+}
+
+// RegionBaseUrl returns the base url for the named SparkPost region.
+func RegionBaseUrl(region string) (string, error) {
+	if u, ok := regionBaseUrls[strings.ToLower(region)]; ok {
+		return u, nil
+	}
+	return "", fmt.Errorf("Unknown SparkPost region [%s]", region)
 }
 
 var nonDigit *regexp.Regexp = regexp.MustCompile(`\D`)
 
+// sharedMu guards the lazily-built http.Client on each Config, and certPool.
+var sharedMu sync.Mutex
+var certPool *x509.CertPool
+
 // NewConfig builds a Config object using the provided map.
 func NewConfig(m map[string]string) (*Config, error) {
 	c := &Config{}
 
 	if baseurl, ok := m["baseurl"]; ok {
 		c.BaseUrl = baseurl
+	} else if region, ok := m["region"]; ok {
+		c.Region = region
 	} else {
-		return nil, fmt.Errorf("BaseUrl is required for api config")
+		return nil, fmt.Errorf("BaseUrl or Region is required for api config")
 	}
 
 	if apikey, ok := m["apikey"]; ok {
@@ -54,6 +164,16 @@ func NewConfig(m map[string]string) (*Config, error) {
 	return c, nil
 }
 
+// Envelope is the JSON structure wrapping every API response.
+type Envelope struct {
+	// Results is left undecoded by ParseResponse, see DecodeResults.
+	Results json.RawMessage `json:"results,omitempty"`
+	Errors  []Error         `json:"errors,omitempty"`
+	// Links and TotalCount are returned by list endpoints which are paged.
+	Links      Links `json:"links,omitempty"`
+	TotalCount int   `json:"total_count,omitempty"`
+}
+
 // Response contains information about the last HTTP response.
 // Helpful when an error message doesn't necessarily give the complete picture.
 // Also contains any messages emitted as a result of the Verbose config option.
@@ -61,8 +181,28 @@ type Response struct {
 	HTTP    *http.Response
 	Body    []byte
 	Verbose map[string]string
-	Results interface{} `json:"results,omitempty"`
-	Errors  []Error     `json:"errors,omitempty"`
+	// Envelope is populated by ParseResponse.
+	Envelope
+
+	// CorrelationID is the correlation ID the request was sent with, if any.
+	CorrelationID string `json:"-"`
+
+	// TTFB is the time from sending the request until the first byte of the response arrived.
+	TTFB time.Duration `json:"-"`
+	// Duration is the time taken by the request, including reading the body once ReadBody is called.
+	Duration time.Duration `json:"-"`
+	// Attempts is the number of times the request was sent.
+	// The client doesn't retry requests, so it's 1 for every request sent.
+	Attempts int `json:"-"`
+	// Cached is true if the response came from Config.MetricsCache, in which case HTTP is nil.
+	Cached bool `json:"-"`
+	start  time.Time
+
+	// bodyFor is the http.Response that Body was read from.
+	bodyFor *http.Response
+	// errorBodyLimit and strict are copied from Config.ErrorBodyLimit and Config.StrictDecoding.
+	errorBodyLimit int
+	strict         bool
 }
 
 // Error mirrors the error format returned by SparkPost APIs.
@@ -84,10 +224,17 @@ func (e Error) Json() (string, error) {
 
 // Init pulls together everything necessary to make an API request.
 // Caller may provide their own http.Client by setting it in the provided API object.
+// Otherwise, every Client initialized with the same Config shares one http.Client.
 func (api *Client) Init(cfg *Config) error {
 	// Set default values
-	if cfg.BaseUrl == "" {
-		cfg.BaseUrl = "https://api.sparkpost.com"
+	if cfg.BaseUrl == "" && cfg.Region != "" {
+		baseUrl, err := RegionBaseUrl(cfg.Region)
+		if err != nil {
+			return err
+		}
+		cfg.BaseUrl = baseUrl
+	} else if cfg.BaseUrl == "" {
+		cfg.BaseUrl = BaseUrlUS
 	} else if !strings.HasPrefix(cfg.BaseUrl, "https://") {
 		return fmt.Errorf("API base url must be https!")
 	}
@@ -98,25 +245,67 @@ func (api *Client) Init(cfg *Config) error {
 	api.headers = make(map[string]string)
 
 	if api.Client == nil {
-		// Ran into an issue where USERTrust was not recognized on OSX.
-		// The rest of this block was the fix.
+		client, err := cfg.httpClient()
+		if err != nil {
+			return err
+		}
+		api.Client = client
+	}
+
+	return nil
+}
+
+// httpClient returns the http.Client shared by all Clients initialized with this Config,
+// building it the first time it's needed. Transport settings are read from the Config
+// at that point; later changes to them have no effect on the shared client.
+func (cfg *Config) httpClient() (*http.Client, error) {
+	sharedMu.Lock()
+	defer sharedMu.Unlock()
+	if cfg.client != nil {
+		return cfg.client, nil
+	}
+
+	// Ran into an issue where USERTrust was not recognized on OSX.
+	// The rest of this block was the fix.
 
-		// load Mozilla cert pool
+	// load Mozilla cert pool, which is only done once per process
+	if certPool == nil {
 		pool, err := certifi.CACerts()
 		if err != nil {
-			return err
+			return nil, err
 		}
+		certPool = pool
+	}
 
-		// configure transport using Mozilla cert pool
-		transport := &http.Transport{
-			TLSClientConfig: &tls.Config{RootCAs: pool},
+	// idle connections are kept as http.DefaultTransport keeps them, unless configured
+	maxIdleConns, idleConnTimeout := cfg.MaxIdleConns, cfg.IdleConnTimeout
+	if def, ok := http.DefaultTransport.(*http.Transport); ok {
+		if maxIdleConns == 0 {
+			maxIdleConns = def.MaxIdleConns
+		}
+		if idleConnTimeout == 0 {
+			idleConnTimeout = def.IdleConnTimeout
 		}
+	}
 
-		// configure http client using transport
-		api.Client = &http.Client{Transport: transport}
+	// configure transport using Mozilla cert pool
+	transport := &http.Transport{
+		DialContext: (&net.Dialer{
+			Timeout: cfg.DialTimeout,
+		}).DialContext,
+		TLSClientConfig:       &tls.Config{RootCAs: certPool},
+		TLSHandshakeTimeout:   cfg.TLSHandshakeTimeout,
+		ResponseHeaderTimeout: cfg.ResponseHeaderTimeout,
+		MaxIdleConns:          maxIdleConns,
+		MaxIdleConnsPerHost:   cfg.MaxIdleConnsPerHost,
+		IdleConnTimeout:       idleConnTimeout,
+		DisableKeepAlives:     cfg.DisableKeepAlives,
+		ForceAttemptHTTP2:     cfg.EnableHTTP2,
 	}
 
-	return nil
+	// configure http client using transport
+	cfg.client = &http.Client{Transport: transport, Timeout: cfg.Timeout}
+	return cfg.client, nil
 }
 
 // SetHeader adds additional HTTP headers for every API request made from client.
@@ -130,6 +319,53 @@ func (c *Client) RemoveHeader(header string) {
 	delete(c.headers, header)
 }
 
+// WithSubaccount returns a copy of the Client which makes requests on behalf of the
+// specified subaccount, regardless of Config.SubaccountID. The copy shares the original's
+// Config and http.Client, so it's cheap enough to create for a single call:
+//
+//	id, res, err := client.WithSubaccount(123).Send(tx)
+func (c *Client) WithSubaccount(id int) *Client {
+	sub := c.clone()
+	sub.subaccountID = id
+	return sub
+}
+
+// subaccount returns the SubaccountHeader value requests are sent with, from WithSubaccount,
+// SetHeader or Config.SubaccountID in that order, or "" if they're made for the primary account.
+func (c *Client) subaccount() string {
+	if c.subaccountID != 0 {
+		return strconv.Itoa(c.subaccountID)
+	}
+	for header, value := range c.headers {
+		if http.CanonicalHeaderKey(header) == http.CanonicalHeaderKey(SubaccountHeader) {
+			return value
+		}
+	}
+	if c.Config.SubaccountID != 0 {
+		return strconv.Itoa(c.Config.SubaccountID)
+	}
+	return ""
+}
+
+// WithCorrelationID returns a copy of the Client which sends id in the Config.CorrelationHeader
+// header with every request, and records it in Response.CorrelationID, so application logs
+// can be tied to specific API calls. Like WithSubaccount, it's cheap enough to create per call.
+func (c *Client) WithCorrelationID(id string) *Client {
+	cc := c.clone()
+	cc.correlationID = id
+	return cc
+}
+
+// clone returns a copy of the Client with its own headers.
+func (c *Client) clone() *Client {
+	cc := *c
+	cc.headers = make(map[string]string, len(c.headers))
+	for header, value := range c.headers {
+		cc.headers[header] = value
+	}
+	return &cc
+}
+
 // HttpPost sends a Post request with the provided JSON payload to the specified url.
 // Query params are supported via net/url - roll your own and stringify it.
 // Authenticate using the configured API key.
@@ -158,13 +394,36 @@ func (c *Client) HttpDelete(url string) (*Response, error) {
 	return c.DoRequest("DELETE", url, nil)
 }
 
+// DoRequest sends an API request, without a deadline or cancellation.
+// See DoRequestContext.
 func (c *Client) DoRequest(method, urlStr string, data []byte) (*Response, error) {
-	req, err := http.NewRequest(method, urlStr, bytes.NewBuffer(data))
+	return c.DoRequestContext(context.Background(), method, urlStr, data)
+}
+
+// DoRequestContext sends an API request with the provided JSON payload (which may be nil)
+// to the specified url, and returns the http.Response wrapped in a Response.
+// The request is canceled if ctx is done before it completes.
+func (c *Client) DoRequestContext(ctx context.Context, method, urlStr string, data []byte) (*Response, error) {
+	body := data
+	compressed := false
+	if data != nil && c.Config.GzipThreshold > 0 && len(data) >= c.Config.GzipThreshold {
+		var err error
+		if body, err = gzipBytes(data); err != nil {
+			return nil, err
+		}
+		compressed = true
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, urlStr, bytes.NewBuffer(body))
 	if err != nil {
 		return nil, err
 	}
 
-	ares := &Response{}
+	ares := &Response{
+		CorrelationID:  c.correlationID,
+		errorBodyLimit: c.Config.ErrorBodyLimit,
+		strict:         c.Config.StrictDecoding,
+	}
 	if c.Config.Verbose {
 		if ares.Verbose == nil {
 			ares.Verbose = map[string]string{}
@@ -174,6 +433,9 @@ func (c *Client) DoRequest(method, urlStr string, data []byte) (*Response, error
 	}
 	if data != nil {
 		req.Header.Set("Content-Type", "application/json")
+		if compressed {
+			req.Header.Set("Content-Encoding", "gzip")
+		}
 
 		if c.Config.Verbose {
 			ares.Verbose["http_postdata"] = string(data)
@@ -182,16 +444,36 @@ func (c *Client) DoRequest(method, urlStr string, data []byte) (*Response, error
 
 	// TODO: set User-Agent based on gosparkpost version and possibly git's short hash
 	req.Header.Set("User-Agent", "GoSparkPost v0.1")
+	// Ask for compressed responses explicitly, since callers may supply a
+	// Transport which doesn't do this on its own.
+	req.Header.Set("Accept-Encoding", "gzip")
+
+	if c.Config.SubaccountID != 0 {
+		req.Header.Set(SubaccountHeader, strconv.Itoa(c.Config.SubaccountID))
+	}
 
 	// Forward additional headers set in client to request
 	for header, value := range c.headers {
 		req.Header.Set(header, value)
 	}
 
+	if c.subaccountID != 0 {
+		req.Header.Set(SubaccountHeader, strconv.Itoa(c.subaccountID))
+	}
+
+	if c.correlationID != "" {
+		correlationHeader := c.Config.CorrelationHeader
+		if correlationHeader == "" {
+			correlationHeader = DefaultCorrelationHeader
+		}
+		req.Header.Set(correlationHeader, c.correlationID)
+	}
+
+	authHeader, auth := c.Config.authorization()
 	if c.Config.ApiKey != "" {
-		req.Header.Set("Authorization", c.Config.ApiKey)
+		req.Header.Set(authHeader, auth)
 	} else {
-		req.Header.Add("Authorization", "Basic "+basicAuth(c.Config.Username, c.Config.Password))
+		req.Header.Add(authHeader, auth)
 	}
 
 	if c.Config.Verbose {
@@ -199,11 +481,70 @@ func (c *Client) DoRequest(method, urlStr string, data []byte) (*Response, error
 		if err != nil {
 			return ares, err
 		}
-		ares.Verbose["http_requestdump"] = string(reqBytes)
+		ares.Verbose["http_requestdump"] = c.Config.redact(string(reqBytes))
+	}
+
+	cb := c.Config.CircuitBreaker
+	if cb != nil && !cb.Allow() {
+		return ares, ErrCircuitOpen
+	}
+
+	req = req.WithContext(httptrace.WithClientTrace(req.Context(), &httptrace.ClientTrace{
+		GotFirstResponseByte: func() { ares.TTFB = time.Since(ares.start) },
+	}))
+
+	endpoint := endpointLabel(urlStr)
+	var span Span
+	if c.Config.Tracer != nil {
+		span = c.Config.Tracer.StartSpan(req, endpoint)
 	}
 
+	ares.start = time.Now()
 	res, err := c.Client.Do(req)
+	err = c.Config.redactError(err)
 	ares.HTTP = res
+	ares.Duration = time.Since(ares.start)
+	ares.Attempts = 1
+	code := 0
+	if res != nil {
+		code = res.StatusCode
+	}
+	if c.Config.Stats != nil || span != nil {
+		stats := RequestStats{
+			Method:        method,
+			Endpoint:      endpoint,
+			StatusCode:    code,
+			Duration:      ares.Duration,
+			Attempts:      ares.Attempts,
+			Err:           err,
+			CorrelationID: c.correlationID,
+		}
+		if c.Config.Stats != nil {
+			c.Config.Stats.RequestDone(stats)
+		}
+		if span != nil {
+			span.End(stats)
+		}
+	}
+	if cb != nil {
+		cb.record(ctx, code, err)
+	}
+	if err != nil {
+		return ares, err
+	}
+
+	if strings.EqualFold(res.Header.Get("Content-Encoding"), "gzip") {
+		gz, err := gzip.NewReader(res.Body)
+		if err != nil {
+			res.Body.Close()
+			return ares, err
+		}
+		res.Body = &gzipReadCloser{gz, res.Body}
+		res.Header.Del("Content-Encoding")
+		res.Header.Del("Content-Length")
+		res.ContentLength = -1
+		res.Uncompressed = true
+	}
 
 	if c.Config.Verbose {
 		ares.Verbose["http_status"] = ares.HTTP.Status
@@ -211,12 +552,106 @@ func (c *Client) DoRequest(method, urlStr string, data []byte) (*Response, error
 		if err != nil {
 			return ares, err
 		}
-		ares.Verbose["http_responsedump"] = string(bodyBytes)
+		ares.Verbose["http_responsedump"] = c.Config.redact(string(bodyBytes))
 	}
 
 	return ares, err
 }
 
+// DoJSON sends reqBody, encoded as JSON, to the specified url. A nil reqBody sends no payload.
+// The response must be JSON, and have a 2xx status code, otherwise an error is returned.
+// If respTarget isn't nil, the response body is decoded into it.
+func (c *Client) DoJSON(ctx context.Context, method, urlStr string, reqBody, respTarget interface{}) (*Response, error) {
+	res, err := c.doJSON(ctx, method, urlStr, reqBody, "", "")
+	if err != nil {
+		return res, err
+	}
+
+	if respTarget != nil {
+		if err = unmarshalJSON(res.Body, respTarget, res.strict); err != nil {
+			return res, err
+		}
+	}
+
+	return res, nil
+}
+
+// doJSON is the request path of DoJSON and the endpoint methods. It sends reqBody as DoJSON
+// does, and returns the parsed Response. A response with a non-2xx status is returned with
+// the error PrettyError gives for noun and verb, or if it gives none (or noun is blank),
+// with the Response's SPError.
+func (c *Client) doJSON(ctx context.Context, method, urlStr string, reqBody interface{}, noun, verb string) (*Response, error) {
+	var data []byte
+	if reqBody != nil {
+		var err error
+		if data, err = json.Marshal(reqBody); err != nil {
+			return nil, err
+		}
+	}
+
+	res, err := c.DoRequestContext(ctx, method, urlStr, data)
+	if err != nil {
+		return res, err
+	}
+
+	if err = res.AssertJson(); err != nil {
+		return res, err
+	}
+
+	if err = res.ParseResponse(); err != nil {
+		return res, err
+	}
+
+	if !res.success() {
+		if noun != "" {
+			if err = res.PrettyError(noun, verb); err != nil {
+				return res, err
+			}
+		}
+		return res, res.SPError()
+	}
+
+	return res, nil
+}
+
+func gzipBytes(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	if _, err := gz.Write(data); err != nil {
+		return nil, err
+	}
+	if err := gz.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// gzipReadCloser decompresses a response body, closing the underlying body when done.
+type gzipReadCloser struct {
+	*gzip.Reader
+	body io.ReadCloser
+}
+
+func (g *gzipReadCloser) Close() error {
+	g.Reader.Close()
+	return g.body.Close()
+}
+
+// authorization returns the header the credentials are sent in, and its value.
+func (c *Config) authorization() (header, value string) {
+	header = c.AuthHeader
+	if header == "" {
+		header = "Authorization"
+	}
+	switch {
+	case c.ApiKey != "" && c.AuthScheme != "":
+		return header, c.AuthScheme + " " + c.ApiKey
+	case c.ApiKey != "":
+		return header, c.ApiKey
+	}
+	return header, "Basic " + basicAuth(c.Username, c.Password)
+}
+
 func basicAuth(username, password string) string {
 	auth := username + ":" + password
 	return base64.StdEncoding.EncodeToString([]byte(auth))
@@ -225,63 +660,197 @@ func basicAuth(username, password string) string {
 // ReadBody is a convenience method that returns the http.Response body.
 // The first time this function is called, the body is read from the
 // http.Response. For subsequent calls, the cached version in
-// Response.Body is returned.
+// Response.Body is returned, as long as Response.HTTP hasn't been replaced.
 func (r *Response) ReadBody() ([]byte, error) {
 	// Calls 2+ to this function for the same http.Response will now DWIM
-	if r.Body != nil {
+	if r.Body != nil && (r.HTTP == nil || r.HTTP == r.bodyFor) {
 		return r.Body, nil
 	}
+	if r.HTTP == nil {
+		return nil, fmt.Errorf("ReadBody got nil http.Response")
+	}
 
 	defer r.HTTP.Body.Close()
-	bodyBytes, err := ioutil.ReadAll(r.HTTP.Body)
+	buf := bodyPool.Get().(*bytes.Buffer)
+	defer putBodyBuffer(buf)
+
+	_, err := buf.ReadFrom(r.HTTP.Body)
+	// copy out of the pooled buffer, since the body is cached on the Response
+	bodyBytes := make([]byte, buf.Len())
+	copy(bodyBytes, buf.Bytes())
 	r.Body = bodyBytes
+	r.bodyFor = r.HTTP
+	if !r.start.IsZero() {
+		r.Duration = time.Since(r.start)
+	}
 	return bodyBytes, err
 }
 
+// bodyPool holds buffers used to read response bodies, so their backing
+// arrays can be reused instead of regrown for every response.
+var bodyPool = sync.Pool{New: func() interface{} { return new(bytes.Buffer) }}
+
+// maxPooledBuffer keeps unusually large responses from pinning memory in the pool.
+const maxPooledBuffer = 1 << 20
+
+func putBodyBuffer(buf *bytes.Buffer) {
+	if buf.Cap() > maxPooledBuffer {
+		return
+	}
+	buf.Reset()
+	bodyPool.Put(buf)
+}
+
 // ParseResponse pulls info from JSON http responses into api.Response object.
 // It's helpful to call Response.AssertJson before calling this function.
+// An SPError is returned if a successful response carries a non-empty errors array.
 func (r *Response) ParseResponse() error {
 	body, err := r.ReadBody()
 	if err != nil {
 		return err
 	}
 
+	// 204 No Content, and other empty responses
+	if len(bytes.TrimSpace(body)) == 0 {
+		if r.success() {
+			return nil
+		}
+		return r.SPError()
+	}
+
 	err = json.Unmarshal(body, r)
 	if err != nil {
-		return fmt.Errorf("Failed to parse API response: [%s]\n%s", err, string(body))
+		e := r.SPError()
+		e.Message = fmt.Sprintf("Failed to parse API response: [%s]", err)
+		return e
+	}
+
+	// An errors array in a successful response still means something went wrong.
+	if len(r.Errors) > 0 && r.success() {
+		return r.SPError()
 	}
 
 	return nil
 }
 
+// DecodeResults unmarshals the "results" of a parsed Response into v.
+// Depending on the endpoint, "results" is either an object or an array; if v points
+// to a slice and the results are a single object, the slice gets that one element.
+func (r *Response) DecodeResults(v interface{}) error {
+	if len(r.Results) == 0 {
+		return r.unexpected("No results in response")
+	}
+	raw := r.Results
+	if rv := reflect.ValueOf(v); rv.Kind() == reflect.Ptr && rv.Elem().Kind() == reflect.Slice && resultsIsObject(raw) {
+		raw = append(append([]byte{'['}, raw...), ']')
+	}
+	if err := unmarshalJSON(raw, v, r.strict); err != nil {
+		return r.unexpected(fmt.Sprintf("Failed to decode results: [%s]", err))
+	}
+	return nil
+}
+
+// unmarshalJSON is json.Unmarshal, which with strict set rejects unknown fields.
+func unmarshalJSON(data []byte, v interface{}, strict bool) error {
+	if !strict {
+		return json.Unmarshal(data, v)
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		return err
+	}
+	if dec.More() {
+		return fmt.Errorf("unexpected data after JSON value")
+	}
+	return nil
+}
+
+// ResultsList returns the elements of the "results" of a parsed Response, without decoding them.
+// Results which are a single object are returned as a list of one.
+func (r *Response) ResultsList() ([]json.RawMessage, error) {
+	var list []json.RawMessage
+	if len(r.Results) == 0 || string(r.Results) == "null" {
+		return list, nil
+	}
+	err := r.DecodeResults(&list)
+	return list, err
+}
+
+// resultsIsObject reports whether raw holds a JSON object.
+func resultsIsObject(raw json.RawMessage) bool {
+	trimmed := bytes.TrimSpace(raw)
+	return len(trimmed) > 0 && trimmed[0] == '{'
+}
+
 // AssertJson returns an error if the provided HTTP response isn't JSON.
+// A 204 No Content response has no body to check, so it's always accepted.
+// The error is an SPError, so the status can be checked with errors.Is or errors.As.
 func (r *Response) AssertJson() error {
 	if r.HTTP == nil {
 		return fmt.Errorf("AssertJson got nil http.Response")
 	}
+	if r.HTTP.StatusCode == http.StatusNoContent {
+		return nil
+	}
 	ctype := strings.ToLower(r.HTTP.Header.Get("Content-Type"))
 	// allow things like "application/json; charset=utf-8" in addition to the bare content type
 	if !strings.HasPrefix(ctype, "application/json") {
-		return fmt.Errorf("Expected json, got [%s] with code %d", ctype, r.HTTP.StatusCode)
+		r.ReadBody()
+		e := r.SPError()
+		e.Message = fmt.Sprintf("Expected json, got [%s]", ctype)
+		return e
 	}
 	return nil
 }
 
+// success reports whether the response has a 2xx status.
+func (r *Response) success() bool {
+	return r.HTTP != nil && r.HTTP.StatusCode >= 200 && r.HTTP.StatusCode < 300
+}
+
+// conflict reports whether r has a 409 status, which some endpoints describe with
+// their own message rather than PrettyError's.
+func (r *Response) conflict() bool {
+	return r != nil && r.HTTP != nil && r.HTTP.StatusCode == http.StatusConflict
+}
+
 // PrettyError returns a human-readable error message for common http errors returned by the API.
 // The string parameters are used to customize the generated error message
 // (example: noun=template, verb=create).
+// Any errors parsed from the response are appended to the message.
+// The returned error unwraps to the Response's SPError.
 func (r *Response) PrettyError(noun, verb string) error {
 	if r.HTTP == nil {
 		return nil
 	}
+	var msg string
 	code := r.HTTP.StatusCode
 	if code == 404 {
-		return fmt.Errorf("%s does not exist, %s failed.", noun, verb)
+		msg = fmt.Sprintf("%s does not exist, %s failed.", noun, verb)
 	} else if code == 401 {
-		return fmt.Errorf("%s %s failed, permission denied. Check your API key.", noun, verb)
+		msg = fmt.Sprintf("%s %s failed, permission denied. Check your API key.", noun, verb)
 	} else if code == 403 {
 		// This is what happens if an endpoint URL gets typo'd.
-		return fmt.Errorf("%s %s failed. Are you using the right API path?", noun, verb)
+		msg = fmt.Sprintf("%s %s failed. Are you using the right API path?", noun, verb)
+	} else if code == 400 {
+		msg = fmt.Sprintf("%s %s failed, the request was invalid.", noun, verb)
+	} else if code == 409 {
+		msg = fmt.Sprintf("%s %s failed, it conflicts with the current state of the %s.", noun, verb, noun)
+	} else if code == 413 {
+		msg = fmt.Sprintf("%s %s failed, the request is too large.", noun, verb)
+	} else if code == 420 {
+		// SparkPost uses this for exceeding an account's sending limits.
+		msg = fmt.Sprintf("%s %s failed, sending limit exceeded.", noun, verb)
+	} else if code == 429 {
+		msg = fmt.Sprintf("%s %s failed, too many requests. Slow down and try again later.", noun, verb)
+	} else if code >= 500 {
+		msg = fmt.Sprintf("%s %s failed, server error (%d). Try again later.", noun, verb, code)
+	} else {
+		return nil
 	}
-	return nil
+	for _, e := range r.Errors {
+		msg += "\n" + e.Error()
+	}
+	return &prettyError{msg: msg, cause: r.SPError()}
 }
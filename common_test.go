@@ -0,0 +1,456 @@
+package gosparkpost
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestInit_timeouts(t *testing.T) {
+	cfg := &Config{
+		ApiKey:                "key",
+		TLSHandshakeTimeout:   2 * time.Second,
+		ResponseHeaderTimeout: 3 * time.Second,
+		Timeout:               4 * time.Second,
+	}
+	var client Client
+	if err := client.Init(cfg); err != nil {
+		t.Fatalf("Init returned error: %v", err)
+	}
+
+	if client.Client.Timeout != cfg.Timeout {
+		t.Errorf("client timeout is %s, expected %s", client.Client.Timeout, cfg.Timeout)
+	}
+	tx, ok := client.Client.Transport.(*http.Transport)
+	if !ok {
+		t.Fatalf("unexpected transport type %T", client.Client.Transport)
+	}
+	if tx.TLSHandshakeTimeout != cfg.TLSHandshakeTimeout {
+		t.Errorf("tls handshake timeout is %s, expected %s", tx.TLSHandshakeTimeout, cfg.TLSHandshakeTimeout)
+	}
+	if tx.ResponseHeaderTimeout != cfg.ResponseHeaderTimeout {
+		t.Errorf("response header timeout is %s, expected %s", tx.ResponseHeaderTimeout, cfg.ResponseHeaderTimeout)
+	}
+}
+
+func TestInit_transport(t *testing.T) {
+	cfg := &Config{
+		ApiKey:              "key",
+		MaxIdleConnsPerHost: 50,
+		IdleConnTimeout:     90 * time.Second,
+		EnableHTTP2:         true,
+	}
+	var client Client
+	if err := client.Init(cfg); err != nil {
+		t.Fatalf("Init returned error: %v", err)
+	}
+
+	tx, ok := client.Client.Transport.(*http.Transport)
+	if !ok {
+		t.Fatalf("unexpected transport type %T", client.Client.Transport)
+	}
+	if tx.MaxIdleConnsPerHost != cfg.MaxIdleConnsPerHost {
+		t.Errorf("max idle conns per host is %d, expected %d", tx.MaxIdleConnsPerHost, cfg.MaxIdleConnsPerHost)
+	}
+	if tx.IdleConnTimeout != cfg.IdleConnTimeout {
+		t.Errorf("idle conn timeout is %s, expected %s", tx.IdleConnTimeout, cfg.IdleConnTimeout)
+	}
+	if !tx.ForceAttemptHTTP2 {
+		t.Error("expected HTTP/2 to be enabled")
+	}
+
+	// unset settings keep idle connections as http.DefaultTransport does
+	if err := client.Init(&Config{ApiKey: "key"}); err != nil {
+		t.Fatalf("Init returned error: %v", err)
+	}
+	tx = client.Client.Transport.(*http.Transport)
+	def := http.DefaultTransport.(*http.Transport)
+	if tx.MaxIdleConns != def.MaxIdleConns || tx.IdleConnTimeout != def.IdleConnTimeout {
+		t.Errorf("default transport keeps %d idle conns for %s, expected %d for %s",
+			tx.MaxIdleConns, tx.IdleConnTimeout, def.MaxIdleConns, def.IdleConnTimeout)
+	}
+}
+
+func TestDoRequest_gzip(t *testing.T) {
+	testSetup(t)
+	defer testTeardown()
+	testClient.Config.GzipThreshold = 10
+
+	payload := []byte(`{"recipients":[{"address":"rcpt@example.com"}]}`)
+	testMux.HandleFunc("/gzip", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		if r.Header.Get("Content-Encoding") != "gzip" {
+			t.Errorf("request Content-Encoding is %q, expected gzip", r.Header.Get("Content-Encoding"))
+		}
+		gz, err := gzip.NewReader(r.Body)
+		if err != nil {
+			t.Fatalf("request body isn't gzipped: %v", err)
+		}
+		body, err := ioutil.ReadAll(gz)
+		if err != nil {
+			t.Fatalf("reading gzipped request body: %v", err)
+		}
+		if !bytes.Equal(body, payload) {
+			t.Errorf("request body is %q, expected %q", body, payload)
+		}
+
+		w.Header().Set("Content-Type", "application/json; charset=utf8")
+		w.Header().Set("Content-Encoding", "gzip")
+		zw := gzip.NewWriter(w)
+		zw.Write([]byte(`{"results":{"id":"1"}}`))
+		zw.Close()
+	})
+
+	res, err := testClient.HttpPost(testClient.Config.BaseUrl+"/gzip", payload)
+	if err != nil {
+		t.Fatalf("HttpPost returned error: %v", err)
+	}
+	body, err := res.ReadBody()
+	if err != nil {
+		testFailVerbose(t, res, "ReadBody returned error: %v", err)
+	}
+	if string(body) != `{"results":{"id":"1"}}` {
+		testFailVerbose(t, res, "response body is %q", body)
+	}
+}
+
+func TestInit_sharedClient(t *testing.T) {
+	cfg := &Config{ApiKey: "key"}
+	var a, b Client
+	if err := a.Init(cfg); err != nil {
+		t.Fatalf("Init returned error: %v", err)
+	}
+	if err := b.Init(cfg); err != nil {
+		t.Fatalf("Init returned error: %v", err)
+	}
+	if a.Client != b.Client {
+		t.Error("expected Clients initialized from the same Config to share an http.Client")
+	}
+
+	custom := &http.Client{}
+	c := Client{Client: custom}
+	if err := c.Init(cfg); err != nil {
+		t.Fatalf("Init returned error: %v", err)
+	}
+	if c.Client != custom {
+		t.Error("Init replaced a caller-provided http.Client")
+	}
+}
+
+func TestReadBody_newResponse(t *testing.T) {
+	res := &Response{HTTP: &http.Response{Body: ioutil.NopCloser(strings.NewReader("first"))}}
+	if body, err := res.ReadBody(); err != nil || string(body) != "first" {
+		t.Fatalf("ReadBody returned (%q, %v), expected first", body, err)
+	}
+
+	res.HTTP = &http.Response{Body: ioutil.NopCloser(strings.NewReader("second"))}
+	if body, err := res.ReadBody(); err != nil || string(body) != "second" {
+		t.Fatalf("ReadBody returned (%q, %v), expected second", body, err)
+	}
+}
+
+func TestDoJSON(t *testing.T) {
+	testSetup(t)
+	defer testTeardown()
+
+	testMux.HandleFunc("/json", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		var in map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+			t.Fatalf("request body isn't json: %v", err)
+		}
+		w.Header().Set("Content-Type", "application/json; charset=utf8")
+		if in["name"] == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"errors":[{"message":"name is required"}]}`))
+			return
+		}
+		w.Write([]byte(`{"results":{"id":"` + in["name"] + `-id"}}`))
+	})
+
+	var out struct {
+		Results struct {
+			ID string `json:"id"`
+		} `json:"results"`
+	}
+	u := testClient.Config.BaseUrl + "/json"
+	res, err := testClient.DoJSON(context.Background(), "POST", u, map[string]string{"name": "test"}, &out)
+	if err != nil {
+		testFailVerbose(t, res, "DoJSON returned error: %v", err)
+	}
+	if out.Results.ID != "test-id" {
+		testFailVerbose(t, res, "DoJSON decoded id %q, expected test-id", out.Results.ID)
+	}
+
+	res, err = testClient.DoJSON(context.Background(), "POST", u, map[string]string{}, &out)
+	if err == nil {
+		testFailVerbose(t, res, "DoJSON didn't return an error for a 400 response")
+	} else if len(res.Errors) != 1 {
+		testFailVerbose(t, res, "DoJSON parsed %d errors, expected 1", len(res.Errors))
+	}
+}
+
+func TestDoRequestContext_canceled(t *testing.T) {
+	testSetup(t)
+	defer testTeardown()
+
+	testMux.HandleFunc("/slow", func(w http.ResponseWriter, r *http.Request) {
+		t.Error("canceled request reached the server")
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := testClient.DoRequestContext(ctx, "GET", testClient.Config.BaseUrl+"/slow", nil); err == nil {
+		t.Fatal("expected an error for a canceled context")
+	}
+}
+
+var benchBody = bytes.Repeat([]byte(`{"recipient":"rcpt@example.com","type":"transactional"},`), 1000)
+
+func BenchmarkReadBody(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		res := &Response{HTTP: &http.Response{Body: ioutil.NopCloser(bytes.NewReader(benchBody))}}
+		if _, err := res.ReadBody(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkReadBody_readAll is the unpooled implementation ReadBody used to have, for comparison.
+func BenchmarkReadBody_readAll(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		body := ioutil.NopCloser(bytes.NewReader(benchBody))
+		if _, err := ioutil.ReadAll(body); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestWithSubaccount(t *testing.T) {
+	testSetup(t)
+	defer testTeardown()
+	testClient.Config.SubaccountID = 1
+
+	var seen []string
+	testMux.HandleFunc("/subaccount", func(w http.ResponseWriter, r *http.Request) {
+		seen = append(seen, r.Header.Get(SubaccountHeader))
+		w.Header().Set("Content-Type", "application/json; charset=utf8")
+		w.Write([]byte(`{"results":{}}`))
+	})
+
+	u := testClient.Config.BaseUrl + "/subaccount"
+	testClient.HttpGet(u)
+	testClient.WithSubaccount(2).HttpGet(u)
+	testClient.HttpGet(u)
+
+	if strings.Join(seen, ",") != "1,2,1" {
+		t.Errorf("server saw subaccount headers %v, expected [1 2 1]", seen)
+	}
+}
+
+func TestWithCorrelationID(t *testing.T) {
+	testSetup(t)
+	defer testTeardown()
+
+	var seen []string
+	testMux.HandleFunc("/correlation", func(w http.ResponseWriter, r *http.Request) {
+		seen = append(seen, r.Header.Get(DefaultCorrelationHeader)+r.Header.Get("X-Trace"))
+		w.Header().Set("Content-Type", "application/json; charset=utf8")
+		w.Write([]byte(`{"results":{}}`))
+	})
+
+	u := testClient.Config.BaseUrl + "/correlation"
+	res, err := testClient.WithCorrelationID("abc").HttpGet(u)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.CorrelationID != "abc" {
+		t.Errorf("Response.CorrelationID is %q, expected abc", res.CorrelationID)
+	}
+	testClient.HttpGet(u)
+	testClient.Config.CorrelationHeader = "X-Trace"
+	testClient.WithCorrelationID("def").HttpGet(u)
+
+	if strings.Join(seen, ",") != "abc,,def" {
+		t.Errorf("server saw correlation headers %v, expected [abc  def]", seen)
+	}
+}
+
+func TestInit_region(t *testing.T) {
+	for region, baseUrl := range map[string]string{"": BaseUrlUS, "us": BaseUrlUS, "EU": BaseUrlEU} {
+		cfg := &Config{ApiKey: "key", Region: region}
+		var client Client
+		if err := client.Init(cfg); err != nil {
+			t.Fatalf("Init returned error for region %q: %v", region, err)
+		}
+		if cfg.BaseUrl != baseUrl {
+			t.Errorf("region %q gave base url %s, expected %s", region, cfg.BaseUrl, baseUrl)
+		}
+	}
+
+	var client Client
+	if err := client.Init(&Config{ApiKey: "key", Region: "mars"}); err == nil {
+		t.Error("expected an error for an unknown region")
+	}
+}
+
+func TestDoRequest_authScheme(t *testing.T) {
+	testSetup(t)
+	defer testTeardown()
+	testClient.Config.ApiKey = "secret"
+
+	var auth, gateway string
+	testMux.HandleFunc("/auth", func(w http.ResponseWriter, r *http.Request) {
+		auth = r.Header.Get("Authorization")
+		gateway = r.Header.Get("X-Gateway-Auth")
+		w.Header().Set("Content-Type", "application/json; charset=utf8")
+		w.Write([]byte(`{"results":{}}`))
+	})
+	u := testClient.Config.BaseUrl + "/auth"
+
+	testClient.HttpGet(u)
+	if auth != "secret" {
+		t.Errorf("Authorization header is %q, expected the bare key", auth)
+	}
+
+	testClient.Config.AuthScheme = "Bearer"
+	testClient.HttpGet(u)
+	if auth != "Bearer secret" {
+		t.Errorf("Authorization header is %q, expected a bearer token", auth)
+	}
+
+	testClient.Config.AuthHeader = "X-Gateway-Auth"
+	testClient.HttpGet(u)
+	if auth != "" || gateway != "Bearer secret" {
+		t.Errorf("credentials sent as Authorization %q, X-Gateway-Auth %q", auth, gateway)
+	}
+}
+
+func TestDecodeResults_send(t *testing.T) {
+	testSetup(t)
+	defer testTeardown()
+
+	// set up the response handler
+	path := fmt.Sprintf(transmissionsPathFormat, testClient.Config.ApiVersion)
+	testMux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json; charset=utf8")
+		w.Write([]byte(`{"results": {"total_rejected_recipients": 1, "total_accepted_recipients": 2, "id": "11668787484950529"}}`))
+	})
+
+	id, res, err := testClient.Send(&Transmission{
+		Recipients: []string{"to@example.com"},
+		Content:    Content{From: "from@example.com", Subject: "subject", Text: "text"},
+	})
+	if err != nil {
+		testFailVerbose(t, res, "Send returned error: %v", err)
+	}
+	if id != "11668787484950529" {
+		testFailVerbose(t, res, "Send returned id %q", id)
+	}
+
+	var results TransmissionResults
+	if err = res.DecodeResults(&results); err != nil {
+		t.Fatal(err)
+	}
+	if results.Accepted != 2 || results.Rejected != 1 {
+		t.Errorf("unexpected results %+v", results)
+	}
+
+	if err = (&Response{}).DecodeResults(&results); err == nil {
+		t.Error("DecodeResults didn't return an error for a response without results")
+	}
+}
+
+func TestDecodeResults_shapes(t *testing.T) {
+	type item struct {
+		ID string `json:"id"`
+	}
+	for idx, test := range []struct {
+		results string
+		ids     string
+	}{
+		{`[{"id": "a"}, {"id": "b"}]`, "[a b]"},
+		{`{"id": "a"}`, "[a]"},
+		{`[]`, "[]"},
+	} {
+		res := &Response{Envelope: Envelope{Results: json.RawMessage(test.results)}}
+		var list []item
+		if err := res.DecodeResults(&list); err != nil {
+			t.Errorf("DecodeResults (%d) returned error: %v", idx, err)
+			continue
+		}
+		ids := []string{}
+		for _, it := range list {
+			ids = append(ids, it.ID)
+		}
+		if fmt.Sprint(ids) != test.ids {
+			t.Errorf("DecodeResults (%d) => %v, want %s", idx, ids, test.ids)
+		}
+
+		raw, err := res.ResultsList()
+		if err != nil || len(raw) != len(list) {
+			t.Errorf("ResultsList (%d) => %d, %v", idx, len(raw), err)
+		}
+	}
+
+	var single item
+	res := &Response{Envelope: Envelope{Results: json.RawMessage(`{"id": "a"}`)}}
+	if err := res.DecodeResults(&single); err != nil || single.ID != "a" {
+		t.Errorf("DecodeResults into a struct => %+v, %v", single, err)
+	}
+}
+
+func TestDecodeResults_strict(t *testing.T) {
+	var out struct {
+		ID string `json:"id"`
+	}
+	res := &Response{Envelope: Envelope{Results: json.RawMessage(`{"id": "a", "new_field": 1}`)}}
+	if err := res.DecodeResults(&out); err != nil || out.ID != "a" {
+		t.Errorf("DecodeResults => %+v, %v", out, err)
+	}
+	res.strict = true
+	if err := res.DecodeResults(&out); err == nil {
+		t.Error("strict DecodeResults accepted an unknown field")
+	}
+}
+
+func TestDoRequest_timing(t *testing.T) {
+	testSetup(t)
+	defer testTeardown()
+
+	testMux.HandleFunc("/slow", func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(20 * time.Millisecond)
+		w.Header().Set("Content-Type", "application/json; charset=utf8")
+		w.WriteHeader(http.StatusOK)
+		w.(http.Flusher).Flush()
+		time.Sleep(20 * time.Millisecond)
+		w.Write([]byte(`{"results":{}}`))
+	})
+
+	res, err := testClient.HttpGet(testClient.Config.BaseUrl + "/slow")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Attempts != 1 {
+		t.Errorf("Attempts is %d, expected 1", res.Attempts)
+	}
+	if res.TTFB < 20*time.Millisecond || res.Duration < res.TTFB {
+		t.Errorf("unexpected TTFB %s and Duration %s", res.TTFB, res.Duration)
+	}
+	headers := res.Duration
+	if _, err = res.ReadBody(); err != nil {
+		t.Fatal(err)
+	}
+	if res.Duration < headers+15*time.Millisecond {
+		t.Errorf("Duration %s doesn't include reading the body (%s before)", res.Duration, headers)
+	}
+}
@@ -0,0 +1,57 @@
+package gosparkpost
+
+import (
+	"context"
+	"fmt"
+)
+
+// https://developers.sparkpost.com/api/#/reference/data-privacy
+var dataPrivacyPathFormat = "/api/v%d/data-privacy/%s"
+
+// DataPrivacyRecipient is one recipient of a data privacy request.
+type DataPrivacyRecipient struct {
+	Email string `json:"email"`
+}
+
+// DataPrivacyRequest is the JSON structure accepted by the SparkPost Data Privacy API.
+type DataPrivacyRequest struct {
+	Recipients []DataPrivacyRecipient `json:"recipients"`
+	// IncludeSubaccounts applies the request to the data of all subaccounts, too.
+	// Use WithSubaccount to file the request for a single subaccount instead.
+	IncludeSubaccounts bool `json:"include_subaccounts,omitempty"`
+}
+
+// RTBFRequest files a right to be forgotten request for the recipients with the specified email addresses,
+// which removes their personal data from SparkPost.
+func (c *Client) RTBFRequest(emails []string, includeSubaccounts bool) (*Response, error) {
+	return c.dataPrivacyRequest("rtbf-request", "RTBF", emails, includeSubaccounts)
+}
+
+// OptOutRequest files an opt-out request for the recipients with the specified email addresses,
+// which adds them to the suppression list for non-transactional mail.
+func (c *Client) OptOutRequest(emails []string, includeSubaccounts bool) (*Response, error) {
+	return c.dataPrivacyRequest("opt-out-request", "Opt-out", emails, includeSubaccounts)
+}
+
+func (c *Client) dataPrivacyRequest(kind, noun string, emails []string, includeSubaccounts bool) (res *Response, err error) {
+	if len(emails) == 0 {
+		err = fmt.Errorf("%s request called with no recipients", noun)
+		return
+	}
+
+	req := DataPrivacyRequest{
+		Recipients:         make([]DataPrivacyRecipient, len(emails)),
+		IncludeSubaccounts: includeSubaccounts,
+	}
+	for i, email := range emails {
+		if email == "" {
+			err = fmt.Errorf("%s request called with blank email", noun)
+			return
+		}
+		req.Recipients[i].Email = email
+	}
+
+	path := fmt.Sprintf(dataPrivacyPathFormat, c.Config.ApiVersion, kind)
+	res, err = c.doJSON(context.Background(), "POST", c.Config.BaseUrl+path, req, noun, "request")
+	return
+}
@@ -0,0 +1,71 @@
+package gosparkpost
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestDataPrivacyRequests(t *testing.T) {
+	testSetup(t)
+	defer testTeardown()
+
+	// set up the response handlers
+	bodies := map[string]string{}
+	for _, kind := range []string{"rtbf-request", "opt-out-request"} {
+		kind := kind
+		testMux.HandleFunc(fmt.Sprintf(dataPrivacyPathFormat, testClient.Config.ApiVersion, kind), func(w http.ResponseWriter, r *http.Request) {
+			testMethod(t, r, "POST")
+			b, _ := ioutil.ReadAll(r.Body)
+			bodies[kind] = string(b)
+			w.Header().Set("Content-Type", "application/json; charset=utf8")
+			w.Write([]byte(`{"results": {"message": "Successfully received request"}}`))
+		})
+	}
+
+	res, err := testClient.RTBFRequest([]string{"foo@example.com", "bar@example.com"}, true)
+	if err != nil {
+		testFailVerbose(t, res, "RTBFRequest returned error: %v", err)
+	}
+	if bodies["rtbf-request"] != `{"recipients":[{"email":"foo@example.com"},{"email":"bar@example.com"}],"include_subaccounts":true}` {
+		t.Errorf("RTBFRequest sent %s", bodies["rtbf-request"])
+	}
+
+	if res, err = testClient.OptOutRequest([]string{"foo@example.com"}, false); err != nil {
+		testFailVerbose(t, res, "OptOutRequest returned error: %v", err)
+	}
+	if bodies["opt-out-request"] != `{"recipients":[{"email":"foo@example.com"}]}` {
+		t.Errorf("OptOutRequest sent %s", bodies["opt-out-request"])
+	}
+
+	if _, err = testClient.RTBFRequest(nil, false); err == nil {
+		t.Error("RTBFRequest accepted no recipients")
+	}
+	if _, err = testClient.OptOutRequest([]string{"foo@example.com", ""}, false); err == nil {
+		t.Error("OptOutRequest accepted a blank email")
+	}
+}
+
+func TestRTBFRequest_error(t *testing.T) {
+	testSetup(t)
+	defer testTeardown()
+
+	// set up the response handler
+	testMux.HandleFunc(fmt.Sprintf(dataPrivacyPathFormat, testClient.Config.ApiVersion, "rtbf-request"), func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json; charset=utf8")
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"errors": [{"message": "invalid params", "description": "recipients must be valid email addresses", "code": "1200"}]}`))
+	})
+
+	_, err := testClient.RTBFRequest([]string{"not an address"}, false)
+	var spErr *SPError
+	if !errors.As(err, &spErr) || spErr.StatusCode != 400 {
+		t.Errorf("RTBFRequest returned %v, expected a 400 SPError", err)
+	}
+	if err != nil && !strings.HasPrefix(err.Error(), "RTBF request failed") {
+		t.Errorf("RTBFRequest returned %q", err)
+	}
+}
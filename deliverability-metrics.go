@@ -1,14 +1,18 @@
 package gosparkpost
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"strconv"
+	"time"
 
 	URL "net/url"
 )
 
 // https://www.sparkpost.com/api#/reference/message-events
 var deliverabilityMetricPathFormat = "/api/v%d/metrics/deliverability"
+var metricsPathFormat = "/api/v%d/metrics"
 
 type DeliverabilityMetricItem struct {
 	CountInjected               int    `json:"count_injected"`
@@ -48,6 +52,8 @@ type DeliverabilityMetricItem struct {
 	WatchedDomain               string `json:"watched_domain,omitempty"`
 	Binding                     string `json:"binding,omitempty"`
 	BindingGroup                string `json:"binding_group,omitempty"`
+	IPPool                      string `json:"ip_pool,omitempty"`
+	SendingIP                   string `json:"sending_ip,omitempty"`
 }
 
 type DeliverabilityMetricEventsWrapper struct {
@@ -58,8 +64,434 @@ type DeliverabilityMetricEventsWrapper struct {
 	//{"errors":[{"param":"from","message":"From must be before to","value":"2014-07-20T09:00"},{"param":"to","message":"To must be in the format YYYY-MM-DDTHH:mm","value":"now"}]}
 }
 
+// DefaultDeliverabilityMetrics are the metrics requested when MetricsParams.Metrics is empty.
+var DefaultDeliverabilityMetrics = []string{
+	"count_injected",
+	"count_delivered",
+	"count_bounce",
+	"count_hard_bounce",
+	"count_soft_bounce",
+	"count_block_bounce",
+	"count_rendered",
+	"count_unique_rendered",
+	"count_clicked",
+	"count_unique_clicked",
+}
+
+// DefaultLinkMetrics are the metrics requested by EngagementByLinkName when MetricsParams.Metrics is empty.
+var DefaultLinkMetrics = []string{
+	"count_clicked",
+	"count_unique_clicked",
+	"count_raw_clicked_approx",
+}
+
+// MetricsParams holds the query parameters shared by the metrics endpoints.
+// From is required; other zero values are omitted from the query.
+type MetricsParams struct {
+	From time.Time
+	To   time.Time
+	// Metrics selects the counters to return, such as "count_delivered".
+	Metrics []string
+
+	Domains     []string
+	Campaigns   []string
+	Templates   []string
+	Subaccounts []int
+	SendingIPs  []string
+	// Bindings and BindingGroups are only accepted by Enterprise and on-premises deployments.
+	Bindings      []string
+	BindingGroups []string
+
+	// Timezone is the IANA name of the zone From, To and time series buckets are in,
+	// such as "America/New_York". The default is UTC.
+	Timezone string
+
+	// Limit and OrderBy apply to the endpoints which break counters down, such as
+	// DeliverabilityByDomain and BounceReasons, and aren't sent to the others.
+	// OrderBy is one of the requested Metrics; results are in descending order.
+	Limit   int
+	OrderBy string
+
+	// Precision is the size of each bucket returned by DeliverabilityTimeSeries,
+	// one of MetricsPrecisions. It isn't sent to other endpoints.
+	Precision string
+
+	// Match restricts the values returned by MetricsCampaigns, MetricsDomains and
+	// MetricsIPPools to those containing it. Other endpoints ignore it.
+	Match string
+}
+
+// MetricsPrecisions are the bucket sizes accepted by DeliverabilityTimeSeries.
+var MetricsPrecisions = []string{"1min", "5min", "15min", "hour", "12hr", "day", "week", "month"}
+
+// TimeSeriesBucket holds the deliverability counters of one bucket of a time series.
+type TimeSeriesBucket struct {
+	// Time is the start of the bucket, parsed from DeliverabilityMetricItem.TimeStamp.
+	Time time.Time
+	*DeliverabilityMetricItem
+}
+
+// Map returns the query parameters, for use with QueryDeliverabilityMetrics.
+// It includes every field which is set, so leave unset those the endpoint doesn't take.
+func (p *MetricsParams) Map() map[string]string {
+	params := p.withMetrics(DefaultDeliverabilityMetrics)
+	p.ranking(params)
+	p.precision(params)
+	return params.Map()
+}
+
+// withMetrics returns the query parameters, selecting defaults unless p.Metrics is set.
+func (p *MetricsParams) withMetrics(defaults []string) *Params {
+	metrics := p.Metrics
+	if len(metrics) == 0 {
+		metrics = defaults
+	}
+	return p.params().List("metrics", metrics)
+}
+
+// params returns the filter parameters, which all the deliverability endpoints accept.
+func (p *MetricsParams) params() *Params {
+	subaccounts := make([]string, len(p.Subaccounts))
+	for i, id := range p.Subaccounts {
+		subaccounts[i] = strconv.Itoa(id)
+	}
+
+	return p.timeRange().
+		List("domains", p.Domains).
+		List("campaigns", p.Campaigns).
+		List("templates", p.Templates).
+		List("subaccounts", subaccounts).
+		List("sending_ips", p.SendingIPs).
+		List("bindings", p.Bindings).
+		List("binding_groups", p.BindingGroups)
+}
+
+// ranking adds the order_by and limit parameters, which only the endpoints
+// breaking counters down by domain, campaign, reason and so on accept.
+func (p *MetricsParams) ranking(params *Params) {
+	params.Set("order_by", p.OrderBy)
+	if p.Limit > 0 {
+		params.Int("limit", p.Limit)
+	}
+}
+
+// precision adds the precision parameter, which only the time series endpoint accepts.
+func (p *MetricsParams) precision(params *Params) {
+	params.Set("precision", p.Precision)
+}
+
+// timeRange returns the from, to and timezone parameters.
+// From and To are given in Timezone, so the API reads them as the same instants.
+func (p *MetricsParams) timeRange() *Params {
+	params := NewParams()
+	if p.Timezone == "" {
+		return params.TimeRange(p.From, p.To)
+	}
+
+	loc, err := time.LoadLocation(p.Timezone)
+	if err != nil {
+		// validate reports this; the API rejects the timezone for callers of Map
+		loc = time.UTC
+	}
+	for key, t := range map[string]time.Time{"from": p.From, "to": p.To} {
+		if !t.IsZero() {
+			params.Set(key, t.In(loc).Format(TimeLayout))
+		}
+	}
+	return params.Set("timezone", p.Timezone)
+}
+
+// validate checks the parameters required by all metrics queries.
+func (p *MetricsParams) validate() error {
+	if p == nil || p.From.IsZero() {
+		return fmt.Errorf("Metrics queries require MetricsParams.From")
+	}
+	if p.Timezone != "" {
+		if _, err := time.LoadLocation(p.Timezone); err != nil {
+			return fmt.Errorf("Invalid metrics timezone [%s]", p.Timezone)
+		}
+	}
+	return nil
+}
+
+// Deliverability returns deliverability counters summed over all mail matching p.
+func (c *Client) Deliverability(p *MetricsParams) (*DeliverabilityMetricItem, *Response, error) {
+	list, res, err := c.deliverabilityMetrics("", p, nil)
+	if err != nil {
+		return nil, res, err
+	}
+	if len(list) == 0 {
+		return &DeliverabilityMetricItem{}, res, nil
+	}
+	return list[0], res, nil
+}
+
+// DeliverabilityByDomain returns deliverability counters for mail matching p, grouped by recipient domain.
+func (c *Client) DeliverabilityByDomain(p *MetricsParams) ([]*DeliverabilityMetricItem, *Response, error) {
+	return c.deliverabilityMetrics("domain", p, p.ranking)
+}
+
+// DeliverabilityByCampaign returns deliverability counters for mail matching p, grouped by campaign.
+func (c *Client) DeliverabilityByCampaign(p *MetricsParams) ([]*DeliverabilityMetricItem, *Response, error) {
+	return c.deliverabilityMetrics("campaign", p, p.ranking)
+}
+
+// DeliverabilityByTemplate returns deliverability counters for mail matching p, grouped by template.
+func (c *Client) DeliverabilityByTemplate(p *MetricsParams) ([]*DeliverabilityMetricItem, *Response, error) {
+	return c.deliverabilityMetrics("template", p, p.ranking)
+}
+
+// DeliverabilityByIPPool returns deliverability counters for mail matching p, grouped by IP pool.
+func (c *Client) DeliverabilityByIPPool(p *MetricsParams) ([]*DeliverabilityMetricItem, *Response, error) {
+	return c.deliverabilityMetrics("ip-pool", p, p.ranking)
+}
+
+// DeliverabilityBySendingIP returns deliverability counters for mail matching p, grouped by sending IP.
+func (c *Client) DeliverabilityBySendingIP(p *MetricsParams) ([]*DeliverabilityMetricItem, *Response, error) {
+	return c.deliverabilityMetrics("sending-ip", p, p.ranking)
+}
+
+// BounceMetric holds the bounce counters of one bounce reason, or bounce classification.
+// Reason, and Domain when grouped by domain, are empty for classifications.
+// BounceCategoryName is one of "Hard", "Soft", "Block", "Admin" and "Undetermined".
+type BounceMetric struct {
+	Reason                 string `json:"reason,omitempty"`
+	Domain                 string `json:"domain,omitempty"`
+	BounceCategoryID       int    `json:"bounce_category_id"`
+	BounceCategoryName     string `json:"bounce_category_name"`
+	BounceClassName        string `json:"bounce_class_name"`
+	BounceClassDescription string `json:"bounce_class_description"`
+	ClassificationID       int    `json:"classification_id"`
+	CountBounce            int    `json:"count_bounce"`
+	CountInbandBounce      int    `json:"count_inband_bounce"`
+	CountOutofbandBounce   int    `json:"count_outofband_bounce"`
+}
+
+// BounceReasons returns bounce counters for mail matching p, by bounce reason.
+func (c *Client) BounceReasons(p *MetricsParams) ([]*BounceMetric, *Response, error) {
+	return c.bounceMetrics("bounce-reason", p)
+}
+
+// BounceReasonsByDomain returns bounce counters for mail matching p, by bounce reason and recipient domain.
+func (c *Client) BounceReasonsByDomain(p *MetricsParams) ([]*BounceMetric, *Response, error) {
+	return c.bounceMetrics("bounce-reason/domain", p)
+}
+
+// BounceClassifications returns bounce counters for mail matching p, by bounce classification.
+func (c *Client) BounceClassifications(p *MetricsParams) ([]*BounceMetric, *Response, error) {
+	return c.bounceMetrics("bounce-classification", p)
+}
+
+func (c *Client) bounceMetrics(extraPath string, p *MetricsParams) ([]*BounceMetric, *Response, error) {
+	var list []*BounceMetric
+	res, err := c.reasonMetrics(extraPath, p, &list)
+	return list, res, err
+}
+
+// reasonMetrics requests one of the endpoints which break counters down by reason.
+// Their counters are fixed, so no metrics are selected.
+func (c *Client) reasonMetrics(extraPath string, p *MetricsParams, v interface{}) (*Response, error) {
+	if err := p.validate(); err != nil {
+		return nil, err
+	}
+	params := p.params()
+	p.ranking(params)
+	return c.metricsResults(extraPath, params, v)
+}
+
+// RejectionMetric holds the rejection count of one rejection reason, and recipient domain
+// when grouped by domain. RejectionType is for example "Policy Rejection" or "Generation Rejection".
+type RejectionMetric struct {
+	Reason              string `json:"reason"`
+	Domain              string `json:"domain,omitempty"`
+	RejectionCategoryID int    `json:"rejection_category_id"`
+	RejectionType       string `json:"rejection_type"`
+	CountRejected       int    `json:"count_rejected"`
+}
+
+// RejectionReasons returns rejection counters for mail matching p, by rejection reason.
+func (c *Client) RejectionReasons(p *MetricsParams) ([]*RejectionMetric, *Response, error) {
+	var list []*RejectionMetric
+	res, err := c.reasonMetrics("rejection-reason", p, &list)
+	return list, res, err
+}
+
+// RejectionReasonsByDomain returns rejection counters for mail matching p, by rejection reason and recipient domain.
+func (c *Client) RejectionReasonsByDomain(p *MetricsParams) ([]*RejectionMetric, *Response, error) {
+	var list []*RejectionMetric
+	res, err := c.reasonMetrics("rejection-reason/domain", p, &list)
+	return list, res, err
+}
+
+// DelayMetric holds the delay counters of one delay reason, and recipient domain when
+// grouped by domain. CountDelayedFirst counts messages delayed on their first attempt.
+type DelayMetric struct {
+	Reason            string `json:"reason"`
+	Domain            string `json:"domain,omitempty"`
+	CountDelayed      int    `json:"count_delayed"`
+	CountDelayedFirst int    `json:"count_delayed_first"`
+}
+
+// DelayReasons returns delay counters for mail matching p, by delay reason.
+func (c *Client) DelayReasons(p *MetricsParams) ([]*DelayMetric, *Response, error) {
+	var list []*DelayMetric
+	res, err := c.reasonMetrics("delay-reason", p, &list)
+	return list, res, err
+}
+
+// DelayReasonsByDomain returns delay counters for mail matching p, by delay reason and recipient domain.
+func (c *Client) DelayReasonsByDomain(p *MetricsParams) ([]*DelayMetric, *Response, error) {
+	var list []*DelayMetric
+	res, err := c.reasonMetrics("delay-reason/domain", p, &list)
+	return list, res, err
+}
+
+// LinkMetric holds the click counters of the links with one link name, the value of
+// their data-msys-linkname attribute.
+type LinkMetric struct {
+	LinkName              string `json:"link_name"`
+	CountClicked          int    `json:"count_clicked,omitempty"`
+	CountUniqueClicked    int    `json:"count_unique_clicked,omitempty"`
+	CountRawClickedApprox int    `json:"count_raw_clicked_approx,omitempty"`
+}
+
+// EngagementByLinkName returns click counters for mail matching p, grouped by link name.
+// p.Limit and p.OrderBy apply.
+func (c *Client) EngagementByLinkName(p *MetricsParams) ([]*LinkMetric, *Response, error) {
+	if err := p.validate(); err != nil {
+		return nil, nil, err
+	}
+
+	params := p.withMetrics(DefaultLinkMetrics)
+	p.ranking(params)
+	var list []*LinkMetric
+	res, err := c.metricsResults("link-name", params, &list)
+	return list, res, err
+}
+
+// DeliverabilityTimeSeries returns deliverability counters for mail matching p,
+// in buckets of p.Precision, oldest first.
+func (c *Client) DeliverabilityTimeSeries(p *MetricsParams) ([]TimeSeriesBucket, *Response, error) {
+	if p != nil && p.Precision != "" {
+		found := false
+		for _, v := range MetricsPrecisions {
+			if p.Precision == v {
+				found = true
+			}
+		}
+		if !found {
+			return nil, nil, fmt.Errorf("Invalid metrics precision [%s]", p.Precision)
+		}
+	}
+
+	list, res, err := c.deliverabilityMetrics("time-series", p, p.precision)
+	if err != nil {
+		return nil, res, err
+	}
+
+	buckets := make([]TimeSeriesBucket, len(list))
+	for i, item := range list {
+		buckets[i].DeliverabilityMetricItem = item
+		if buckets[i].Time, err = time.Parse(time.RFC3339, item.TimeStamp); err != nil {
+			return nil, res, res.unexpected(fmt.Sprintf("Invalid time series timestamp [%s]", item.TimeStamp))
+		}
+	}
+	return buckets, res, nil
+}
+
+// deliverabilityMetrics is QueryDeliverabilityMetrics with typed parameters and results.
+// extra, if set, adds the parameters only the endpoint under extraPath accepts.
+func (c *Client) deliverabilityMetrics(extraPath string, p *MetricsParams, extra func(*Params)) ([]*DeliverabilityMetricItem, *Response, error) {
+	if err := p.validate(); err != nil {
+		return nil, nil, err
+	}
+
+	params := p.withMetrics(DefaultDeliverabilityMetrics)
+	if extra != nil {
+		extra(params)
+	}
+	var list []*DeliverabilityMetricItem
+	res, err := c.metricsResults(extraPath, params, &list)
+	return list, res, err
+}
+
+// MetricsCampaigns returns the campaigns with mail sent between p.From and p.To,
+// which can then be used as MetricsParams.Campaigns. p.Match and p.Limit apply.
+func (c *Client) MetricsCampaigns(p *MetricsParams) ([]string, *Response, error) {
+	return c.metricsList("campaigns", p)
+}
+
+// MetricsDomains returns the recipient domains with mail sent between p.From and p.To,
+// which can then be used as MetricsParams.Domains. p.Match and p.Limit apply.
+func (c *Client) MetricsDomains(p *MetricsParams) ([]string, *Response, error) {
+	return c.metricsList("domains", p)
+}
+
+// MetricsIPPools returns the IP pools with mail sent between p.From and p.To.
+// p.Match and p.Limit apply.
+func (c *Client) MetricsIPPools(p *MetricsParams) ([]string, *Response, error) {
+	return c.metricsList("ip-pools", p)
+}
+
+// metricsList requests one of the lists of filter values under /metrics.
+// The list is returned in the results field with the same name as the endpoint.
+func (c *Client) metricsList(field string, p *MetricsParams) ([]string, *Response, error) {
+	if err := p.validate(); err != nil {
+		return nil, nil, err
+	}
+
+	params := p.timeRange().Set("match", p.Match)
+	if p.Limit > 0 {
+		params.Int("limit", p.Limit)
+	}
+
+	path := fmt.Sprintf(metricsPathFormat, c.Config.ApiVersion)
+	var results map[string][]string
+	res, err := c.metricsGet(fmt.Sprintf("%s/%s", path, field), params, &results)
+	if err != nil {
+		return nil, res, err
+	}
+	list, ok := results[field]
+	if !ok {
+		return nil, res, res.unexpected(fmt.Sprintf("Metrics results have no %s", field))
+	}
+	return list, res, nil
+}
+
+// metricsResults requests the deliverability metrics under extraPath, and decodes the results into v.
+func (c *Client) metricsResults(extraPath string, params *Params, v interface{}) (*Response, error) {
+	path := fmt.Sprintf(deliverabilityMetricPathFormat, c.Config.ApiVersion)
+	if extraPath != "" {
+		path = fmt.Sprintf("%s/%s", path, extraPath)
+	}
+	return c.metricsGet(path, params, v)
+}
+
+// metricsGet requests path with params, and decodes the results into v.
+// Successful responses are stored in Config.MetricsCache, if set.
+func (c *Client) metricsGet(path string, params *Params, v interface{}) (*Response, error) {
+	url := params.Url(c.Config.BaseUrl + path)
+	key := c.metricsCacheKey(url)
+	if res := c.cachedResponse(key); res != nil {
+		return res, res.DecodeResults(v)
+	}
+
+	res, err := c.doJSON(context.Background(), "GET", url, nil, "", "")
+	if err != nil {
+		return res, err
+	}
+
+	if err = res.DecodeResults(v); err != nil {
+		return res, err
+	}
+	if c.Config.MetricsCache != nil {
+		c.Config.MetricsCache.Set(key, res.Body)
+	}
+	return res, nil
+}
+
 // https://developers.sparkpost.com/api/#/reference/metrics/deliverability-metrics-by-domain
-func (c *Client) QueryDeliverabilityMetrics(extraPath string, parameters map[string]string) (*DeliverabilityMetricEventsWrapper, error) {
+func (c *Client) QueryDeliverabilityMetrics(extraPath string, parameters map[string]string) (*DeliverabilityMetricEventsWrapper, *Response, error) {
 
 	var finalUrl string
 	path := fmt.Sprintf(deliverabilityMetricPathFormat, c.Config.ApiVersion)
@@ -89,38 +521,34 @@ func (c *Client) MetricEventAsString(e *DeliverabilityMetricItem) string {
 	return fmt.Sprintf("domain: %s, [%v]", e.Domain, e)
 }
 
-func doMetricsRequest(c *Client, finalUrl string) (*DeliverabilityMetricEventsWrapper, error) {
-	// Send off our request
-	res, err := c.HttpGet(finalUrl)
-	if err != nil {
-		return nil, err
-	}
-
-	// Assert that we got a JSON Content-Type back
-	if err = res.AssertJson(); err != nil {
-		return nil, err
+func doMetricsRequest(c *Client, finalUrl string) (*DeliverabilityMetricEventsWrapper, *Response, error) {
+	key := c.metricsCacheKey(finalUrl)
+	res := c.cachedResponse(key)
+	if res == nil {
+		// Send off our request
+		var err error
+		res, err = c.doJSON(context.Background(), "GET", finalUrl, nil, "", "")
+		if err != nil {
+			return nil, res, err
+		}
+		if c.Config.MetricsCache != nil {
+			c.Config.MetricsCache.Set(key, res.Body)
+		}
 	}
 
 	// Get the Content
 	bodyBytes, err := res.ReadBody()
 	if err != nil {
-		return nil, err
+		return nil, res, err
 	}
 
-	/*// DEBUG
-	err = iou.WriteFile("./events.json", bodyBytes, 0644)
-	if err != nil {
-		return nil, err
-	}
-	*/
-
 	// Parse expected response structure
 	var resMap DeliverabilityMetricEventsWrapper
 	err = json.Unmarshal(bodyBytes, &resMap)
 
 	if err != nil {
-		return nil, err
+		return nil, res, err
 	}
 
-	return &resMap, err
+	return &resMap, res, err
 }
@@ -0,0 +1,442 @@
+package gosparkpost
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestMetricsParams(t *testing.T) {
+	p := &MetricsParams{
+		From:        time.Date(2016, 2, 1, 8, 0, 0, 0, time.UTC),
+		To:          time.Date(2016, 2, 2, 8, 0, 0, 0, time.UTC),
+		Metrics:     []string{"count_injected", "count_delivered"},
+		Campaigns:   []string{"spring sale"},
+		Subaccounts: []int{101, 102},
+	}
+	out := ParamsFromMap(p.Map()).Encode()
+	if out != "campaigns=spring+sale&from=2016-02-01T08%3A00&metrics=count_injected%2Ccount_delivered&subaccounts=101%2C102&to=2016-02-02T08%3A00" {
+		t.Errorf("MetricsParams encoded as %q", out)
+	}
+
+	p = &MetricsParams{
+		From:          p.From,
+		To:            p.To,
+		Timezone:      "America/New_York",
+		SendingIPs:    []string{"10.0.0.1", "10.0.0.2"},
+		Bindings:      []string{"outbound a"},
+		BindingGroups: []string{"marketing&sales"},
+	}
+	if err := p.validate(); err != nil {
+		t.Error(err)
+	}
+	out = p.params().Encode()
+	if out != "binding_groups=marketing%26sales&bindings=outbound+a&from=2016-02-01T03%3A00&sending_ips=10.0.0.1%2C10.0.0.2&timezone=America%2FNew_York&to=2016-02-02T03%3A00" {
+		t.Errorf("MetricsParams with timezone encoded as %q", out)
+	}
+	p.Timezone = "Mars/Olympus_Mons"
+	if err := p.validate(); err == nil {
+		t.Error("validate accepted an unknown timezone")
+	}
+
+	p = &MetricsParams{From: p.From}
+	if m := p.Map()["metrics"]; m != "count_injected,count_delivered,count_bounce,count_hard_bounce,count_soft_bounce,count_block_bounce,count_rendered,count_unique_rendered,count_clicked,count_unique_clicked" {
+		t.Errorf("default metrics were %q", m)
+	}
+}
+
+func TestDeliverability(t *testing.T) {
+	testSetup(t)
+	defer testTeardown()
+
+	// set up the response handler
+	var query string
+	path := fmt.Sprintf(deliverabilityMetricPathFormat, testClient.Config.ApiVersion)
+	testMux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		query = r.URL.Query().Get("domains")
+		w.Header().Set("Content-Type", "application/json; charset=utf8")
+		w.Write([]byte(`{"results": [{"count_injected": 1000, "count_delivered": 950, "count_bounce": 50,
+			"count_rendered": 400, "count_unique_clicked": 120}]}`))
+	})
+
+	m, res, err := testClient.Deliverability(&MetricsParams{
+		From:    time.Date(2016, 2, 1, 8, 0, 0, 0, time.UTC),
+		Domains: []string{"gmail.com", "yahoo.com"},
+	})
+	if err != nil {
+		testFailVerbose(t, res, "Deliverability returned error: %v", err)
+	}
+	if query != "gmail.com,yahoo.com" {
+		t.Errorf("domains filter was %q", query)
+	}
+	if m == nil || m.CountInjected != 1000 || m.CountDelivered != 950 || m.CountBounce != 50 || m.CountUniqueClicked != 120 {
+		t.Errorf("Deliverability returned %+v", m)
+	}
+
+	if _, _, err = testClient.Deliverability(&MetricsParams{}); err == nil {
+		t.Error("Deliverability accepted params without From")
+	}
+}
+
+func TestDeliverabilityTimeSeries(t *testing.T) {
+	testSetup(t)
+	defer testTeardown()
+
+	// set up the response handler
+	var precision string
+	path := fmt.Sprintf(deliverabilityMetricPathFormat, testClient.Config.ApiVersion)
+	testMux.HandleFunc(path+"/time-series", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		precision = r.URL.Query().Get("precision")
+		w.Header().Set("Content-Type", "application/json; charset=utf8")
+		w.Write([]byte(`{"results": [
+			{"ts": "2016-02-01T08:00:00+00:00", "count_delivered": 10},
+			{"ts": "2016-02-01T09:00:00-05:00", "count_delivered": 20}
+		]}`))
+	})
+
+	from := time.Date(2016, 2, 1, 8, 0, 0, 0, time.UTC)
+	buckets, res, err := testClient.DeliverabilityTimeSeries(&MetricsParams{From: from, Precision: "hour"})
+	if err != nil {
+		testFailVerbose(t, res, "DeliverabilityTimeSeries returned error: %v", err)
+	}
+	if precision != "hour" {
+		t.Errorf("precision was %q", precision)
+	}
+	if len(buckets) != 2 {
+		t.Fatalf("DeliverabilityTimeSeries returned %d buckets, expected 2", len(buckets))
+	}
+	if !buckets[0].Time.Equal(from) || buckets[0].CountDelivered != 10 {
+		t.Errorf("first bucket was %v %+v", buckets[0].Time, buckets[0].DeliverabilityMetricItem)
+	}
+	if !buckets[1].Time.Equal(from.Add(6*time.Hour)) || buckets[1].CountDelivered != 20 {
+		t.Errorf("second bucket was %v %+v", buckets[1].Time, buckets[1].DeliverabilityMetricItem)
+	}
+
+	if _, _, err = testClient.DeliverabilityTimeSeries(&MetricsParams{From: from, Precision: "fortnight"}); err == nil {
+		t.Error("DeliverabilityTimeSeries accepted an invalid precision")
+	}
+}
+
+func TestDeliverabilityGroupBy(t *testing.T) {
+	testSetup(t)
+	defer testTeardown()
+
+	// set up the response handlers, one per facet
+	path := fmt.Sprintf(deliverabilityMetricPathFormat, testClient.Config.ApiVersion)
+	var queries []string
+	for facet, result := range map[string]string{
+		"domain":     `{"domain": "gmail.com", "count_delivered": 1}`,
+		"campaign":   `{"campaign_id": "spring sale", "count_delivered": 2}`,
+		"template":   `{"template_id": "welcome", "count_delivered": 3}`,
+		"ip-pool":    `{"ip_pool": "transactional", "count_delivered": 4}`,
+		"sending-ip": `{"sending_ip": "10.1.2.3", "count_delivered": 5}`,
+	} {
+		result := result
+		testMux.HandleFunc(path+"/"+facet, func(w http.ResponseWriter, r *http.Request) {
+			testMethod(t, r, "GET")
+			queries = append(queries, r.URL.Query().Get("limit")+" "+r.URL.Query().Get("order_by"))
+			w.Header().Set("Content-Type", "application/json; charset=utf8")
+			fmt.Fprintf(w, `{"results": [%s]}`, result)
+		})
+	}
+
+	p := &MetricsParams{
+		From:    time.Date(2016, 2, 1, 8, 0, 0, 0, time.UTC),
+		Metrics: []string{"count_delivered"},
+		Limit:   5,
+		OrderBy: "count_delivered",
+	}
+	for idx, test := range []struct {
+		fn    func(*MetricsParams) ([]*DeliverabilityMetricItem, *Response, error)
+		check func(*DeliverabilityMetricItem) bool
+	}{
+		{testClient.DeliverabilityByDomain, func(m *DeliverabilityMetricItem) bool { return m.Domain == "gmail.com" }},
+		{testClient.DeliverabilityByCampaign, func(m *DeliverabilityMetricItem) bool { return m.CampaignId == "spring sale" }},
+		{testClient.DeliverabilityByTemplate, func(m *DeliverabilityMetricItem) bool { return m.TemplateId == "welcome" }},
+		{testClient.DeliverabilityByIPPool, func(m *DeliverabilityMetricItem) bool { return m.IPPool == "transactional" }},
+		{testClient.DeliverabilityBySendingIP, func(m *DeliverabilityMetricItem) bool { return m.SendingIP == "10.1.2.3" }},
+	} {
+		list, res, err := test.fn(p)
+		if err != nil {
+			testFailVerbose(t, res, "group-by (%d) returned error: %v", idx, err)
+			continue
+		}
+		if len(list) != 1 || !test.check(list[0]) || list[0].CountDelivered != idx+1 {
+			t.Errorf("group-by (%d) returned %+v", idx, list)
+		}
+	}
+
+	for _, q := range queries {
+		if q != "5 count_delivered" {
+			t.Errorf("limit and order_by were %q", q)
+		}
+	}
+}
+
+func TestDeliverability_endpointParams(t *testing.T) {
+	testSetup(t)
+	defer testTeardown()
+
+	// set up the response handler, which records the ranking and precision parameters
+	path := fmt.Sprintf(deliverabilityMetricPathFormat, testClient.Config.ApiVersion)
+	queries := map[string]string{}
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		queries[r.URL.Path[len(path):]] = fmt.Sprintf("%s|%s|%s", q.Get("limit"), q.Get("order_by"), q.Get("precision"))
+		w.Header().Set("Content-Type", "application/json; charset=utf8")
+		w.Write([]byte(`{"results": []}`))
+	}
+	testMux.HandleFunc(path, handler)
+	testMux.HandleFunc(path+"/", handler)
+
+	p := &MetricsParams{
+		From:      time.Date(2016, 2, 1, 8, 0, 0, 0, time.UTC),
+		Limit:     5,
+		OrderBy:   "count_delivered",
+		Precision: "hour",
+	}
+	testClient.Deliverability(p)
+	testClient.DeliverabilityTimeSeries(p)
+	testClient.DeliverabilityByDomain(p)
+	testClient.BounceReasons(p)
+	testClient.EngagementByLinkName(p)
+
+	for extraPath, want := range map[string]string{
+		"":               "||",
+		"/time-series":   "||hour",
+		"/domain":        "5|count_delivered|",
+		"/bounce-reason": "5|count_delivered|",
+		"/link-name":     "5|count_delivered|",
+	} {
+		if got, ok := queries[extraPath]; !ok || got != want {
+			t.Errorf("%q was sent limit|order_by|precision %q, want %q", extraPath, got, want)
+		}
+	}
+}
+
+func TestBounceMetrics(t *testing.T) {
+	testSetup(t)
+	defer testTeardown()
+
+	// set up the response handlers
+	path := fmt.Sprintf(deliverabilityMetricPathFormat, testClient.Config.ApiVersion)
+	var metrics []string
+	reply := func(result string) func(w http.ResponseWriter, r *http.Request) {
+		return func(w http.ResponseWriter, r *http.Request) {
+			testMethod(t, r, "GET")
+			metrics = append(metrics, r.URL.Query().Get("metrics"))
+			w.Header().Set("Content-Type", "application/json; charset=utf8")
+			fmt.Fprintf(w, `{"results": [%s]}`, result)
+		}
+	}
+	testMux.HandleFunc(path+"/bounce-reason", reply(`{"reason": "550 5.1.1 Unknown user", "bounce_category_id": 1,
+		"bounce_category_name": "Hard", "bounce_class_name": "Invalid Recipient", "classification_id": 10,
+		"count_bounce": 30, "count_inband_bounce": 25, "count_outofband_bounce": 5}`))
+	testMux.HandleFunc(path+"/bounce-reason/domain", reply(`{"reason": "421 4.7.0 Try again later", "domain": "yahoo.com",
+		"bounce_category_name": "Block", "classification_id": 51, "count_bounce": 7}`))
+	testMux.HandleFunc(path+"/bounce-classification", reply(`{"bounce_category_name": "Soft",
+		"bounce_class_name": "Mailbox Full", "classification_id": 20, "count_bounce": 3}`))
+
+	p := &MetricsParams{From: time.Date(2016, 2, 1, 8, 0, 0, 0, time.UTC)}
+	reasons, res, err := testClient.BounceReasons(p)
+	if err != nil {
+		testFailVerbose(t, res, "BounceReasons returned error: %v", err)
+	}
+	if len(reasons) != 1 || reasons[0].BounceCategoryName != "Hard" || reasons[0].CountInbandBounce != 25 {
+		t.Errorf("BounceReasons returned %+v", reasons)
+	}
+
+	byDomain, res, err := testClient.BounceReasonsByDomain(p)
+	if err != nil {
+		testFailVerbose(t, res, "BounceReasonsByDomain returned error: %v", err)
+	}
+	if len(byDomain) != 1 || byDomain[0].Domain != "yahoo.com" || byDomain[0].BounceCategoryName != "Block" {
+		t.Errorf("BounceReasonsByDomain returned %+v", byDomain)
+	}
+
+	classes, res, err := testClient.BounceClassifications(p)
+	if err != nil {
+		testFailVerbose(t, res, "BounceClassifications returned error: %v", err)
+	}
+	if len(classes) != 1 || classes[0].ClassificationID != 20 || classes[0].Reason != "" {
+		t.Errorf("BounceClassifications returned %+v", classes)
+	}
+
+	// the bounce endpoints don't take a metrics selection
+	for _, m := range metrics {
+		if m != "" {
+			t.Errorf("metrics %q were sent", m)
+		}
+	}
+}
+
+func TestRejectionReasons(t *testing.T) {
+	testSetup(t)
+	defer testTeardown()
+
+	// set up the response handlers
+	path := fmt.Sprintf(deliverabilityMetricPathFormat, testClient.Config.ApiVersion)
+	testMux.HandleFunc(path+"/rejection-reason", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json; charset=utf8")
+		w.Write([]byte(`{"results": [{"reason": "550 - 5.7.1 recipient is on the suppression list",
+			"rejection_category_id": 1, "rejection_type": "Policy Rejection", "count_rejected": 12}]}`))
+	})
+	testMux.HandleFunc(path+"/rejection-reason/domain", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json; charset=utf8")
+		w.Write([]byte(`{"results": [{"reason": "550 - 5.7.1 recipient is on the suppression list",
+			"domain": "gmail.com", "rejection_type": "Policy Rejection", "count_rejected": 4}]}`))
+	})
+
+	p := &MetricsParams{From: time.Date(2016, 2, 1, 8, 0, 0, 0, time.UTC)}
+	reasons, res, err := testClient.RejectionReasons(p)
+	if err != nil {
+		testFailVerbose(t, res, "RejectionReasons returned error: %v", err)
+	}
+	if len(reasons) != 1 || reasons[0].RejectionType != "Policy Rejection" || reasons[0].CountRejected != 12 {
+		t.Errorf("RejectionReasons returned %+v", reasons)
+	}
+
+	byDomain, res, err := testClient.RejectionReasonsByDomain(p)
+	if err != nil {
+		testFailVerbose(t, res, "RejectionReasonsByDomain returned error: %v", err)
+	}
+	if len(byDomain) != 1 || byDomain[0].Domain != "gmail.com" || byDomain[0].CountRejected != 4 {
+		t.Errorf("RejectionReasonsByDomain returned %+v", byDomain)
+	}
+
+	if _, _, err = testClient.RejectionReasons(nil); err == nil {
+		t.Error("RejectionReasons accepted nil params")
+	}
+}
+
+func TestDelayReasons(t *testing.T) {
+	testSetup(t)
+	defer testTeardown()
+
+	// set up the response handlers
+	path := fmt.Sprintf(deliverabilityMetricPathFormat, testClient.Config.ApiVersion)
+	testMux.HandleFunc(path+"/delay-reason", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json; charset=utf8")
+		w.Write([]byte(`{"results": [{"reason": "454 4.7.1 Greylisted, try again later",
+			"count_delayed": 40, "count_delayed_first": 35}]}`))
+	})
+	testMux.HandleFunc(path+"/delay-reason/domain", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json; charset=utf8")
+		w.Write([]byte(`{"results": [{"reason": "421 4.7.0 [TS01] Messages from 10.1.2.3 temporarily deferred",
+			"domain": "yahoo.com", "count_delayed": 9, "count_delayed_first": 9}]}`))
+	})
+
+	p := &MetricsParams{From: time.Date(2016, 2, 1, 8, 0, 0, 0, time.UTC)}
+	reasons, res, err := testClient.DelayReasons(p)
+	if err != nil {
+		testFailVerbose(t, res, "DelayReasons returned error: %v", err)
+	}
+	if len(reasons) != 1 || reasons[0].CountDelayed != 40 || reasons[0].CountDelayedFirst != 35 {
+		t.Errorf("DelayReasons returned %+v", reasons)
+	}
+
+	byDomain, res, err := testClient.DelayReasonsByDomain(p)
+	if err != nil {
+		testFailVerbose(t, res, "DelayReasonsByDomain returned error: %v", err)
+	}
+	if len(byDomain) != 1 || byDomain[0].Domain != "yahoo.com" || byDomain[0].CountDelayed != 9 {
+		t.Errorf("DelayReasonsByDomain returned %+v", byDomain)
+	}
+}
+
+func TestEngagementByLinkName(t *testing.T) {
+	testSetup(t)
+	defer testTeardown()
+
+	// set up the response handler
+	var metrics string
+	path := fmt.Sprintf(deliverabilityMetricPathFormat, testClient.Config.ApiVersion)
+	testMux.HandleFunc(path+"/link-name", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		metrics = r.URL.Query().Get("metrics")
+		w.Header().Set("Content-Type", "application/json; charset=utf8")
+		w.Write([]byte(`{"results": [
+			{"link_name": "Sale banner", "count_clicked": 60, "count_unique_clicked": 45, "count_raw_clicked_approx": 70},
+			{"link_name": "Unsubscribe", "count_clicked": 3, "count_unique_clicked": 3, "count_raw_clicked_approx": 3}
+		]}`))
+	})
+
+	p := &MetricsParams{From: time.Date(2016, 2, 1, 8, 0, 0, 0, time.UTC)}
+	links, res, err := testClient.EngagementByLinkName(p)
+	if err != nil {
+		testFailVerbose(t, res, "EngagementByLinkName returned error: %v", err)
+	}
+	if metrics != "count_clicked,count_unique_clicked,count_raw_clicked_approx" {
+		t.Errorf("metrics were %q", metrics)
+	}
+	if len(links) != 2 || links[0].LinkName != "Sale banner" || links[0].CountRawClickedApprox != 70 {
+		t.Errorf("EngagementByLinkName returned %+v", links)
+	}
+
+	p.Metrics = []string{"count_unique_clicked"}
+	if _, _, err = testClient.EngagementByLinkName(p); err != nil {
+		t.Error(err)
+	}
+	if metrics != "count_unique_clicked" {
+		t.Errorf("selected metrics were %q", metrics)
+	}
+}
+
+func TestMetricsLists(t *testing.T) {
+	testSetup(t)
+	defer testTeardown()
+
+	// set up the response handlers
+	var query url.Values
+	path := fmt.Sprintf(metricsPathFormat, testClient.Config.ApiVersion)
+	for field, body := range map[string]string{
+		"campaigns": `{"results": {"campaigns": ["Summer Sale", "Black Friday"]}}`,
+		"domains":   `{"results": {"domains": ["gmail.com", "yahoo.com"]}}`,
+		"ip-pools":  `{"results": {"ip-pools": ["default", "marketing"]}}`,
+	} {
+		body := body
+		testMux.HandleFunc(path+"/"+field, func(w http.ResponseWriter, r *http.Request) {
+			testMethod(t, r, "GET")
+			query = r.URL.Query()
+			w.Header().Set("Content-Type", "application/json; charset=utf8")
+			w.Write([]byte(body))
+		})
+	}
+
+	p := &MetricsParams{
+		From:      time.Date(2016, 2, 1, 8, 0, 0, 0, time.UTC),
+		Campaigns: []string{"ignored"},
+		Match:     "Sale",
+		Limit:     5,
+	}
+	campaigns, res, err := testClient.MetricsCampaigns(p)
+	if err != nil {
+		testFailVerbose(t, res, "MetricsCampaigns returned error: %v", err)
+	}
+	if len(campaigns) != 2 || campaigns[0] != "Summer Sale" {
+		t.Errorf("MetricsCampaigns returned %v", campaigns)
+	}
+	if query.Get("from") != "2016-02-01T08:00" || query.Get("match") != "Sale" || query.Get("limit") != "5" {
+		t.Errorf("query was %v", query)
+	}
+	if _, ok := query["campaigns"]; ok {
+		t.Errorf("campaigns filter was sent to a list endpoint: %v", query)
+	}
+
+	domains, _, err := testClient.MetricsDomains(p)
+	if err != nil || len(domains) != 2 || domains[1] != "yahoo.com" {
+		t.Errorf("MetricsDomains returned %v (%v)", domains, err)
+	}
+	pools, _, err := testClient.MetricsIPPools(p)
+	if err != nil || len(pools) != 2 || pools[1] != "marketing" {
+		t.Errorf("MetricsIPPools returned %v (%v)", pools, err)
+	}
+
+	if _, _, err = testClient.MetricsDomains(&MetricsParams{}); err == nil {
+		t.Error("MetricsDomains accepted a zero From")
+	}
+}
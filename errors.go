@@ -0,0 +1,229 @@
+package gosparkpost
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+)
+
+// DefaultErrorBodyLimit is the number of bytes of a response body included in error messages,
+// unless Config.ErrorBodyLimit says otherwise.
+const DefaultErrorBodyLimit = 512
+
+// Errors returned by API calls can be matched against these with errors.Is,
+// according to the response status or the SparkPost error codes in the response:
+//
+//	if errors.Is(err, gosparkpost.ErrRateLimited) {
+//		// back off and try again later
+//	}
+var (
+	ErrNotFound     = errors.New("not found")
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrForbidden    = errors.New("forbidden")
+	// ErrRateLimited matches 429, and 420 which SparkPost returns when a sending limit is exceeded.
+	ErrRateLimited = errors.New("rate limited")
+	ErrServerError = errors.New("server error")
+)
+
+// statusError returns the sentinel error matching an HTTP status code, or nil.
+func statusError(code int) error {
+	switch {
+	case code == 404:
+		return ErrNotFound
+	case code == 401:
+		return ErrUnauthorized
+	case code == 403:
+		return ErrForbidden
+	case code == 420 || code == 429:
+		return ErrRateLimited
+	case code >= 500:
+		return ErrServerError
+	}
+	return nil
+}
+
+// codeErrors maps the documented SparkPost error codes onto the sentinel errors they mean,
+// for responses whose status alone doesn't say.
+var codeErrors = map[string]error{
+	"1600": ErrNotFound,    // resource not found
+	"1601": ErrNotFound,    // subresource not found
+	"2101": ErrRateLimited, // daily sending limit exceeded
+	"2102": ErrRateLimited, // hourly sending limit exceeded
+}
+
+// Error returns the SparkPost error code, message and description, so an Error
+// can be used as a Go error.
+func (e Error) Error() string {
+	msg := e.Message
+	if e.Code != "" {
+		msg = e.Code + ": " + msg
+	}
+	if e.Description != "" {
+		msg += "\n" + e.Description
+	}
+	return msg
+}
+
+// SPError is the error returned when the API responds with an error status.
+// Use errors.As to get at the details:
+//
+//	var spErr *gosparkpost.SPError
+//	if errors.As(err, &spErr) && spErr.Code == "1902" {
+//		// handle the specific SparkPost error code
+//	}
+type SPError struct {
+	StatusCode int
+	// Code, Message and Description come from the first of Errors, if there are any.
+	// Otherwise Message may describe why the response couldn't be parsed.
+	Code        string
+	Message     string
+	Description string
+	Errors      []Error
+	// Body is the raw response body. Error messages include at most Config.ErrorBodyLimit bytes of it.
+	Body []byte
+
+	bodyLimit int
+}
+
+func (e *SPError) Error() string {
+	if len(e.Errors) > 0 {
+		return fmt.Sprintf("%d: %s", e.StatusCode, e.Errors[0].Error())
+	} else if e.Message != "" {
+		return fmt.Sprintf("%d: %s\n%s", e.StatusCode, e.Message, truncateBody(e.Body, e.bodyLimit))
+	}
+	return fmt.Sprintf("%d: %s", e.StatusCode, truncateBody(e.Body, e.bodyLimit))
+}
+
+// Is reports whether the response status, or the code of any of Errors, matches target,
+// one of ErrNotFound, ErrUnauthorized, ErrForbidden, ErrRateLimited or ErrServerError.
+func (e *SPError) Is(target error) bool {
+	if sentinel := statusError(e.StatusCode); sentinel != nil && sentinel == target {
+		return true
+	}
+	for _, err := range e.Errors {
+		if sentinel := codeErrors[err.Code]; sentinel != nil && sentinel == target {
+			return true
+		}
+	}
+	return false
+}
+
+// Retryable reports whether the request may succeed if it's sent again later:
+// rate limiting, request timeouts and server errors.
+// Anything else, such as an invalid request or bad credentials, is permanent.
+func (e *SPError) Retryable() bool {
+	switch {
+	case e.StatusCode == 408, e.StatusCode == 420, e.StatusCode == 429:
+		return true
+	case e.StatusCode >= 500 && e.StatusCode != 501:
+		return true
+	}
+	return false
+}
+
+// IsRetryable reports whether err, returned by an API call, is worth retrying later.
+// Besides SPError, timeouts and temporary network errors, and ErrCircuitOpen are
+// retryable. Canceled requests are not, nor are errors which aren't recognized.
+func IsRetryable(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+	var spErr *SPError
+	if errors.As(err, &spErr) {
+		return spErr.Retryable()
+	}
+	if errors.Is(err, ErrCircuitOpen) || errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return netErr.Timeout()
+	}
+	return false
+}
+
+// prettyError is returned by PrettyError. It keeps its friendlier message, while still
+// unwrapping to the SPError it describes.
+type prettyError struct {
+	msg   string
+	cause *SPError
+}
+
+func (e *prettyError) Error() string { return e.msg }
+
+func (e *prettyError) Unwrap() error { return e.cause }
+
+// SPError returns an SPError describing the Response, which should already have been parsed.
+func (r *Response) SPError() *SPError {
+	e := &SPError{Errors: r.Errors, Body: r.Body, bodyLimit: r.errorBodyLimit}
+	if r.HTTP != nil {
+		e.StatusCode = r.HTTP.StatusCode
+	}
+	if len(r.Errors) > 0 {
+		e.Code = r.Errors[0].Code
+		e.Message = r.Errors[0].Message
+		e.Description = r.Errors[0].Description
+	}
+	return e
+}
+
+// unexpected returns an error for a response which didn't have the expected structure,
+// including the status and (some of) the body.
+func (r *Response) unexpected(msg string) error {
+	code := 0
+	if r.HTTP != nil {
+		code = r.HTTP.StatusCode
+	}
+	return fmt.Errorf("%s: %d: %s", msg, code, truncateBody(r.Body, r.errorBodyLimit))
+}
+
+// truncateBody returns body as a string, cut down to limit bytes; see Config.ErrorBodyLimit.
+func truncateBody(body []byte, limit int) string {
+	if limit == 0 {
+		limit = DefaultErrorBodyLimit
+	}
+	if limit < 0 || len(body) <= limit {
+		return string(body)
+	}
+	return fmt.Sprintf("%s... (%d more bytes)", body[:limit], len(body)-limit)
+}
+
+// redacted replaces credentials in error messages and debug output.
+const redacted = "[REDACTED]"
+
+// redact replaces any credentials from the Config which appear in s.
+func (cfg *Config) redact(s string) string {
+	secrets := []string{cfg.ApiKey, cfg.Password}
+	if cfg.Username != "" || cfg.Password != "" {
+		secrets = append(secrets, basicAuth(cfg.Username, cfg.Password))
+	}
+	for _, secret := range secrets {
+		if secret != "" {
+			s = strings.Replace(s, secret, redacted, -1)
+		}
+	}
+	return s
+}
+
+// redactError returns err, or if its message contains credentials, an error
+// with the credentials removed from its message which still unwraps to err.
+func (cfg *Config) redactError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if msg := cfg.redact(err.Error()); msg != err.Error() {
+		return &redactedError{msg: msg, err: err}
+	}
+	return err
+}
+
+type redactedError struct {
+	msg string
+	err error
+}
+
+func (e *redactedError) Error() string { return e.msg }
+
+func (e *redactedError) Unwrap() error { return e.err }
@@ -0,0 +1,317 @@
+package gosparkpost
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestSPError(t *testing.T) {
+	testSetup(t)
+	defer testTeardown()
+
+	// set up the response handler
+	path := fmt.Sprintf(transmissionsPathFormat, testClient.Config.ApiVersion)
+	body := `{"errors": [{"message": "Invalid data", "code": "1300", "description": "no recipients"}]}`
+	testMux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json; charset=utf8")
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		w.Write([]byte(body))
+	})
+
+	_, _, err := testClient.Send(&Transmission{
+		Recipients: []string{"to@example.com"},
+		Content:    Content{From: "from@example.com", Subject: "subject", Text: "text"},
+	})
+	if err == nil {
+		t.Fatal("Send didn't return an error")
+	}
+	var spErr *SPError
+	if !errors.As(err, &spErr) {
+		t.Fatalf("Send returned %T, expected *SPError", err)
+	}
+	if spErr.StatusCode != 422 || spErr.Code != "1300" || spErr.Description != "no recipients" || string(spErr.Body) != body {
+		t.Errorf("unexpected SPError %+v", spErr)
+	}
+	if err.Error() != "422: 1300: Invalid data\nno recipients" {
+		t.Errorf("unexpected message %q", err.Error())
+	}
+}
+
+func TestSPError_noErrors(t *testing.T) {
+	e := &SPError{StatusCode: 502, Body: []byte("Bad Gateway")}
+	if e.Error() != "502: Bad Gateway" {
+		t.Errorf("unexpected message %q", e.Error())
+	}
+}
+
+func TestTruncateBody(t *testing.T) {
+	long := []byte(strings.Repeat("x", DefaultErrorBodyLimit+10))
+	for idx, test := range []struct {
+		body  []byte
+		limit int
+		out   string
+	}{
+		{[]byte("short"), 0, "short"},
+		{[]byte("0123456789"), 4, "0123... (6 more bytes)"},
+		{long, -1, string(long)},
+		{long, 0, strings.Repeat("x", DefaultErrorBodyLimit) + "... (10 more bytes)"},
+	} {
+		if out := truncateBody(test.body, test.limit); out != test.out {
+			t.Errorf("truncateBody (%d) => %q, want %q", idx, out, test.out)
+		}
+	}
+}
+
+func TestSPError_bodyLimit(t *testing.T) {
+	testSetup(t)
+	defer testTeardown()
+	testClient.Config.ErrorBodyLimit = 8
+
+	// set up the response handler
+	path := fmt.Sprintf(transmissionsPathFormat, testClient.Config.ApiVersion)
+	testMux.HandleFunc(path+"/123", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json; charset=utf8")
+		w.WriteHeader(http.StatusBadGateway)
+		w.Write([]byte(`{"message": "upstream went away"}`))
+	})
+
+	_, _, err := testClient.Transmission("123")
+	var spErr *SPError
+	if !errors.As(err, &spErr) || spErr.Error() != `502: {"messag... (25 more bytes)` {
+		t.Errorf("unexpected error %v", err)
+	}
+}
+
+func TestSPError_is(t *testing.T) {
+	for _, test := range []struct {
+		code int
+		is   error
+	}{
+		{404, ErrNotFound},
+		{401, ErrUnauthorized},
+		{403, ErrForbidden},
+		{420, ErrRateLimited},
+		{429, ErrRateLimited},
+		{500, ErrServerError},
+		{503, ErrServerError},
+		{400, nil},
+	} {
+		err := error(&SPError{StatusCode: test.code})
+		for _, sentinel := range []error{ErrNotFound, ErrUnauthorized, ErrForbidden, ErrRateLimited, ErrServerError} {
+			if errors.Is(err, sentinel) != (sentinel == test.is) {
+				t.Errorf("errors.Is(%d, %v) => %t", test.code, sentinel, !(sentinel == test.is))
+			}
+		}
+	}
+}
+
+func TestSPError_isCode(t *testing.T) {
+	for _, test := range []struct {
+		status int
+		code   string
+		is     error
+	}{
+		{400, "1600", ErrNotFound},
+		{400, "1601", ErrNotFound},
+		{400, "2101", ErrRateLimited},
+		{400, "2102", ErrRateLimited},
+		{400, "1602", nil},
+		{400, "", nil},
+	} {
+		err := error(&SPError{StatusCode: test.status, Errors: []Error{{Code: test.code}}})
+		for _, sentinel := range []error{ErrNotFound, ErrUnauthorized, ErrForbidden, ErrRateLimited, ErrServerError} {
+			if errors.Is(err, sentinel) != (sentinel == test.is) {
+				t.Errorf("errors.Is(%d/%s, %v) => %t", test.status, test.code, sentinel, !(sentinel == test.is))
+			}
+		}
+	}
+
+	// the status and the codes are both matched
+	err := error(&SPError{StatusCode: 503, Errors: []Error{{Code: "1602"}, {Code: "2102"}}})
+	if !errors.Is(err, ErrServerError) || !errors.Is(err, ErrRateLimited) {
+		t.Errorf("errors.Is didn't match both the status and a later code of %v", err)
+	}
+}
+
+func TestPrettyError_is(t *testing.T) {
+	testSetup(t)
+	defer testTeardown()
+
+	// set up the response handler
+	path := fmt.Sprintf(transmissionsPathFormat, testClient.Config.ApiVersion)
+	testMux.HandleFunc(path+"/123", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json; charset=utf8")
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"errors": [{"message": "resource not found"}]}`))
+	})
+
+	_, _, err := testClient.Transmission("123")
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("Transmission returned %v, expected it to match ErrNotFound", err)
+	}
+	if err.Error() != "Transmission does not exist, retrieve failed.\nresource not found" {
+		t.Errorf("unexpected message %q", err.Error())
+	}
+}
+
+func TestParseResponse_errorsWithSuccess(t *testing.T) {
+	testSetup(t)
+	defer testTeardown()
+
+	// set up the response handler
+	path := fmt.Sprintf(webhookListPathFormat, testClient.Config.ApiVersion)
+	testMux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json; charset=utf8")
+		w.Write([]byte(`{"results": [], "errors": [{"message": "From must be before to", "code": "1200"}]}`))
+	})
+
+	_, res, err := testClient.ListWebhooks(nil)
+	var spErr *SPError
+	if !errors.As(err, &spErr) {
+		t.Fatalf("ListWebhooks returned %v, expected an SPError", err)
+	}
+	if spErr.StatusCode != 200 || spErr.Message != "From must be before to" || len(res.Errors) != 1 {
+		t.Errorf("unexpected SPError %+v", spErr)
+	}
+}
+
+func TestRedact(t *testing.T) {
+	testSetup(t)
+	defer testTeardown()
+	testClient.Config.ApiKey = "s3cr3t-api-key"
+	testClient.Config.AuthScheme = "Bearer"
+
+	testMux.HandleFunc("/redact", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json; charset=utf8")
+		// a proxy which echoes the request headers back
+		fmt.Fprintf(w, `{"results": {"auth": %q}}`, r.Header.Get("Authorization"))
+	})
+
+	res, err := testClient.HttpGet(testClient.Config.BaseUrl + "/redact")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for k, v := range res.Verbose {
+		if strings.Contains(v, "s3cr3t") {
+			t.Errorf("Verbose[%s] contains the API key: %s", k, v)
+		}
+	}
+	if !strings.Contains(res.Verbose["http_requestdump"], "Bearer "+redacted) {
+		t.Errorf("request dump doesn't show the redacted header: %s", res.Verbose["http_requestdump"])
+	}
+}
+
+func TestRedactError(t *testing.T) {
+	cfg := &Config{Username: "user", Password: "p4ssw0rd"}
+	cause := fmt.Errorf("proxy said: bad credentials %s", basicAuth("user", "p4ssw0rd"))
+	err := cfg.redactError(cause)
+	if strings.Contains(err.Error(), basicAuth("user", "p4ssw0rd")) {
+		t.Errorf("error contains credentials: %v", err)
+	}
+	if !errors.Is(err, cause) {
+		t.Error("redacted error doesn't unwrap to its cause")
+	}
+	if plain := errors.New("plain"); cfg.redactError(plain) != plain {
+		t.Error("error without credentials was wrapped")
+	}
+}
+
+func TestPrettyError(t *testing.T) {
+	for _, test := range []struct {
+		code int
+		msg  string
+	}{
+		{400, "Template create failed, the request was invalid.\n1300: bad"},
+		{409, "Template create failed, it conflicts with the current state of the Template.\n1300: bad"},
+		{413, "Template create failed, the request is too large.\n1300: bad"},
+		{420, "Template create failed, sending limit exceeded.\n1300: bad"},
+		{429, "Template create failed, too many requests. Slow down and try again later.\n1300: bad"},
+		{503, "Template create failed, server error (503). Try again later.\n1300: bad"},
+		{422, ""},
+	} {
+		res := &Response{HTTP: &http.Response{StatusCode: test.code}, Envelope: Envelope{Errors: []Error{{Code: "1300", Message: "bad"}}}}
+		err := res.PrettyError("Template", "create")
+		if test.msg == "" {
+			if err != nil {
+				t.Errorf("PrettyError(%d) => %v, expected nil", test.code, err)
+			}
+			continue
+		}
+		if err == nil || err.Error() != test.msg {
+			t.Errorf("PrettyError(%d) => %v, expected %q", test.code, err, test.msg)
+		}
+	}
+}
+
+func TestNoContent(t *testing.T) {
+	testSetup(t)
+	defer testTeardown()
+
+	// set up the response handler
+	path := fmt.Sprintf(transmissionsPathFormat, testClient.Config.ApiVersion)
+	testMux.HandleFunc(path+"/123", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	res, err := testClient.TransmissionDelete("123")
+	if err != nil {
+		testFailVerbose(t, res, "TransmissionDelete returned error: %v", err)
+	}
+}
+
+func TestAssertJson_html(t *testing.T) {
+	testSetup(t)
+	defer testTeardown()
+
+	// set up the response handler
+	path := fmt.Sprintf(transmissionsPathFormat, testClient.Config.ApiVersion)
+	testMux.HandleFunc(path+"/123", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.WriteHeader(http.StatusBadGateway)
+		w.Write([]byte("<html>Bad Gateway</html>"))
+	})
+
+	_, _, err := testClient.Transmission("123")
+	var spErr *SPError
+	if !errors.As(err, &spErr) {
+		t.Fatalf("Transmission returned %v, expected an SPError", err)
+	}
+	if !errors.Is(err, ErrServerError) {
+		t.Errorf("error %v doesn't match ErrServerError", err)
+	}
+	if want := "502: Expected json, got [text/html]\n<html>Bad Gateway</html>"; err.Error() != want {
+		t.Errorf("unexpected message %q", err.Error())
+	}
+}
+
+func TestIsRetryable(t *testing.T) {
+	for _, test := range []struct {
+		err       error
+		retryable bool
+	}{
+		{nil, false},
+		{&SPError{StatusCode: 429}, true},
+		{&SPError{StatusCode: 420}, true},
+		{&SPError{StatusCode: 503}, true},
+		{&SPError{StatusCode: 501}, false},
+		{&SPError{StatusCode: 400}, false},
+		{&SPError{StatusCode: 401}, false},
+		{&prettyError{msg: "pretty", cause: &SPError{StatusCode: 502}}, true},
+		{ErrCircuitOpen, true},
+		{context.DeadlineExceeded, true},
+		{context.Canceled, false},
+		{&net.DNSError{IsTimeout: true}, true},
+		{&net.DNSError{Err: "no such host"}, false},
+		{errors.New("something else"), false},
+	} {
+		if IsRetryable(test.err) != test.retryable {
+			t.Errorf("IsRetryable(%v) => %t", test.err, !test.retryable)
+		}
+	}
+}
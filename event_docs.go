@@ -1,73 +1,57 @@
 package gosparkpost
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 
-	"github.com/pkg/errors"
+	"github.com/SparkPost/gosparkpost/events"
 )
 
 var eventDocumentationFormat = "/api/v%d/webhooks/events/documentation"
+var eventSamplesFormat = "/api/v%d/webhooks/events/samples"
 
-type EventGroup struct {
-	Name        string
-	Events      map[string]EventMeta `json:"events"`
-	Description string               `json:"description"`
-	DisplayName string               `json:"display_name"`
-}
-
-type EventMeta struct {
-	Name        string
-	Fields      map[string]EventField `json:"event"`
-	Description string                `json:"description"`
-	DisplayName string                `json:"display_name"`
-}
-
-type EventField struct {
-	Description string      `json:"description"`
-	SampleValue interface{} `json:"sampleValue"`
-}
+// The event documentation types live in the events package, so webhook consumers can use them too.
+type (
+	EventGroup = events.Group
+	EventMeta  = events.Schema
+	EventField = events.Field
+)
 
-func (c *Client) EventDocumentation() (g map[string]*EventGroup, res *Response, err error) {
+// EventDocumentation returns the schema of each event type, grouped as SparkPost groups them.
+func (c *Client) EventDocumentation() (g events.Documentation, res *Response, err error) {
 	path := fmt.Sprintf(eventDocumentationFormat, c.Config.ApiVersion)
-	res, err = c.HttpGet(c.Config.BaseUrl + path)
+	res, err = c.doJSON(context.Background(), "GET", c.Config.BaseUrl+path, nil, "EventDocumentation", "retrieve")
 	if err != nil {
-		return nil, nil, err
+		return nil, res, err
 	}
 
-	if err = res.AssertJson(); err != nil {
+	var groups events.Documentation
+	if err = res.DecodeResults(&groups); err != nil {
 		return nil, res, err
 	}
+	return groups, res, nil
+}
 
-	if res.HTTP.StatusCode == 200 {
-		var body []byte
-		var ok bool
-		body, err = res.ReadBody()
-		if err != nil {
-			return nil, res, err
+// WebhookEventSamples returns one example of each of the requested event types, as they'd
+// be delivered to a webhook. All event types are returned if none are requested.
+func (c *Client) WebhookEventSamples(types []string) (events.Events, *Response, error) {
+	for _, etype := range types {
+		if !events.ValidEventType(etype) {
+			return nil, nil, fmt.Errorf("Invalid event type [%s]", etype)
 		}
+	}
 
-		var results map[string]map[string]*EventGroup
-		var groups map[string]*EventGroup
-		if err = json.Unmarshal(body, &results); err != nil {
-			return nil, res, err
-		} else if groups, ok = results["results"]; ok {
-			return groups, res, err
-		}
-		return nil, res, errors.New("Unexpected response format")
-	} else {
-		err = res.ParseResponse()
-		if err != nil {
-			return nil, res, err
-		}
-		if len(res.Errors) > 0 {
-			err = res.PrettyError("EventDocumentation", "retrieve")
-			if err != nil {
-				return nil, res, err
-			}
-		}
-		return nil, res, errors.Errorf("%d: %s", res.HTTP.StatusCode, string(res.Body))
+	path := fmt.Sprintf(eventSamplesFormat, c.Config.ApiVersion)
+	res, err := c.doJSON(context.Background(), "GET", NewParams().List("events", types).Url(c.Config.BaseUrl+path), nil, "EventSamples", "retrieve")
+	if err != nil {
+		return nil, res, err
 	}
 
-	return nil, res, err
+	// samples are "msys"-wrapped, like a webhook batch
+	var samples events.Events
+	if err = json.Unmarshal(res.Results, &samples); err != nil {
+		return nil, res, err
+	}
+	return samples, res, nil
 }
@@ -5,6 +5,8 @@ import (
 	"io/ioutil"
 	"net/http"
 	"testing"
+
+	"github.com/SparkPost/gosparkpost/events"
 )
 
 const eventDocumentationFile = "test/event-docs.json"
@@ -98,6 +100,10 @@ func TestEventDocs_Get_parse(t *testing.T) {
 			}
 		}
 
+		if s := groups.Schema("bounce"); s == nil || s.Group != "message_event" || s.Fields["rcpt_to"].Description == "" {
+			t.Errorf("bounce schema was %+v", s)
+		}
+
 		for gname, seen := range eventGroupsSeen {
 			if !seen {
 				t.Fatalf("expected message type [%s] not returned", gname)
@@ -105,3 +111,48 @@ func TestEventDocs_Get_parse(t *testing.T) {
 		}
 	}
 }
+
+var webhookEventSamples = `{
+  "results": [
+    {"msys": {"message_event": {"type": "bounce", "bounce_class": "10", "rcpt_to": "recipient@example.com",
+      "timestamp": "1454442600", "message_id": "000443ee14578172be22"}}},
+    {"msys": {"track_event": {"type": "click", "target_link_url": "http://example.com",
+      "timestamp": "1454442600", "message_id": "000443ee14578172be22"}}}
+  ]
+}`
+
+func TestWebhookEventSamples(t *testing.T) {
+	testSetup(t)
+	defer testTeardown()
+
+	// set up the response handler
+	var query string
+	path := fmt.Sprintf(eventSamplesFormat, testClient.Config.ApiVersion)
+	testMux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		query = r.URL.RawQuery
+		w.Header().Set("Content-Type", "application/json; charset=utf8")
+		w.Write([]byte(webhookEventSamples))
+	})
+
+	samples, res, err := testClient.WebhookEventSamples([]string{"bounce", "click"})
+	if err != nil {
+		testFailVerbose(t, res, "WebhookEventSamples returned error: %v", err)
+	}
+	if query != "events=bounce%2Cclick" {
+		t.Errorf("query string was %q", query)
+	}
+	if len(samples) != 2 {
+		t.Fatalf("WebhookEventSamples returned %d events, expected 2", len(samples))
+	}
+	if b, ok := samples[0].(*events.Bounce); !ok || b.Recipient != "recipient@example.com" {
+		t.Errorf("first sample was %#v", samples[0])
+	}
+	if _, ok := samples[1].(*events.Click); !ok {
+		t.Errorf("second sample was %#v", samples[1])
+	}
+
+	if _, _, err = testClient.WebhookEventSamples([]string{"bogus"}); err == nil {
+		t.Error("WebhookEventSamples accepted an invalid event type")
+	}
+}
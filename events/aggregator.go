@@ -0,0 +1,170 @@
+package events
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultAggregatorResolution is how finely an Aggregator buckets events unless told otherwise.
+const DefaultAggregatorResolution = time.Minute
+
+// Aggregator keeps rolling counts of events by type, campaign and recipient domain, over one
+// or more windows, such as the last 5 minutes and the last hour. It's enough to power a
+// lightweight real-time dashboard. Events are counted by their time, or when they're added if
+// they have none, in buckets of Resolution; events older than the longest window are dropped,
+// and events timestamped in the future are counted as current.
+// An Aggregator is an EventSink, and is safe for concurrent use.
+type Aggregator struct {
+	resolution time.Duration
+	windows    []time.Duration
+	now        func() time.Time
+
+	mu      sync.Mutex
+	buckets map[int64]*aggregateCounts // keyed by bucket number
+	pruned  int64                      // the current bucket when buckets were last pruned
+}
+
+// WindowCounts are the counts of events in one window.
+type WindowCounts struct {
+	Window     time.Duration
+	Total      int
+	ByType     map[string]int
+	ByCampaign map[string]int
+	ByDomain   map[string]int
+}
+
+// AggregateSnapshot is the state of an Aggregator at a point in time.
+type AggregateSnapshot struct {
+	At      time.Time
+	Windows []WindowCounts
+}
+
+type aggregateCounts struct {
+	total     int
+	types     map[string]int
+	campaigns map[string]int
+	domains   map[string]int
+}
+
+// NewAggregator returns an Aggregator counting events over each of windows, in buckets
+// of resolution (DefaultAggregatorResolution if zero). It panics without a window.
+func NewAggregator(resolution time.Duration, windows ...time.Duration) *Aggregator {
+	if len(windows) == 0 {
+		panic("events: NewAggregator called without a window")
+	}
+	if resolution <= 0 {
+		resolution = DefaultAggregatorResolution
+	}
+	sorted := append([]time.Duration(nil), windows...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	return &Aggregator{
+		resolution: resolution,
+		windows:    sorted,
+		now:        time.Now,
+		buckets:    map[int64]*aggregateCounts{},
+	}
+}
+
+// Write counts events.
+func (a *Aggregator) Write(ctx context.Context, events []Event) error {
+	a.Add(events...)
+	return nil
+}
+
+// Add counts events.
+func (a *Aggregator) Add(events ...Event) {
+	now := a.now()
+	current := a.bucket(now)
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if current != a.pruned {
+		a.prune(current)
+	}
+	for _, e := range events {
+		t := now
+		if env, ok := e.(Envelope); ok && !env.EventTime().IsZero() {
+			t = env.EventTime()
+		}
+		n := a.bucket(t)
+		if n > current {
+			n = current
+		} else if a.expired(current, n) {
+			continue
+		}
+
+		b := a.buckets[n]
+		if b == nil {
+			b = &aggregateCounts{types: map[string]int{}, campaigns: map[string]int{}, domains: map[string]int{}}
+			a.buckets[n] = b
+		}
+		b.total++
+		b.types[e.EventType()]++
+		if id, _ := stringField(e, "CampaignID"); id != "" {
+			b.campaigns[id]++
+		}
+		if env, ok := e.(Envelope); ok {
+			if at := strings.LastIndexByte(env.EventRecipient(), '@'); at >= 0 {
+				b.domains[strings.ToLower(env.EventRecipient()[at+1:])]++
+			}
+		}
+	}
+}
+
+// Snapshot returns the current counts for each window, shortest first.
+// A window includes the bucket the current time falls in.
+func (a *Aggregator) Snapshot() AggregateSnapshot {
+	now := a.now()
+	current := a.bucket(now)
+	snap := AggregateSnapshot{At: now, Windows: make([]WindowCounts, len(a.windows))}
+	for i, w := range a.windows {
+		snap.Windows[i] = WindowCounts{Window: w, ByType: map[string]int{}, ByCampaign: map[string]int{}, ByDomain: map[string]int{}}
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.prune(current)
+	for n, b := range a.buckets {
+		age := time.Duration(current-n) * a.resolution
+		for i := range snap.Windows {
+			wc := &snap.Windows[i]
+			if age >= wc.Window {
+				continue
+			}
+			wc.Total += b.total
+			addCounts(wc.ByType, b.types)
+			addCounts(wc.ByCampaign, b.campaigns)
+			addCounts(wc.ByDomain, b.domains)
+		}
+	}
+	return snap
+}
+
+// prune drops the buckets too old for any window, as of the bucket current.
+// It's called as buckets are added too, so an Aggregator which is rarely read doesn't grow.
+func (a *Aggregator) prune(current int64) {
+	for n := range a.buckets {
+		if a.expired(current, n) {
+			delete(a.buckets, n)
+		}
+	}
+	a.pruned = current
+}
+
+// expired reports whether bucket n is too old for any window, as of the bucket current.
+func (a *Aggregator) expired(current, n int64) bool {
+	return time.Duration(current-n)*a.resolution >= a.windows[len(a.windows)-1]
+}
+
+func (a *Aggregator) bucket(t time.Time) int64 {
+	return t.UnixNano() / int64(a.resolution)
+}
+
+func addCounts(to, from map[string]int) {
+	for k, n := range from {
+		to[k] += n
+	}
+}
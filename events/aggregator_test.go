@@ -0,0 +1,75 @@
+package events
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestAggregator(t *testing.T) {
+	start := time.Unix(1454442600, 0)
+	now := start
+	a := NewAggregator(time.Minute, time.Hour, 5*time.Minute)
+	a.now = func() time.Time { return now }
+
+	at := func(ago time.Duration) Timestamp { return Timestamp{Time: start.Add(-ago)} }
+	err := a.Write(context.Background(), Events{
+		&Delivery{EventCommon: EventCommon{Type: "delivery"}, CampaignID: "spring", Recipient: "a@Example.com", Timestamp: at(0)},
+		&Bounce{EventCommon: EventCommon{Type: "bounce"}, CampaignID: "spring", Recipient: "b@example.org", Timestamp: at(10 * time.Minute)},
+		&Open{EventCommon: EventCommon{Type: "open"}, CampaignID: "fall", Recipient: "c@example.com", Timestamp: at(2 * time.Minute)},
+		&RelayMessage{EventCommon: EventCommon{Type: "relay_message"}, To: "d@example.com"}, // counted now
+		&Delivery{EventCommon: EventCommon{Type: "delivery"}, Timestamp: at(2 * time.Hour)}, // too old
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	snap := a.Snapshot()
+	if len(snap.Windows) != 2 || snap.Windows[0].Window != 5*time.Minute {
+		t.Fatalf("snapshot windows are %+v", snap.Windows)
+	}
+	short, long := snap.Windows[0], snap.Windows[1]
+	if short.Total != 3 || short.ByType["delivery"] != 1 || short.ByCampaign["spring"] != 1 || short.ByDomain["example.com"] != 3 {
+		t.Errorf("5 minute window is %+v", short)
+	}
+	if long.Total != 4 || long.ByType["bounce"] != 1 || long.ByCampaign["spring"] != 2 || long.ByDomain["example.org"] != 1 {
+		t.Errorf("hour window is %+v", long)
+	}
+
+	// the window rolls on
+	now = start.Add(55 * time.Minute)
+	snap = a.Snapshot()
+	if snap.Windows[0].Total != 0 || snap.Windows[1].Total != 3 {
+		t.Errorf("later windows are %+v", snap.Windows)
+	}
+	now = start.Add(2 * time.Hour)
+	if snap = a.Snapshot(); snap.Windows[1].Total != 0 || len(a.buckets) != 0 {
+		t.Errorf("old buckets weren't dropped: %+v", snap.Windows[1])
+	}
+}
+
+func TestAggregator_add(t *testing.T) {
+	start := time.Unix(1454442600, 0)
+	now := start
+	a := NewAggregator(time.Minute, 5*time.Minute)
+	a.now = func() time.Time { return now }
+
+	// buckets are pruned as events are added, without a Snapshot
+	for i := 0; i < 60; i++ {
+		now = start.Add(time.Duration(i) * time.Minute)
+		a.Add(&Delivery{EventCommon: EventCommon{Type: "delivery"}, Timestamp: Timestamp{Time: now}})
+	}
+	if len(a.buckets) > 5 {
+		t.Errorf("Aggregator holds %d buckets, expected at most 5", len(a.buckets))
+	}
+
+	// events from the future are counted as current, and leave the window with them
+	a.Add(&Delivery{EventCommon: EventCommon{Type: "delivery"}, Timestamp: Timestamp{Time: now.Add(time.Hour)}})
+	if snap := a.Snapshot(); snap.Windows[0].Total != 6 {
+		t.Errorf("5 minute window has %d events, expected 6", snap.Windows[0].Total)
+	}
+	now = now.Add(5 * time.Minute)
+	if snap := a.Snapshot(); snap.Windows[0].Total != 0 {
+		t.Errorf("5 minute window has %d events once it's passed, expected 0", snap.Windows[0].Total)
+	}
+}
@@ -0,0 +1,105 @@
+package events
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strings"
+	"time"
+)
+
+// DefaultCSVColumns are the columns WriteCSV writes when it's passed none.
+var DefaultCSVColumns = []string{
+	"timestamp", "type", "event_id", "message_id", "rcpt_to", "subaccount_id", "campaign_id",
+}
+
+// WriteCSV writes events to w as CSV, with a header row of columns. Columns are the JSON
+// field names of the events, such as "bounce_class" or "raw_reason", and fields of nested
+// objects are named with dots, such as "geo_ip.country". Events without a field get an empty
+// cell, so events of different types can share an extract. Lists and objects are written as
+// JSON, and "timestamp" in RFC 3339 format, in UTC.
+func WriteCSV(w io.Writer, events []Event, columns []string) error {
+	if len(columns) == 0 {
+		columns = DefaultCSVColumns
+	}
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(columns); err != nil {
+		return err
+	}
+
+	row := make([]string, len(columns))
+	for _, e := range events {
+		fields, err := eventFields(e)
+		if err != nil {
+			return err
+		}
+		for i, col := range columns {
+			row[i] = csvCell(e, fields, col)
+		}
+		if err = cw.Write(row); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// eventFields returns the fields of e as its struct has them, with numbers as json.Number.
+func eventFields(e Event) (map[string]interface{}, error) {
+	var raw []byte
+	if u, ok := e.(*Unknown); ok {
+		raw = u.RawJSON
+	} else {
+		var err error
+		if raw, err = structJSON(e); err != nil {
+			return nil, err
+		}
+	}
+
+	fields := map[string]interface{}{}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	if err := dec.Decode(&fields); err != nil {
+		// Events which aren't objects have no fields to write.
+		return map[string]interface{}{}, nil
+	}
+	return fields, nil
+}
+
+func csvCell(e Event, fields map[string]interface{}, column string) string {
+	if column == "timestamp" {
+		if env, ok := e.(Envelope); ok && !env.EventTime().IsZero() {
+			return env.EventTime().UTC().Format(time.RFC3339)
+		}
+	}
+
+	var value interface{} = fields
+	for _, name := range strings.Split(column, ".") {
+		obj, ok := value.(map[string]interface{})
+		if !ok {
+			return ""
+		}
+		if value, ok = obj[name]; !ok {
+			return ""
+		}
+	}
+
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	case bool:
+		if v {
+			return "true"
+		}
+		return "false"
+	}
+	b, _ := json.Marshal(value)
+	return string(b)
+}
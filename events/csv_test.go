@@ -0,0 +1,46 @@
+package events
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"testing"
+)
+
+func TestWriteCSV(t *testing.T) {
+	batch := sampleBatch(t)
+	batch = append(batch, ParseRawJSONEvent(json.RawMessage(`{"type": "bounce", "bounce_class": [], "rcpt_to": "x@example.com"}`)))
+
+	var buf bytes.Buffer
+	columns := []string{"timestamp", "type", "rcpt_to", "bounce_class", "msg_size", "geo_ip.city", "rcpt_tags", "missing"}
+	if err := WriteCSV(&buf, batch, columns); err != nil {
+		t.Fatal(err)
+	}
+
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 25 {
+		t.Fatalf("got %d rows, expected 25", len(rows))
+	}
+	for i, expected := range map[int][]string{
+		0:  columns,
+		1:  {"2016-02-02T19:50:00Z", "bounce", "recipient@example.com", "1", "1337", "", `["male","US"]`, ""},
+		24: {"", "bounce", "x@example.com", "[]", "", "", "", ""},
+	} {
+		got, _ := json.Marshal(rows[i])
+		want, _ := json.Marshal(expected)
+		if !bytes.Equal(got, want) {
+			t.Errorf("row %d is %s, expected %s", i, got, want)
+		}
+	}
+
+	buf.Reset()
+	if err = WriteCSV(&buf, batch[:1], nil); err != nil {
+		t.Fatal(err)
+	}
+	if rows, _ = csv.NewReader(&buf).ReadAll(); len(rows) != 2 || len(rows[0]) != len(DefaultCSVColumns) {
+		t.Errorf("default columns wrote %v", rows)
+	}
+}
@@ -0,0 +1,140 @@
+package events
+
+import (
+	"container/list"
+	"context"
+	"sync"
+)
+
+// DefaultDedupSize is how many event ids NewDeduplicator remembers.
+const DefaultDedupSize = 100000
+
+// DedupStore remembers the ids of events which have been handled.
+// Implementations must be safe for concurrent use.
+type DedupStore interface {
+	// Has reports whether id has been added.
+	Has(id string) bool
+	// Add records ids as handled.
+	Add(ids ...string)
+}
+
+// Deduplicator drops events which have already been handled, since SparkPost retries
+// batches it didn't get a 200 response for, and may deliver a batch more than once.
+// Events are keyed on their event_id; those without one are always handled.
+type Deduplicator struct {
+	Store DedupStore
+}
+
+// NewDeduplicator returns a Deduplicator remembering the last size event ids in memory.
+// A size of 0 or less means DefaultDedupSize.
+func NewDeduplicator(size int) *Deduplicator {
+	return &Deduplicator{Store: NewLRUStore(size)}
+}
+
+// Filter returns the events which haven't been handled, dropping duplicates within events too.
+// It doesn't record them as handled; see Add.
+func (d *Deduplicator) Filter(events []Event) []Event {
+	fresh := make([]Event, 0, len(events))
+	inBatch := make(map[string]bool, len(events))
+	for _, e := range events {
+		id := eventID(e)
+		if id != "" {
+			if inBatch[id] || d.Store.Has(id) {
+				continue
+			}
+			inBatch[id] = true
+		}
+		fresh = append(fresh, e)
+	}
+	return fresh
+}
+
+// Add records events as handled.
+func (d *Deduplicator) Add(events []Event) {
+	ids := make([]string, 0, len(events))
+	for _, e := range events {
+		if id := eventID(e); id != "" {
+			ids = append(ids, id)
+		}
+	}
+	d.Store.Add(ids...)
+}
+
+// Wrap returns a callback, such as WebhookReceiver.Handle, which passes fn only the events
+// that haven't been handled. Events are recorded as handled once fn returns without error,
+// so a failed batch is handled again when SparkPost retries it. fn isn't called when every
+// event is a duplicate.
+func (d *Deduplicator) Wrap(fn func(ctx context.Context, events []Event) error) func(ctx context.Context, events []Event) error {
+	return func(ctx context.Context, events []Event) error {
+		fresh := d.Filter(events)
+		if len(fresh) == 0 {
+			return nil
+		}
+		if err := fn(ctx, fresh); err != nil {
+			return err
+		}
+		d.Add(fresh)
+		return nil
+	}
+}
+
+func eventID(e Event) string {
+	if ider, ok := e.(interface{ EventID() string }); ok {
+		return ider.EventID()
+	}
+	return ""
+}
+
+// LRUStore is an in-memory DedupStore, which forgets the least recently used ids
+// once it holds its maximum.
+type LRUStore struct {
+	mu    sync.Mutex
+	max   int
+	order *list.List // of string ids, most recently used first
+	ids   map[string]*list.Element
+}
+
+// NewLRUStore returns an LRUStore holding up to size ids.
+// A size of 0 or less means DefaultDedupSize.
+func NewLRUStore(size int) *LRUStore {
+	if size <= 0 {
+		size = DefaultDedupSize
+	}
+	return &LRUStore{max: size, order: list.New(), ids: map[string]*list.Element{}}
+}
+
+// Has reports whether id is in the store, marking it as recently used.
+func (s *LRUStore) Has(id string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	el, ok := s.ids[id]
+	if ok {
+		s.order.MoveToFront(el)
+	}
+	return ok
+}
+
+// Add puts ids in the store, evicting the least recently used ids to make room.
+func (s *LRUStore) Add(ids ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, id := range ids {
+		if el, ok := s.ids[id]; ok {
+			s.order.MoveToFront(el)
+			continue
+		}
+		s.ids[id] = s.order.PushFront(id)
+		for s.order.Len() > s.max {
+			oldest := s.order.Back()
+			s.order.Remove(oldest)
+			delete(s.ids, oldest.Value.(string))
+		}
+	}
+}
+
+// Len returns the number of ids in the store.
+func (s *LRUStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.order.Len()
+}
@@ -0,0 +1,68 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestDeduplicator(t *testing.T) {
+	batch, err := ParseWebhookBatch(strings.NewReader(`[
+		{"msys": {"message_event": {"type": "delivery", "event_id": "1"}}},
+		{"msys": {"track_event": {"type": "open", "event_id": "2"}}},
+		{"msys": {"track_event": {"type": "click", "event_id": "3"}}}
+	]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id := batch[2].(Envelope).EventID(); id != "3" {
+		t.Fatalf("third event has id %q", id)
+	}
+
+	var handled int
+	fail := true
+	d := NewDeduplicator(0)
+	handle := d.Wrap(func(ctx context.Context, events []Event) error {
+		if fail {
+			return errors.New("try again")
+		}
+		handled += len(events)
+		return nil
+	})
+
+	// a failed batch isn't recorded, so its retry is handled
+	if err = handle(context.Background(), batch); err == nil {
+		t.Fatal("Wrap swallowed the error")
+	}
+	fail = false
+	if err = handle(context.Background(), batch); err != nil || handled != 3 {
+		t.Fatalf("handled %d events, %v; expected 3", handled, err)
+	}
+	if err = handle(context.Background(), batch); err != nil || handled != 3 {
+		t.Errorf("duplicate batch handled %d more events, %v", handled-3, err)
+	}
+
+	// events without an id are always handled, duplicates within a batch aren't
+	dup := &Open{EventCommon: EventCommon{Type: "open", ID: "4"}}
+	noID := &Open{EventCommon: EventCommon{Type: "open"}}
+	if fresh := d.Filter([]Event{dup, dup, noID, noID}); len(fresh) != 3 {
+		t.Errorf("Filter returned %d events, expected 3", len(fresh))
+	}
+}
+
+func TestLRUStore(t *testing.T) {
+	s := NewLRUStore(2)
+	s.Add("a", "b")
+	if !s.Has("a") { // a is now more recently used than b
+		t.Fatal("store lost a")
+	}
+	s.Add("c")
+	if s.Has("b") || !s.Has("a") || !s.Has("c") || s.Len() != 2 {
+		t.Errorf("store didn't evict the least recently used id")
+	}
+	s.Add("c", "c")
+	if s.Len() != 2 {
+		t.Errorf("store has %d ids after re-adding, expected 2", s.Len())
+	}
+}
@@ -0,0 +1,120 @@
+package events
+
+import (
+	"encoding/json"
+	"sort"
+)
+
+// Documentation describes each type of event, as returned by the webhooks event documentation
+// endpoint. It's keyed by group name, such as "message_event".
+type Documentation map[string]*Group
+
+// Group is a group of related event types, such as those tracking engagement.
+type Group struct {
+	Name        string
+	Events      map[string]Schema `json:"events"`
+	Description string            `json:"description"`
+	DisplayName string            `json:"display_name"`
+}
+
+// Schema describes one type of event, such as "bounce", and the fields it has.
+type Schema struct {
+	Name        string
+	Group       string
+	Fields      map[string]Field `json:"event"`
+	Description string           `json:"description"`
+	DisplayName string           `json:"display_name"`
+}
+
+// Field describes one field of an event.
+type Field struct {
+	Description string      `json:"description"`
+	SampleValue interface{} `json:"sampleValue"`
+}
+
+// Kind returns the JSON type of the field's sample value: "string", "number", "boolean",
+// "array" or "object". It's "null" if there is no sample value.
+func (f Field) Kind() string {
+	switch f.SampleValue.(type) {
+	case string:
+		return "string"
+	case float64, json.Number:
+		return "number"
+	case bool:
+		return "boolean"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	}
+	return "null"
+}
+
+// UnmarshalJSON decodes the documentation, filling in the Name of each Group and Schema from its key.
+func (d *Documentation) UnmarshalJSON(data []byte) error {
+	var groups map[string]*Group
+	if err := json.Unmarshal(data, &groups); err != nil {
+		return err
+	}
+	for gname, g := range groups {
+		if g == nil {
+			delete(groups, gname)
+			continue
+		}
+		g.Name = gname
+		for ename, s := range g.Events {
+			s.Name, s.Group = ename, gname
+			g.Events[ename] = s
+		}
+	}
+	*d = groups
+	return nil
+}
+
+// EventTypes returns the documented event types, sorted.
+func (d Documentation) EventTypes() []string {
+	var types []string
+	for _, g := range d {
+		for ename := range g.Events {
+			types = append(types, ename)
+		}
+	}
+	sort.Strings(types)
+	return types
+}
+
+// Schema returns the schema of the event type, or nil if it isn't documented.
+func (d Documentation) Schema(eventType string) *Schema {
+	for _, g := range d {
+		if s, ok := g.Events[eventType]; ok {
+			return &s
+		}
+	}
+	return nil
+}
+
+// Sample returns an event of this type with each field set to its sample value,
+// parsed as ParseRawJSONEvent would. It's a fixture which doesn't need an API call.
+func (s *Schema) Sample() Event {
+	return ParseRawJSONEvent(s.SampleJSON())
+}
+
+// SampleJSON returns the JSON of an event of this type with each field set to its sample value.
+func (s *Schema) SampleJSON() json.RawMessage {
+	values := make(map[string]interface{}, len(s.Fields))
+	for name, f := range s.Fields {
+		values[name] = f.SampleValue
+	}
+	// the values were decoded from JSON, so can always be encoded again
+	raw, _ := json.Marshal(values)
+	return raw
+}
+
+// Samples returns Schema.Sample for each documented event type, in EventTypes order.
+func (d Documentation) Samples() Events {
+	var samples Events
+	for _, etype := range d.EventTypes() {
+		samples = append(samples, d.Schema(etype).Sample())
+	}
+	return samples
+}
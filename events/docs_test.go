@@ -0,0 +1,84 @@
+package events
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+)
+
+func loadDocumentation(t *testing.T) Documentation {
+	payload, err := ioutil.ReadFile("../test/event-docs.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var wrapper struct {
+		Results Documentation `json:"results"`
+	}
+	if err = json.Unmarshal(payload, &wrapper); err != nil {
+		t.Fatal(err)
+	}
+	return wrapper.Results
+}
+
+func TestDocumentation(t *testing.T) {
+	docs := loadDocumentation(t)
+
+	s := docs.Schema("bounce")
+	if s == nil {
+		t.Fatal("bounce isn't documented")
+	}
+	if s.Name != "bounce" || s.Group != "message_event" || docs["message_event"].Name != "message_event" {
+		t.Errorf("names weren't filled in: %q in %q", s.Name, s.Group)
+	}
+	if f := s.Fields["bounce_class"]; f.Kind() != "string" || f.Description == "" {
+		t.Errorf("bounce_class field was %+v", f)
+	}
+	if docs.Schema("bogus") != nil {
+		t.Error("Schema returned an undocumented event type")
+	}
+
+	types := docs.EventTypes()
+	for i := 1; i < len(types); i++ {
+		if types[i-1] >= types[i] {
+			t.Fatalf("EventTypes weren't sorted: %v", types)
+		}
+	}
+	for _, etype := range types {
+		if !ValidEventType(etype) {
+			t.Errorf("documented event type %q isn't valid", etype)
+		}
+	}
+}
+
+func TestDocumentationSamples(t *testing.T) {
+	docs := loadDocumentation(t)
+
+	samples := docs.Samples()
+	if len(samples) != len(docs.EventTypes()) {
+		t.Fatalf("got %d samples of %d event types", len(samples), len(docs.EventTypes()))
+	}
+	for _, e := range samples {
+		if u, ok := e.(*Unknown); ok {
+			t.Error(u)
+		}
+	}
+	if b, ok := docs.Schema("bounce").Sample().(*Bounce); !ok || b.BounceClass != "1" {
+		t.Errorf("bounce sample was %#v", b)
+	}
+}
+
+func TestFieldKind(t *testing.T) {
+	for kind, f := range map[string]Field{
+		"string":  {SampleValue: "x"},
+		"number":  {SampleValue: 1.5},
+		"boolean": {SampleValue: true},
+		"array":   {SampleValue: []interface{}{"a"}},
+		"object":  {SampleValue: map[string]interface{}{}},
+		"null":    {},
+	} {
+		if f.Kind() != kind {
+			t.Errorf("Kind of %v was %q, expected %q", f.SampleValue, f.Kind(), kind)
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Drift describes how the struct for an event type differs from a sample of that event,
+// such as one from the Event Samples API or Documentation.Samples.
+type Drift struct {
+	Type string
+	// Unknown lists fields of the sample which the struct doesn't have.
+	Unknown []string
+	// Missing lists fields of the struct which the sample doesn't have.
+	Missing []string
+	// Err is set if the sample doesn't decode into the struct, or has no struct to decode into.
+	Err error
+}
+
+func (d *Drift) String() string {
+	var parts []string
+	if d.Err != nil {
+		parts = append(parts, d.Err.Error())
+	}
+	if len(d.Unknown) > 0 {
+		parts = append(parts, "unknown fields "+strings.Join(d.Unknown, ", "))
+	}
+	if len(d.Missing) > 0 {
+		parts = append(parts, "missing fields "+strings.Join(d.Missing, ", "))
+	}
+	return fmt.Sprintf("%s: %s", d.Type, strings.Join(parts, "; "))
+}
+
+// OptionalFields are struct fields CheckSample doesn't expect every sample to have, since
+// SparkPost only sends them for some events, or to older accounts.
+var OptionalFields = []string{
+	"binding", "binding_group", // older names of sending_ip and ip_pool
+	"event_id", // not sent with sms_status
+	"pathway", "pathway_group",
+	"recv_method",
+	"relay_id",
+	"user_agent_parsed",
+}
+
+// CheckSamples runs CheckSample on each event in data, which holds a webhook batch or an
+// Event Samples response, returning the drift found.
+func CheckSamples(data []byte, ignoreMissing ...string) ([]*Drift, error) {
+	rawEvents, err := parseRawJSONEventsFromWebhook(data)
+	if err != nil {
+		if rawEvents, err = parseRawJSONEventsFromSamples(data); err != nil {
+			return nil, err
+		}
+	}
+
+	var drift []*Drift
+	for _, rawEvent := range rawEvents {
+		if d := CheckSample(rawEvent, ignoreMissing...); d != nil {
+			drift = append(drift, d)
+		}
+	}
+	return drift, nil
+}
+
+// CheckSample compares a raw sample event with the struct its type decodes into,
+// returning nil if they match, so schema drift can be caught by tests.
+// Struct fields in OptionalFields or ignoreMissing may be left out of the sample.
+func CheckSample(rawEvent json.RawMessage, ignoreMissing ...string) *Drift {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(rawEvent, &fields); err != nil {
+		return &Drift{Err: err}
+	}
+	var eventType string
+	json.Unmarshal(fields["type"], &eventType)
+	drift := &Drift{Type: eventType}
+
+	switch e := ParseRawJSONEventStrict(rawEvent).(type) {
+	case *RawEvent:
+		drift.Err = fmt.Errorf("no struct for event type %q", eventType)
+		return drift
+	case *Unknown:
+		// Unknown fields are listed below, so only report other errors.
+		if e.Error != nil && !strings.Contains(e.Error.Error(), "unknown field") {
+			drift.Err = e.Error
+		}
+	}
+
+	known := jsonFields(reflect.TypeOf(EventForName(eventType)).Elem())
+	for name := range fields {
+		if !known[name] {
+			drift.Unknown = append(drift.Unknown, name)
+		}
+	}
+	ignore := stringSet(append(ignoreMissing, OptionalFields...), false)
+	for name := range known {
+		if _, ok := fields[name]; !ok && !ignore[name] {
+			drift.Missing = append(drift.Missing, name)
+		}
+	}
+	sort.Strings(drift.Unknown)
+	sort.Strings(drift.Missing)
+
+	if drift.Err == nil && len(drift.Unknown) == 0 && len(drift.Missing) == 0 {
+		return nil
+	}
+	return drift
+}
+
+// jsonFields returns the JSON names of the fields of struct type t, including those of
+// embedded structs, as encoding/json would see them.
+func jsonFields(t reflect.Type) map[string]bool {
+	names := map[string]bool{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name := strings.Split(tag, ",")[0]
+		if f.Anonymous && name == "" && f.Type.Kind() == reflect.Struct {
+			for embedded := range jsonFields(f.Type) {
+				names[embedded] = true
+			}
+			continue
+		}
+		if f.PkgPath != "" {
+			continue // unexported
+		}
+		if name == "" {
+			name = f.Name
+		}
+		names[name] = true
+	}
+	return names
+}
@@ -0,0 +1,79 @@
+package events
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+)
+
+// TestSampleDrift catches changes to the events SparkPost sends which the structs haven't kept up with.
+// sample-events.json predates some fields, so a field is only missing if neither source has it.
+func TestSampleDrift(t *testing.T) {
+	payload, err := ioutil.ReadFile("sample-events.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	drift, err := CheckSamples(payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+	docs := loadDocumentation(t)
+	for _, etype := range docs.EventTypes() {
+		if d := CheckSample(docs.Schema(etype).SampleJSON(), generationFailureOnly(etype)...); d != nil {
+			drift = append(drift, d)
+		}
+	}
+
+	missing := map[string]map[string]int{}
+	for _, d := range drift {
+		if d.Err != nil || len(d.Unknown) > 0 {
+			t.Error(d)
+		}
+		if missing[d.Type] == nil {
+			missing[d.Type] = map[string]int{}
+		}
+		for _, name := range d.Missing {
+			missing[d.Type][name]++
+		}
+	}
+	for etype, fields := range missing {
+		for name, count := range fields {
+			if count == 2 {
+				t.Errorf("%s: no sample has %s", etype, name)
+			}
+		}
+	}
+}
+
+// generationFailureOnly returns the fields generation_failure events don't have, but
+// share a struct with generation_rejection events which do.
+func generationFailureOnly(etype string) []string {
+	if etype == "generation_failure" {
+		return []string{"bounce_class", "subject"}
+	}
+	return nil
+}
+
+func TestCheckSample(t *testing.T) {
+	d := CheckSample(json.RawMessage(`{"type": "sms_status", "sms_text": "lol", "shiny_new": 1}`), "customer_id")
+	if d == nil || d.Err != nil || len(d.Unknown) != 1 || d.Unknown[0] != "shiny_new" {
+		t.Fatalf("CheckSample returned %v", d)
+	}
+	if len(d.Missing) == 0 || d.Missing[0] != "delv_method" || d.String() == "" {
+		t.Errorf("CheckSample returned missing fields %v", d.Missing)
+	}
+
+	for _, raw := range []string{
+		`{"type": "brand_new"}`,
+		`{"type": "bounce", "timestamp": "last tuesday"}`,
+		`[]`,
+	} {
+		if d = CheckSample(json.RawMessage(raw)); d == nil || d.Err == nil {
+			t.Errorf("CheckSample returned %v for %s", d, raw)
+		}
+	}
+
+	if _, err := CheckSamples([]byte(`"samples"`)); err == nil {
+		t.Error("CheckSamples accepted a string")
+	}
+}
@@ -0,0 +1,184 @@
+package events
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Envelope is implemented by every event struct in this package. It exposes the fields pipelines
+// route and index events by, so they don't need a type switch over each kind of event.
+// Events without one of these fields (relay events have no message id, for example) return
+// its zero value. The accessors are prefixed with Event, like EventType, since most structs
+// already have fields named Timestamp, MessageID and Recipient. EventID comes from EventCommon.
+type Envelope interface {
+	Event
+	EventID() string
+	EventTime() time.Time
+	EventMessageID() string
+	EventRecipient() string
+	EventSubaccountID() string
+}
+
+func (e *Bounce) EventTime() time.Time      { return e.Timestamp.Time }
+func (e *Bounce) EventMessageID() string    { return e.MessageID }
+func (e *Bounce) EventRecipient() string    { return e.Recipient }
+func (e *Bounce) EventSubaccountID() string { return e.SubaccountID }
+
+func (e *Delay) EventTime() time.Time      { return e.Timestamp.Time }
+func (e *Delay) EventMessageID() string    { return e.MessageID }
+func (e *Delay) EventRecipient() string    { return e.Recipient }
+func (e *Delay) EventSubaccountID() string { return e.SubaccountID }
+
+func (e *Delivery) EventTime() time.Time      { return e.Timestamp.Time }
+func (e *Delivery) EventMessageID() string    { return e.MessageID }
+func (e *Delivery) EventRecipient() string    { return e.Recipient }
+func (e *Delivery) EventSubaccountID() string { return e.SubaccountID }
+
+func (e *Injection) EventTime() time.Time      { return e.Timestamp.Time }
+func (e *Injection) EventMessageID() string    { return e.MessageID }
+func (e *Injection) EventRecipient() string    { return e.Recipient }
+func (e *Injection) EventSubaccountID() string { return e.SubaccountID }
+
+func (e *OutOfBand) EventTime() time.Time      { return e.Timestamp.Time }
+func (e *OutOfBand) EventMessageID() string    { return e.MessageID }
+func (e *OutOfBand) EventRecipient() string    { return e.Recipient }
+func (e *OutOfBand) EventSubaccountID() string { return e.SubaccountID }
+
+func (e *PolicyRejection) EventTime() time.Time      { return e.Timestamp.Time }
+func (e *PolicyRejection) EventMessageID() string    { return e.MessageID }
+func (e *PolicyRejection) EventRecipient() string    { return e.Recipient }
+func (e *PolicyRejection) EventSubaccountID() string { return e.SubaccountID }
+
+func (e *SpamComplaint) EventTime() time.Time      { return e.Timestamp.Time }
+func (e *SpamComplaint) EventMessageID() string    { return e.MessageID }
+func (e *SpamComplaint) EventRecipient() string    { return e.Recipient }
+func (e *SpamComplaint) EventSubaccountID() string { return e.SubaccountID }
+
+func (e *SMSStatus) EventTime() time.Time      { return e.Timestamp.Time }
+func (e *SMSStatus) EventMessageID() string    { return "" }
+func (e *SMSStatus) EventRecipient() string    { return "" }
+func (e *SMSStatus) EventSubaccountID() string { return e.SubaccountID }
+
+func (e *Click) EventTime() time.Time      { return e.Timestamp.Time }
+func (e *Click) EventMessageID() string    { return e.MessageID }
+func (e *Click) EventRecipient() string    { return e.Recipient }
+func (e *Click) EventSubaccountID() string { return e.SubaccountID }
+
+func (e *Open) EventTime() time.Time      { return e.Timestamp.Time }
+func (e *Open) EventMessageID() string    { return e.MessageID }
+func (e *Open) EventRecipient() string    { return e.Recipient }
+func (e *Open) EventSubaccountID() string { return e.SubaccountID }
+
+func (e *GenerationFailure) EventTime() time.Time      { return e.Timestamp.Time }
+func (e *GenerationFailure) EventMessageID() string    { return "" }
+func (e *GenerationFailure) EventRecipient() string    { return e.Recipient }
+func (e *GenerationFailure) EventSubaccountID() string { return e.SubaccountID }
+
+func (e *ListUnsubscribe) EventTime() time.Time      { return e.Timestamp.Time }
+func (e *ListUnsubscribe) EventMessageID() string    { return e.MessageID }
+func (e *ListUnsubscribe) EventRecipient() string    { return e.Recipient }
+func (e *ListUnsubscribe) EventSubaccountID() string { return e.SubaccountID }
+
+func (e *RelayInjection) EventTime() time.Time      { return e.Timestamp.Time }
+func (e *RelayInjection) EventMessageID() string    { return "" }
+func (e *RelayInjection) EventRecipient() string    { return e.Recipient }
+func (e *RelayInjection) EventSubaccountID() string { return e.SubaccountID }
+
+func (e *RelayRejection) EventTime() time.Time      { return e.Timestamp.Time }
+func (e *RelayRejection) EventMessageID() string    { return "" }
+func (e *RelayRejection) EventRecipient() string    { return e.Recipient }
+func (e *RelayRejection) EventSubaccountID() string { return e.SubaccountID }
+
+func (e *RelayDelivery) EventTime() time.Time      { return e.Timestamp.Time }
+func (e *RelayDelivery) EventMessageID() string    { return "" }
+func (e *RelayDelivery) EventRecipient() string    { return "" }
+func (e *RelayDelivery) EventSubaccountID() string { return e.SubaccountID }
+
+func (e *RelayTempfail) EventTime() time.Time      { return e.Timestamp.Time }
+func (e *RelayTempfail) EventMessageID() string    { return "" }
+func (e *RelayTempfail) EventRecipient() string    { return "" }
+func (e *RelayTempfail) EventSubaccountID() string { return e.SubaccountID }
+
+func (e *Creation) EventTime() time.Time      { return e.Timestamp.Time }
+func (e *Creation) EventMessageID() string    { return "" }
+func (e *Creation) EventRecipient() string    { return "" }
+func (e *Creation) EventSubaccountID() string { return "" }
+
+func (e *GenerationRejection) EventTime() time.Time { return (*GenerationFailure)(e).EventTime() }
+func (e *GenerationRejection) EventMessageID() string {
+	return (*GenerationFailure)(e).EventMessageID()
+}
+func (e *GenerationRejection) EventRecipient() string {
+	return (*GenerationFailure)(e).EventRecipient()
+}
+func (e *GenerationRejection) EventSubaccountID() string {
+	return (*GenerationFailure)(e).EventSubaccountID()
+}
+
+func (e *RelayPermfail) EventTime() time.Time      { return (*RelayTempfail)(e).EventTime() }
+func (e *RelayPermfail) EventMessageID() string    { return (*RelayTempfail)(e).EventMessageID() }
+func (e *RelayPermfail) EventRecipient() string    { return (*RelayTempfail)(e).EventRecipient() }
+func (e *RelayPermfail) EventSubaccountID() string { return (*RelayTempfail)(e).EventSubaccountID() }
+
+func (e *InitialOpen) EventTime() time.Time      { return (*Open)(e).EventTime() }
+func (e *InitialOpen) EventMessageID() string    { return (*Open)(e).EventMessageID() }
+func (e *InitialOpen) EventRecipient() string    { return (*Open)(e).EventRecipient() }
+func (e *InitialOpen) EventSubaccountID() string { return (*Open)(e).EventSubaccountID() }
+
+func (e *AMPOpen) EventTime() time.Time      { return (*Open)(e).EventTime() }
+func (e *AMPOpen) EventMessageID() string    { return (*Open)(e).EventMessageID() }
+func (e *AMPOpen) EventRecipient() string    { return (*Open)(e).EventRecipient() }
+func (e *AMPOpen) EventSubaccountID() string { return (*Open)(e).EventSubaccountID() }
+
+func (e *AMPInitialOpen) EventTime() time.Time      { return (*Open)(e).EventTime() }
+func (e *AMPInitialOpen) EventMessageID() string    { return (*Open)(e).EventMessageID() }
+func (e *AMPInitialOpen) EventRecipient() string    { return (*Open)(e).EventRecipient() }
+func (e *AMPInitialOpen) EventSubaccountID() string { return (*Open)(e).EventSubaccountID() }
+
+func (e *AMPClick) EventTime() time.Time      { return (*Click)(e).EventTime() }
+func (e *AMPClick) EventMessageID() string    { return (*Click)(e).EventMessageID() }
+func (e *AMPClick) EventRecipient() string    { return (*Click)(e).EventRecipient() }
+func (e *AMPClick) EventSubaccountID() string { return (*Click)(e).EventSubaccountID() }
+
+// LinkUnsubscribe gets its accessors from the embedded ListUnsubscribe.
+
+func (e *RelayMessage) EventTime() time.Time      { return time.Time{} }
+func (e *RelayMessage) EventMessageID() string    { return "" }
+func (e *RelayMessage) EventRecipient() string    { return e.To }
+func (e *RelayMessage) EventSubaccountID() string { return "" }
+
+// Unknown and RawEvent look the fields up in the event JSON.
+
+func (e *Unknown) EventID() string           { return envelopeOf(e.RawJSON).ID }
+func (e *Unknown) EventTime() time.Time      { return envelopeOf(e.RawJSON).time() }
+func (e *Unknown) EventMessageID() string    { return envelopeOf(e.RawJSON).MessageID }
+func (e *Unknown) EventRecipient() string    { return envelopeOf(e.RawJSON).Recipient }
+func (e *Unknown) EventSubaccountID() string { return envelopeOf(e.RawJSON).SubaccountID.String() }
+
+func (e *RawEvent) EventID() string           { return envelopeOf(e.JSON).ID }
+func (e *RawEvent) EventTime() time.Time      { return envelopeOf(e.JSON).time() }
+func (e *RawEvent) EventMessageID() string    { return envelopeOf(e.JSON).MessageID }
+func (e *RawEvent) EventRecipient() string    { return envelopeOf(e.JSON).Recipient }
+func (e *RawEvent) EventSubaccountID() string { return envelopeOf(e.JSON).SubaccountID.String() }
+
+// rawEnvelope holds the envelope fields of an event decoded from JSON on its own.
+// Fields which don't decode are left blank.
+type rawEnvelope struct {
+	ID           string      `json:"event_id"`
+	Timestamp    *Timestamp  `json:"timestamp"`
+	MessageID    string      `json:"message_id"`
+	Recipient    string      `json:"rcpt_to"`
+	SubaccountID json.Number `json:"subaccount_id"`
+}
+
+func envelopeOf(raw json.RawMessage) (env rawEnvelope) {
+	json.Unmarshal(raw, &env)
+	return env
+}
+
+func (env rawEnvelope) time() time.Time {
+	if env.Timestamp == nil {
+		return time.Time{}
+	}
+	return env.Timestamp.Time
+}
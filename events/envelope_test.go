@@ -0,0 +1,57 @@
+package events
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+)
+
+func TestEnvelope_sampleEvents(t *testing.T) {
+	payload, err := ioutil.ReadFile("sample-events.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var events Events
+	if err = json.Unmarshal(payload, &events); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, event := range events {
+		env, ok := event.(Envelope)
+		if !ok {
+			t.Errorf("%T doesn't implement Envelope", event)
+			continue
+		}
+		if env.EventTime().IsZero() {
+			t.Errorf("%s has no time", env.EventType())
+		}
+		if env.EventSubaccountID() != "101" {
+			t.Errorf("%s has subaccount %q, expected 101", env.EventType(), env.EventSubaccountID())
+		}
+	}
+
+	bounce := events[0].(Envelope)
+	if bounce.EventMessageID() != "0e0d94b7-9085-4e3c-ab30-e3f2cd9c273e" || bounce.EventRecipient() != "recipient@example.com" {
+		t.Errorf("bounce envelope is %q, %q", bounce.EventMessageID(), bounce.EventRecipient())
+	}
+}
+
+func TestEnvelope_rawEvent(t *testing.T) {
+	raw := json.RawMessage(`{"type": "brand_new", "timestamp": "1454442600", "message_id": "000443ee14578172be22",
+		"rcpt_to": "recipient@example.com", "subaccount_id": 7}`)
+	env, ok := ParseRawJSONEvent(raw).(Envelope)
+	if !ok {
+		t.Fatal("RawEvent doesn't implement Envelope")
+	}
+	if env.EventTime().Unix() != 1454442600 || env.EventMessageID() != "000443ee14578172be22" ||
+		env.EventRecipient() != "recipient@example.com" || env.EventSubaccountID() != "7" {
+		t.Errorf("RawEvent envelope is %v, %q, %q, %q",
+			env.EventTime(), env.EventMessageID(), env.EventRecipient(), env.EventSubaccountID())
+	}
+
+	unknown := ParseRawJSONEvent(json.RawMessage(`{"message_id": "000443ee14578172be22"}`)).(Envelope)
+	if unknown.EventMessageID() != "000443ee14578172be22" || !unknown.EventTime().IsZero() {
+		t.Errorf("Unknown envelope is %v, %q", unknown.EventTime(), unknown.EventMessageID())
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"strconv"
 	"time"
 )
@@ -30,86 +31,80 @@ func ValidEventType(eventType string) bool {
 	return true
 }
 
-// EventForName returns a struct matching the passed-in type.
+// EventForName returns a struct matching the passed-in type, made by the factory
+// registered for it.
 func EventForName(eventType string) Event {
-	switch eventType {
-	case "bounce":
-		return &Bounce{}
-	case "click":
-		return &Click{}
-	case "creation":
-		return &Creation{}
-	case "delay":
-		return &Delay{}
-	case "delivery":
-		return &Delivery{}
-	case "generation_failure":
-		return &GenerationFailure{}
-	case "generation_rejection":
-		return &GenerationRejection{}
-	case "injection":
-		return &Injection{}
-	case "list_unsubscribe":
-		return &ListUnsubscribe{}
-	case "link_unsubscribe":
-		return &LinkUnsubscribe{}
-	case "open":
-		return &Open{}
-	case "out_of_band":
-		return &OutOfBand{}
-	case "policy_rejection":
-		return &PolicyRejection{}
-	case "spam_complaint":
-		return &SpamComplaint{}
-	case "relay_delivery":
-		return &RelayDelivery{}
-	case "relay_injection":
-		return &RelayInjection{}
-	case "relay_message":
-		return &RelayMessage{}
-	case "relay_permfail":
-		return &RelayPermfail{}
-	case "relay_rejection":
-		return &RelayRejection{}
-	case "relay_tempfail":
-		return &RelayTempfail{}
-	case "sms_status":
-		return &SMSStatus{}
+	registryMu.RLock()
+	factory, ok := registry[eventType]
+	registryMu.RUnlock()
+	if ok {
+		return factory()
 	}
 	return &Unknown{}
 }
 
+// ParseRawJSONEvents parses each raw JSON event into the matching event struct.
 func ParseRawJSONEvents(rawEvents []json.RawMessage) ([]Event, error) {
 	events := []Event{}
 
 	// Each item is event data in raw JSON.
 	for _, rawEvent := range rawEvents {
-		var typeLookup EventCommon
-		if err := json.Unmarshal(rawEvent, &typeLookup); err != nil {
-			typeLookup.Type = "unknown"
-		}
+		events = append(events, ParseRawJSONEvent(rawEvent))
+	}
 
-		event := EventForName(typeLookup.EventType())
-		if e, ok := event.(*Unknown); ok {
-			e.EventCommon.Type = typeLookup.EventType()
-			e.RawJSON = rawEvent
-			e.Error = ErrNotImplemented
-			events = append(events, e)
-			continue
-		}
+	return events, nil
+}
+
+// ParseRawJSONEvent parses a single raw JSON event into the matching event struct.
+// Events of a type this package doesn't handle are returned as *RawEvent, and
+// events which can't be parsed are returned as *Unknown.
+func ParseRawJSONEvent(rawEvent json.RawMessage) Event {
+	return parseRawJSONEvent(rawEvent, false)
+}
+
+// ParseRawJSONEventStrict is like ParseRawJSONEvent, but events with fields the matching
+// struct doesn't have are also returned as *Unknown, with Error describing the field.
+// It's meant for tests which check the structs keep up with the events SparkPost sends.
+func ParseRawJSONEventStrict(rawEvent json.RawMessage) Event {
+	return parseRawJSONEvent(rawEvent, true)
+}
+
+func parseRawJSONEvent(rawEvent json.RawMessage, strict bool) Event {
+	// Only the type is decoded here, so field order and escaping in the rest of the event don't matter.
+	var typeLookup struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(rawEvent, &typeLookup); err != nil {
+		typeLookup.Type = "unknown"
+	}
 
-		// Unmarshal into specic event object.
-		if err := json.Unmarshal(rawEvent, &event); err != nil {
-			event = &Unknown{
-				EventCommon: EventCommon{Type: typeLookup.EventType()},
-				RawJSON:     rawEvent,
-				Error:       err,
-			}
+	event := EventForName(typeLookup.Type)
+	if e, ok := event.(*Unknown); ok {
+		if typeLookup.Type != "unknown" && typeLookup.Type != "" {
+			return &RawEvent{Type: typeLookup.Type, JSON: rawEvent}
 		}
-		events = append(events, event)
+		e.EventCommon.Type = typeLookup.Type
+		e.RawJSON = rawEvent
+		e.Error = ErrNotImplemented
+		return e
 	}
 
-	return events, nil
+	// Unmarshal into specic event object.
+	dec := json.NewDecoder(bytes.NewReader(rawEvent))
+	if strict {
+		dec.DisallowUnknownFields()
+	}
+	if err := dec.Decode(event); err != nil {
+		return &Unknown{
+			EventCommon: EventCommon{Type: typeLookup.Type},
+			RawJSON:     rawEvent,
+			Error:       err,
+		}
+	}
+	if e, ok := event.(rawHolder); ok {
+		e.setRaw(rawEvent)
+	}
+	return event
 }
 
 func (events *Events) UnmarshalJSON(data []byte) error {
@@ -130,6 +125,53 @@ func (events *Events) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// ParseWebhookBatch reads a batch of events as SparkPost POSTs them to a webhook: an array of
+// "msys" objects, each wrapping one event in an object named for its class, such as
+// "message_event". The batch is decoded as it's read, so large batches aren't buffered.
+// The empty batch sent when a webhook is validated yields no events.
+func ParseWebhookBatch(r io.Reader) (Events, error) {
+	dec := json.NewDecoder(r)
+	if err := expectDelim(dec, '['); err != nil {
+		return nil, err
+	}
+
+	events := Events{}
+	err := decodeWebhookBatch(dec, func(e Event) { events = append(events, e) })
+	if err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// decodeWebhookBatch passes each event in a webhook batch to emit, as it's decoded.
+// The opening '[' of the batch must already have been read from dec.
+func decodeWebhookBatch(dec *json.Decoder, emit func(Event)) error {
+	for dec.More() {
+		var wrapper struct {
+			Msys map[string]json.RawMessage `json:"msys"`
+		}
+		if err := dec.Decode(&wrapper); err != nil {
+			return err
+		}
+		for _, rawEvent := range wrapper.Msys {
+			emit(ParseRawJSONEvent(rawEvent))
+		}
+	}
+	return expectDelim(dec, ']')
+}
+
+// expectDelim reads the next token from dec, which must be delim.
+func expectDelim(dec *json.Decoder, delim json.Delim) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if d, ok := tok.(json.Delim); !ok || d != delim {
+		return fmt.Errorf("expected %q in event JSON, got %v", delim, tok)
+	}
+	return nil
+}
+
 func parseRawJSONEventsFromWebhook(data []byte) ([]json.RawMessage, error) {
 	var rawEvents []json.RawMessage
 
@@ -191,10 +233,27 @@ type ECLogger interface {
 // EventCommon contains fields common to all types of Event objects
 type EventCommon struct {
 	Type string `json:"type"`
+	// ID uniquely identifies the event, so duplicates from webhook retries can be spotted.
+	ID string `json:"event_id"`
+	// raw is the JSON the event was parsed from, which MarshalJSON reproduces.
+	raw json.RawMessage
 }
 
 func (e EventCommon) EventType() string { return e.Type }
 
+// EventID returns the unique id of the event.
+func (e EventCommon) EventID() string { return e.ID }
+
+// rawHolder is implemented by events embedding EventCommon, which keep the JSON
+// they were parsed from.
+type rawHolder interface {
+	rawJSON() json.RawMessage
+	setRaw(raw json.RawMessage)
+}
+
+func (e *EventCommon) rawJSON() json.RawMessage   { return e.raw }
+func (e *EventCommon) setRaw(raw json.RawMessage) { e.raw = raw }
+
 type Unknown struct {
 	EventCommon
 	RawJSON json.RawMessage
@@ -211,50 +270,96 @@ func (e *Unknown) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
-type Timestamp time.Time
+// RawEvent is an event of a type this package doesn't handle (yet), such as one newly
+// added by SparkPost. It keeps the event JSON, so it can still be stored or reported.
+type RawEvent struct {
+	Type string
+	JSON json.RawMessage
+}
+
+// EventType returns the type of the event, as sent by SparkPost.
+func (e *RawEvent) EventType() string { return e.Type }
+
+func (e *RawEvent) String() string {
+	return fmt.Sprintf("Unhandled event (type %q)\n%s", e.Type, e.JSON)
+}
+
+// MarshalJSON returns the original event JSON.
+func (e *RawEvent) MarshalJSON() ([]byte, error) {
+	return e.JSON, nil
+}
+
+// Timestamp is the time of an event. Webhooks send it as epoch seconds, while event samples
+// and the Events API use an RFC 3339 string; either decodes into Time, and the Timestamp
+// marshals back to the form it was decoded from. Timestamps created in code marshal as epoch seconds.
+type Timestamp struct {
+	time.Time
+	// layout is the time layout the timestamp was sent in, or "" for epoch seconds.
+	layout string
+	// quoted is set when epoch seconds were sent as a JSON string.
+	quoted bool
+}
+
+// eventSampleLayout is the RFC 3339-like format of timestamps in Event Samples.
+const eventSampleLayout = "2006-01-02T15:04:05.000-07:00"
 
 func (t Timestamp) String() string {
-	return time.Time(t).String()
+	return t.Time.String()
 }
 
-func (t *Timestamp) MarshalJSON() ([]byte, error) {
-	return []byte(fmt.Sprint(time.Time(*t).Unix())), nil
+func (t Timestamp) MarshalJSON() ([]byte, error) {
+	if t.layout != "" {
+		return []byte(strconv.Quote(t.Format(t.layout))), nil
+	}
+	unix := strconv.FormatInt(t.Unix(), 10)
+	if t.quoted {
+		return []byte(strconv.Quote(unix)), nil
+	}
+	return []byte(unix), nil
 }
 
 func (t *Timestamp) UnmarshalJSON(data []byte) error {
 	// Trim quotes.
-	data = bytes.Trim(data, `"`)
+	trimmed := bytes.Trim(data, `"`)
+	quoted := len(trimmed) < len(data)
 
 	// Timestamps coming from Webhook Events are Unix timestamps.
-	unix, err := strconv.ParseInt(string(data), 10, 64)
+	unix, err := strconv.ParseInt(string(trimmed), 10, 64)
 	if err == nil {
-		*t = Timestamp(time.Unix(unix, 0))
+		*t = Timestamp{Time: time.Unix(unix, 0), quoted: quoted}
 		return nil
 	}
 
-	// Timestamps coming from Event Samples are in this RFC 3339-like format.
-	customTime, err := time.Parse("2006-01-02T15:04:05.000-07:00", string(data))
-	if err != nil {
-		return err
+	// Timestamps coming from Event Samples are in this RFC 3339-like format,
+	// and those from the Events API are RFC 3339 with varying precision.
+	for _, layout := range []string{eventSampleLayout, time.RFC3339Nano} {
+		parsed, perr := time.Parse(layout, string(trimmed))
+		if perr == nil {
+			*t = Timestamp{Time: parsed, layout: layout}
+			return nil
+		}
+		err = perr
 	}
-
-	*t = Timestamp(customTime)
-	return nil
+	return err
 }
 
+// GeoIP is where an engagement event came from, looked up from its IP address.
 type GeoIP struct {
-	Country   string  `json:"country"`
-	Region    string  `json:"region"`
-	City      string  `json:"city"`
-	Latitude  LatLong `json:"latitude"`
-	Longitude LatLong `json:"longitude"`
+	Country    string  `json:"country"`
+	Region     string  `json:"region"`
+	City       string  `json:"city"`
+	Latitude   LatLong `json:"latitude"`
+	Longitude  LatLong `json:"longitude"`
+	PostalCode string  `json:"postal_code"`
+	// Zip is sent as a number, so US ZIP codes with leading zeros lose them; see PostalCode.
+	Zip Int `json:"zip"`
 }
 
 // The API inconsistently returns float or string. We need a custom unmarshaller.
 type LatLong float32
 
 func (v *LatLong) MarshalJSON() ([]byte, error) {
-	return []byte(fmt.Sprint("%v", v)), nil
+	return []byte(fmt.Sprint(float32(*v))), nil
 }
 
 func (v *LatLong) UnmarshalJSON(data []byte) error {
@@ -271,16 +376,47 @@ func (v *LatLong) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// The API sends sizes, counts and durations as strings, and sometimes as numbers.
+// We need a custom unmarshaller.
+type Int int64
+
+func (v Int) String() string {
+	return strconv.FormatInt(int64(v), 10)
+}
+
+func (v Int) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.FormatInt(int64(v), 10)), nil
+}
+
+func (v *Int) UnmarshalJSON(data []byte) error {
+	// Trim quotes if the API returns string.
+	data = bytes.Trim(data, `"`)
+
+	// Blank and null values are left at zero.
+	if len(data) == 0 || string(data) == "null" {
+		return nil
+	}
+
+	// Parse the actual value.
+	value, err := strconv.ParseInt(string(data), 10, 64)
+	if err != nil {
+		return err
+	}
+
+	*v = Int(value)
+	return nil
+}
+
 type Creation struct {
 	EventCommon
-	Accepted        string      `json:"accepted_rcpts"`
+	Accepted        Int         `json:"accepted_rcpts"`
 	CampaignID      string      `json:"campaign_id"`
 	CustomerID      string      `json:"customer_id"`
 	InjectionMethod string      `json:"inj_method"`
 	NodeName        string      `json:"node_name"`
 	Metadata        interface{} `json:"rcpt_meta"`
 	Tags            []string    `json:"rcpt_tags"`
-	Submitted       string      `json:"submitted_rcpts"`
+	Submitted       Int         `json:"submitted_rcpts"`
 	TemplateID      string      `json:"template_id"`
 	TemplateVersion string      `json:"template_version"`
 	Timestamp       Timestamp   `json:"timestamp"`
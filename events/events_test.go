@@ -2,9 +2,12 @@ package events
 
 import (
 	"encoding/json"
+	"fmt"
 	"io/ioutil"
 	"os"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestSampleEvents(t *testing.T) {
@@ -28,6 +31,10 @@ func TestSampleEvents(t *testing.T) {
 		if unknown, ok := event.(*Unknown); ok {
 			t.Fatal(unknown)
 		}
+		// every sample has a struct of its own
+		if raw, ok := event.(*RawEvent); ok {
+			t.Error(raw)
+		}
 	}
 }
 
@@ -51,3 +58,231 @@ func TestSampleWebhookValidationRequest(t *testing.T) {
 		t.Fatalf("expected zero events, got %d: %v", len(events), events)
 	}
 }
+
+func TestParseRawJSONEventStrict(t *testing.T) {
+	raw := json.RawMessage(`{"type": "bounce", "bounce_class": "10", "brand_new_field": "x"}`)
+
+	if b, ok := ParseRawJSONEvent(raw).(*Bounce); !ok || b.BounceClass != "10" {
+		t.Errorf("ParseRawJSONEvent didn't ignore the unknown field: %+v", b)
+	}
+
+	unknown, ok := ParseRawJSONEventStrict(raw).(*Unknown)
+	if !ok {
+		t.Fatal("ParseRawJSONEventStrict accepted an unknown field")
+	}
+	if unknown.EventCommon.Type != "bounce" || unknown.Error == nil {
+		t.Errorf("unexpected Unknown event %v", unknown)
+	}
+
+	if _, ok := ParseRawJSONEventStrict(json.RawMessage(`{"type": "bounce", "bounce_class": "10"}`)).(*Bounce); !ok {
+		t.Error("ParseRawJSONEventStrict rejected a known event")
+	}
+}
+
+func TestParseRawJSONEvent_adversarial(t *testing.T) {
+	for idx, test := range []struct {
+		raw  string
+		want string
+	}{
+		// type after the other fields
+		{`{"bounce_class": "10", "rcpt_to": "a@example.com", "type": "bounce"}`, "*events.Bounce"},
+		// escaped characters in the type
+		{`{"type": "bou\u006ece"}`, "*events.Bounce"},
+		// "type" inside string values and nested objects
+		{`{"raw_reason": "{\"type\": \"delivery\"}", "rcpt_meta": {"type": "delivery"}, "type": "bounce"}`, "*events.Bounce"},
+		{`{"rcpt_meta": {"type": "bounce"}, "raw_reason": "\"type\":\"bounce\""}`, "*events.Unknown"},
+		// whitespace and escaped quotes around the type
+		{"{\n\t\"type\"\n:\n\"delivery\"\n, \"subject\": \"a \\\"quoted\\\" subject\"}", "*events.Delivery"},
+		// not an event at all
+		{`{"type": 5}`, "*events.Unknown"},
+		{`["type", "bounce"]`, "*events.Unknown"},
+		{`"bounce"`, "*events.Unknown"},
+		{`null`, "*events.Unknown"},
+		{`{"type": "bounce"`, "*events.Unknown"},
+		// the right type, with fields of the wrong type
+		{`{"type": "bounce", "bounce_class": {"nested": true}}`, "*events.Unknown"},
+	} {
+		event := ParseRawJSONEvent(json.RawMessage(test.raw))
+		if got := fmt.Sprintf("%T", event); got != test.want {
+			t.Errorf("ParseRawJSONEvent (%d) => %s, want %s", idx, got, test.want)
+		}
+	}
+
+	// the subject survives escaping
+	b, _ := ParseRawJSONEvent(json.RawMessage(`{"type": "bounce", "subject": "a \"quoted\" \u00e9"}`)).(*Bounce)
+	if b == nil || b.Subject != `a "quoted" é` {
+		t.Errorf("unexpected bounce %+v", b)
+	}
+}
+
+func TestParseRawJSONEvents_rawEvent(t *testing.T) {
+	raws := []json.RawMessage{
+		json.RawMessage(`{"type": "bounce"}`),
+		json.RawMessage(`{"type": "brand_new_event", "rcpt_to": "a@example.com"}`),
+	}
+	events, err := ParseRawJSONEvents(raws)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 2 {
+		t.Fatalf("ParseRawJSONEvents returned %d events, expected 2", len(events))
+	}
+	raw, ok := events[1].(*RawEvent)
+	if !ok {
+		t.Fatalf("unhandled event parsed as %T", events[1])
+	}
+	if raw.EventType() != "brand_new_event" || string(raw.JSON) != string(raws[1]) {
+		t.Errorf("unexpected RawEvent %v", raw)
+	}
+	if out, err := json.Marshal(raw); err != nil || string(out) != `{"type":"brand_new_event","rcpt_to":"a@example.com"}` {
+		t.Errorf("RawEvent marshaled to %s, %v", out, err)
+	}
+}
+
+func TestTrackEventTypes(t *testing.T) {
+	for etype, want := range map[string]string{
+		"initial_open":     "*events.InitialOpen",
+		"amp_open":         "*events.AMPOpen",
+		"amp_initial_open": "*events.AMPInitialOpen",
+		"amp_click":        "*events.AMPClick",
+	} {
+		raw := json.RawMessage(`{"type": "` + etype + `", "rcpt_to": "recipient@example.com",
+			"target_link_url": "http://example.com", "timestamp": "1454442600"}`)
+		e := ParseRawJSONEvent(raw)
+		if got := fmt.Sprintf("%T", e); got != want {
+			t.Errorf("%s parsed as %s, expected %s", etype, got, want)
+			continue
+		}
+		if e.EventType() != etype {
+			t.Errorf("%s has EventType %q", want, e.EventType())
+		}
+		if s := e.(fmt.Stringer).String(); !strings.Contains(s, "recipient@example.com") {
+			t.Errorf("%s String was %q", want, s)
+		}
+	}
+}
+
+func TestParseWebhookBatch(t *testing.T) {
+	file, err := os.Open("sample-events.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	events, err := ParseWebhookBatch(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 23 {
+		t.Errorf("got %d events, expected 23", len(events))
+	}
+	if b, ok := events[0].(*Bounce); !ok || b.BounceClass != "1" {
+		t.Errorf("first event was %#v", events[0])
+	}
+
+	file, err = os.Open("sample-webhook-validation.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	if events, err = ParseWebhookBatch(file); err != nil || len(events) != 0 {
+		t.Errorf("validation batch parsed as %v, %v", events, err)
+	}
+
+	for _, bad := range []string{
+		``,
+		`{"results": []}`,
+		`[{"msys": {"message_event": {"type": "bounce"}}}`,
+		`[{"msys": []}]`,
+	} {
+		if _, err = ParseWebhookBatch(strings.NewReader(bad)); err == nil {
+			t.Errorf("ParseWebhookBatch accepted %q", bad)
+		}
+	}
+}
+
+func TestTimestamp(t *testing.T) {
+	for _, test := range []struct {
+		in   string
+		unix int64
+	}{
+		{`1454442600`, 1454442600},
+		{`"1454442600"`, 1454442600},
+		{`"2016-02-02T19:50:00.000+00:00"`, 1454442600},
+		{`"2016-02-02T14:50:00-05:00"`, 1454442600},
+		{`"2016-02-02T19:50:00.25Z"`, 1454442600},
+	} {
+		var ts Timestamp
+		if err := json.Unmarshal([]byte(test.in), &ts); err != nil {
+			t.Errorf("Timestamp %s failed to unmarshal: %v", test.in, err)
+			continue
+		}
+		if ts.Unix() != test.unix {
+			t.Errorf("Timestamp %s unmarshaled to %v", test.in, ts)
+		}
+		out, err := json.Marshal(ts)
+		if err != nil || string(out) != test.in {
+			t.Errorf("Timestamp %s marshaled back to %s, %v", test.in, out, err)
+		}
+	}
+
+	var ts Timestamp
+	if err := json.Unmarshal([]byte(`"Feb 2 2016"`), &ts); err == nil {
+		t.Errorf("Timestamp accepted a bad time, got %v", ts)
+	}
+	if out, _ := json.Marshal(Timestamp{Time: time.Unix(1454442600, 0)}); string(out) != `1454442600` {
+		t.Errorf("new Timestamp marshaled to %s", out)
+	}
+}
+
+func TestSampleEvents_numericFields(t *testing.T) {
+	payload, err := ioutil.ReadFile("sample-events.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var events Events
+	if err = json.Unmarshal(payload, &events); err != nil {
+		t.Fatal(err)
+	}
+
+	var checked int
+	for _, event := range events {
+		switch e := event.(type) {
+		case *Delivery:
+			checked++
+			if e.MessageSize != 1337 || e.Retries != 2 || e.QueueTime != 12 {
+				t.Errorf("delivery has size %d, retries %d, queue time %d", e.MessageSize, e.Retries, e.QueueTime)
+			}
+		case *Delay:
+			checked++
+			if e.MessageSize != 1337 || e.Retries != 2 || e.QueueTime != 12 {
+				t.Errorf("delay has size %d, retries %d, queue time %d", e.MessageSize, e.Retries, e.QueueTime)
+			}
+		case *RelayPermfail:
+			checked++
+			if e.Retries != 2 || e.QueueTime != 12 {
+				t.Errorf("relay_permfail has retries %d, queue time %d", e.Retries, e.QueueTime)
+			}
+		}
+	}
+	if checked != 3 {
+		t.Errorf("checked %d events, expected 3", checked)
+	}
+}
+
+func TestInt(t *testing.T) {
+	for in, expected := range map[string]Int{`"1337"`: 1337, `1337`: 1337, `""`: 0, `null`: 0} {
+		var v Int
+		if err := json.Unmarshal([]byte(in), &v); err != nil || v != expected {
+			t.Errorf("Int %s unmarshaled to %d, %v", in, v, err)
+		}
+	}
+	var v Int
+	if err := json.Unmarshal([]byte(`"12.5"`), &v); err == nil {
+		t.Errorf("Int accepted a fraction, got %d", v)
+	}
+	if out, _ := json.Marshal(Int(1337)); string(out) != `1337` {
+		t.Errorf("Int marshaled to %s", out)
+	}
+}
@@ -0,0 +1,127 @@
+package events
+
+import (
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Filter reports whether an event should be handled. Filters compose with And, Or and Not,
+// so webhook consumers can route events to handlers with declarative rules.
+type Filter func(Event) bool
+
+// Filter returns the events matching f, in order.
+func (events Events) Filter(f Filter) Events {
+	matched := Events{}
+	for _, e := range events {
+		if f(e) {
+			matched = append(matched, e)
+		}
+	}
+	return matched
+}
+
+// ByType matches events of any of the passed-in types, such as "bounce".
+func ByType(types ...string) Filter {
+	set := stringSet(types, false)
+	return func(e Event) bool {
+		return set[e.EventType()]
+	}
+}
+
+// ByCampaign matches events with any of the passed-in campaign ids.
+// Events without a CampaignID field never match.
+func ByCampaign(ids ...string) Filter {
+	set := stringSet(ids, false)
+	return func(e Event) bool {
+		id, ok := stringField(e, "CampaignID")
+		return ok && set[id]
+	}
+}
+
+// ByRecipientDomain matches events whose recipient is at any of the passed-in domains.
+// Domains are compared case-insensitively, and subdomains don't match.
+func ByRecipientDomain(domains ...string) Filter {
+	set := stringSet(domains, true)
+	return func(e Event) bool {
+		env, ok := e.(Envelope)
+		if !ok {
+			return false
+		}
+		rcpt := env.EventRecipient()
+		at := strings.LastIndexByte(rcpt, '@')
+		return at >= 0 && set[strings.ToLower(rcpt[at+1:])]
+	}
+}
+
+// TimeRange matches events from (inclusive) to (exclusive). A zero from or to leaves
+// that end of the range open. Events without a time never match.
+func TimeRange(from, to time.Time) Filter {
+	return func(e Event) bool {
+		env, ok := e.(Envelope)
+		if !ok {
+			return false
+		}
+		t := env.EventTime()
+		if t.IsZero() {
+			return false
+		}
+		return (from.IsZero() || !t.Before(from)) && (to.IsZero() || t.Before(to))
+	}
+}
+
+// And matches events matching all of filters. With no filters, it matches every event.
+func And(filters ...Filter) Filter {
+	return func(e Event) bool {
+		for _, f := range filters {
+			if !f(e) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// Or matches events matching any of filters. With no filters, it matches no events.
+func Or(filters ...Filter) Filter {
+	return func(e Event) bool {
+		for _, f := range filters {
+			if f(e) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Not matches events f doesn't.
+func Not(f Filter) Filter {
+	return func(e Event) bool {
+		return !f(e)
+	}
+}
+
+func stringSet(list []string, lower bool) map[string]bool {
+	set := make(map[string]bool, len(list))
+	for _, s := range list {
+		if lower {
+			s = strings.ToLower(s)
+		}
+		set[s] = true
+	}
+	return set
+}
+
+// stringField returns the named string field of the struct e points to, if it has one.
+// Fields of embedded structs are found too, so this works for registered event types.
+func stringField(e Event, name string) (string, bool) {
+	v := reflect.ValueOf(e)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return "", false
+	}
+	f := v.Elem().FieldByName(name)
+	if !f.IsValid() || f.Kind() != reflect.String {
+		return "", false
+	}
+	return f.String(), true
+}
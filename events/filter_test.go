@@ -0,0 +1,41 @@
+package events
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFilters(t *testing.T) {
+	at := func(unix int64) Timestamp { return Timestamp{Time: time.Unix(unix, 0)} }
+	events := Events{
+		&Delivery{EventCommon: EventCommon{Type: "delivery"}, CampaignID: "spring", Recipient: "a@Example.com", Timestamp: at(100)},
+		&Bounce{EventCommon: EventCommon{Type: "bounce"}, CampaignID: "spring", Recipient: "b@mail.example.com", Timestamp: at(200)},
+		&Open{EventCommon: EventCommon{Type: "open"}, CampaignID: "fall", Recipient: "c@example.org", Timestamp: at(300)},
+		&LinkUnsubscribe{EventCommon: EventCommon{Type: "link_unsubscribe"},
+			ListUnsubscribe: ListUnsubscribe{CampaignID: "fall", Recipient: "d@example.com", Timestamp: at(400)}},
+		&RelayMessage{EventCommon: EventCommon{Type: "relay_message"}, To: "e@example.com"},
+		&RawEvent{Type: "brand_new"},
+	}
+
+	for _, test := range []struct {
+		name   string
+		filter Filter
+		count  int
+	}{
+		{"ByType", ByType("bounce", "open"), 2},
+		{"ByType none", ByType(), 0},
+		{"ByCampaign", ByCampaign("fall"), 2},
+		{"ByRecipientDomain", ByRecipientDomain("EXAMPLE.com"), 3},
+		{"TimeRange", TimeRange(time.Unix(200, 0), time.Unix(400, 0)), 2},
+		{"TimeRange open", TimeRange(time.Unix(200, 0), time.Time{}), 3},
+		{"And", And(ByCampaign("spring"), ByRecipientDomain("example.com")), 1},
+		{"And none", And(), 6},
+		{"Or", Or(ByType("relay_message"), TimeRange(time.Time{}, time.Unix(101, 0))), 2},
+		{"Or none", Or(), 0},
+		{"Not", Not(ByType("delivery")), 5},
+	} {
+		if got := events.Filter(test.filter); len(got) != test.count {
+			t.Errorf("%s matched %d events, expected %d", test.name, len(got), test.count)
+		}
+	}
+}
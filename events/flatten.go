@@ -0,0 +1,50 @@
+package events
+
+import "encoding/json"
+
+// Flatten returns the fields of e as a flat map, for structured logging. Keys are the JSON
+// field names SparkPost uses, such as "rcpt_to", with fields of nested objects named with
+// dots, such as "geo_ip.city". "timestamp" is a time.Time in UTC, numbers are int64 or float64,
+// and lists are kept as lists. Empty fields are left out.
+func Flatten(e Event) map[string]interface{} {
+	flat := map[string]interface{}{}
+	fields, err := eventFields(e)
+	if err != nil {
+		fields = map[string]interface{}{}
+	}
+	flatten(flat, "", fields)
+	flat["type"] = e.EventType()
+
+	delete(flat, "timestamp")
+	if env, ok := e.(Envelope); ok && !env.EventTime().IsZero() {
+		flat["timestamp"] = env.EventTime().UTC()
+	}
+	return flat
+}
+
+func flatten(flat map[string]interface{}, prefix string, fields map[string]interface{}) {
+	for name, value := range fields {
+		key := prefix + name
+		switch v := value.(type) {
+		case nil:
+		case string:
+			if v != "" {
+				flat[key] = v
+			}
+		case json.Number:
+			if n, err := v.Int64(); err == nil {
+				flat[key] = n
+			} else if f, err := v.Float64(); err == nil {
+				flat[key] = f
+			}
+		case map[string]interface{}:
+			flatten(flat, key+".", v)
+		case []interface{}:
+			if len(v) > 0 {
+				flat[key] = v
+			}
+		default:
+			flat[key] = v
+		}
+	}
+}
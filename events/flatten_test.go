@@ -0,0 +1,38 @@
+package events
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFlatten(t *testing.T) {
+	var click *Click
+	for _, e := range sampleBatch(t) {
+		if c, ok := e.(*Click); ok {
+			click = c
+		}
+	}
+
+	flat := Flatten(click)
+	for key, want := range map[string]interface{}{
+		"type":        "click",
+		"rcpt_to":     "recipient@example.com",
+		"geo_ip.city": "Columbia",
+		"timestamp":   time.Unix(1454442600, 0).UTC(),
+	} {
+		if flat[key] != want {
+			t.Errorf("%s is %#v, expected %#v", key, flat[key], want)
+		}
+	}
+	if _, ok := flat["user_agent_parsed"]; ok {
+		t.Error("empty field was flattened")
+	}
+	if lat, ok := flat["geo_ip.latitude"].(float64); !ok || lat < 39.17 || lat > 39.18 {
+		t.Errorf("geo_ip.latitude is %#v", flat["geo_ip.latitude"])
+	}
+
+	d := Flatten(&Delivery{EventCommon: EventCommon{Type: "delivery"}, MessageSize: 1337})
+	if d["msg_size"] != int64(1337) {
+		t.Errorf("msg_size is %#v", d["msg_size"])
+	}
+}
@@ -6,9 +6,13 @@ type GenerationFailure struct {
 	EventCommon
 	Binding          string      `json:"binding"`
 	BindingGroup     string      `json:"binding_group"`
+	BounceClass      string      `json:"bounce_class"`
 	CampaignID       string      `json:"campaign_id"`
 	CustomerID       string      `json:"customer_id"`
 	ErrorCode        string      `json:"error_code"`
+	FriendlyFrom     string      `json:"friendly_from"`
+	IPPool           string      `json:"ip_pool"`
+	RawRecipient     string      `json:"raw_rcpt_to"`
 	Metadata         interface{} `json:"rcpt_meta"`
 	SubstitutionData interface{} `json:"rcpt_subs"`
 	Tags             []string    `json:"rcpt_tags"`
@@ -17,6 +21,9 @@ type GenerationFailure struct {
 	Reason           string      `json:"reason"`
 	ReceiveProtocol  string      `json:"recv_method"`
 	RoutingDomain    string      `json:"routing_domain"`
+	SendingIP        string      `json:"sending_ip"`
+	SubaccountID     string      `json:"subaccount_id"`
+	Subject          string      `json:"subject"`
 	TemplateID       string      `json:"template_id"`
 	TemplateVersion  string      `json:"template_version"`
 	Timestamp        Timestamp   `json:"timestamp"`
@@ -4,28 +4,43 @@ import "fmt"
 
 type Delivery struct {
 	EventCommon
-	Binding         string      `json:"binding"`
-	BindingGroup    string      `json:"binding_group"`
-	CampaignID      string      `json:"campaign_id"`
-	CustomerID      string      `json:"customer_id"`
-	DeliveryMethod  string      `json:"delv_method"`
-	DeviceToken     string      `json:"device_token"`
-	IPAddress       string      `json:"ip_address"`
-	MessageID       string      `json:"message_id"`
-	MessageFrom     string      `json:"msg_from"`
-	MessageSize     string      `json:"msg_size"`
-	Retries         string      `json:"num_retries"`
-	QueueTime       string      `json:"queue_time"`
-	Metadata        interface{} `json:"rcpt_meta"`
-	Tags            []string    `json:"rcpt_tags"`
-	Recipient       string      `json:"rcpt_to"`
-	RecipientType   string      `json:"rcpt_type"`
-	ReceiveProtocol string      `json:"recv_method"`
-	RoutingDomain   string      `json:"routing_domain"`
-	TemplateID      string      `json:"template_id"`
-	TemplateVersion string      `json:"template_version"`
-	Timestamp       Timestamp   `json:"timestamp"`
-	TransmissionID  string      `json:"transmission_id"`
+	Binding           string      `json:"binding"`
+	BindingGroup      string      `json:"binding_group"`
+	CampaignID        string      `json:"campaign_id"`
+	CustomerID        string      `json:"customer_id"`
+	DeliveryMethod    string      `json:"delv_method"`
+	DeviceToken       string      `json:"device_token"`
+	FriendlyFrom      string      `json:"friendly_from"`
+	IPAddress         string      `json:"ip_address"`
+	IPPool            string      `json:"ip_pool"`
+	MessageID         string      `json:"message_id"`
+	MessageFrom       string      `json:"msg_from"`
+	MessageSize       Int         `json:"msg_size"`
+	Retries           Int         `json:"num_retries"`
+	QueueTime         Int         `json:"queue_time"`
+	RawRecipient      string      `json:"raw_rcpt_to"`
+	Metadata          interface{} `json:"rcpt_meta"`
+	Tags              []string    `json:"rcpt_tags"`
+	Recipient         string      `json:"rcpt_to"`
+	RecipientType     string      `json:"rcpt_type"`
+	ReceiveProtocol   string      `json:"recv_method"`
+	RoutingDomain     string      `json:"routing_domain"`
+	SendingIP         string      `json:"sending_ip"`
+	SMSCoding         string      `json:"sms_coding"`
+	SMSDestination    string      `json:"sms_dst"`
+	SMSDestinationNPI string      `json:"sms_dst_npi"`
+	SMSDestinationTON string      `json:"sms_dst_ton"`
+	SMSRemoteIDs      []string    `json:"sms_remoteids"`
+	SMSSegments       Int         `json:"sms_segments"`
+	SMSSource         string      `json:"sms_src"`
+	SMSSourceNPI      string      `json:"sms_src_npi"`
+	SMSSourceTON      string      `json:"sms_src_ton"`
+	SubaccountID      string      `json:"subaccount_id"`
+	Subject           string      `json:"subject"`
+	TemplateID        string      `json:"template_id"`
+	TemplateVersion   string      `json:"template_version"`
+	Timestamp         Timestamp   `json:"timestamp"`
+	TransmissionID    string      `json:"transmission_id"`
 }
 
 // String returns a brief summary of a Delivery event
@@ -44,26 +59,40 @@ func (d *Delivery) ECLog() string {
 
 type Injection struct {
 	EventCommon
-	Binding         string      `json:"binding"`
-	BindingGroup    string      `json:"binding_group"`
-	CampaignID      string      `json:"campaign_id"`
-	CustomerID      string      `json:"customer_id"`
-	MessageID       string      `json:"message_id"`
-	MessageFrom     string      `json:"msg_from"`
-	MessageSize     string      `json:"msg_size"`
-	Metadata        interface{} `json:"rcpt_meta"`
-	Pathway         string      `json:"pathway"`
-	PathwayGroup    string      `json:"pathway_group"`
-	Tags            []string    `json:"rcpt_tags"`
-	Recipient       string      `json:"rcpt_to"`
-	RecipientType   string      `json:"rcpt_type"`
-	ReceiveProtocol string      `json:"recv_method"`
-	RoutingDomain   string      `json:"routing_domain"`
-	Subject         string      `json:"subject"`
-	TemplateID      string      `json:"template_id"`
-	TemplateVersion string      `json:"template_version"`
-	Timestamp       Timestamp   `json:"timestamp"`
-	TransmissionID  string      `json:"transmission_id"`
+	Binding           string      `json:"binding"`
+	BindingGroup      string      `json:"binding_group"`
+	CampaignID        string      `json:"campaign_id"`
+	CustomerID        string      `json:"customer_id"`
+	FriendlyFrom      string      `json:"friendly_from"`
+	IPPool            string      `json:"ip_pool"`
+	MessageID         string      `json:"message_id"`
+	MessageFrom       string      `json:"msg_from"`
+	MessageSize       Int         `json:"msg_size"`
+	RawRecipient      string      `json:"raw_rcpt_to"`
+	Metadata          interface{} `json:"rcpt_meta"`
+	Pathway           string      `json:"pathway"`
+	PathwayGroup      string      `json:"pathway_group"`
+	Tags              []string    `json:"rcpt_tags"`
+	Recipient         string      `json:"rcpt_to"`
+	RecipientType     string      `json:"rcpt_type"`
+	ReceiveProtocol   string      `json:"recv_method"`
+	RoutingDomain     string      `json:"routing_domain"`
+	SendingIP         string      `json:"sending_ip"`
+	SMSCoding         string      `json:"sms_coding"`
+	SMSDestination    string      `json:"sms_dst"`
+	SMSDestinationNPI string      `json:"sms_dst_npi"`
+	SMSDestinationTON string      `json:"sms_dst_ton"`
+	SMSSegments       Int         `json:"sms_segments"`
+	SMSSource         string      `json:"sms_src"`
+	SMSSourceNPI      string      `json:"sms_src_npi"`
+	SMSSourceTON      string      `json:"sms_src_ton"`
+	SMSText           string      `json:"sms_text"`
+	SubaccountID      string      `json:"subaccount_id"`
+	Subject           string      `json:"subject"`
+	TemplateID        string      `json:"template_id"`
+	TemplateVersion   string      `json:"template_version"`
+	Timestamp         Timestamp   `json:"timestamp"`
+	TransmissionID    string      `json:"transmission_id"`
 }
 
 // String returns a brief summary of a GenerationFailure event
@@ -83,32 +112,44 @@ func (i *Injection) ECLog() string {
 
 type Bounce struct {
 	EventCommon
-	Binding         string            `json:"binding"`
-	BindingGroup    string            `json:"binding_group"`
-	BounceClass     string            `json:"bounce_class"`
-	CampaignID      string            `json:"campaign_id"`
-	CustomerID      string            `json:"customer_id"`
-	DeliveryMethod  string            `json:"delv_method"`
-	DeviceToken     string            `json:"device_token"`
-	ErrorCode       string            `json:"error_code"`
-	IPAddress       string            `json:"ip_address"`
-	MessageID       string            `json:"message_id"`
-	MessageFrom     string            `json:"msg_from"`
-	MessageSize     string            `json:"msg_size"`
-	Retries         string            `json:"num_retries"`
-	Metadata        map[string]string `json:"rcpt_meta"`
-	Tags            []string          `json:"rcpt_tags"`
-	Recipient       string            `json:"rcpt_to"`
-	RecipientType   string            `json:"rcpt_type"`
-	RawReason       string            `json:"raw_reason"`
-	Reason          string            `json:"reason"`
-	ReceiveProtocol string            `json:"recv_method"`
-	RoutingDomain   string            `json:"routing_domain"`
-	Subject         string            `json:"subject"`
-	TemplateID      string            `json:"template_id"`
-	TemplateVersion string            `json:"template_version"`
-	Timestamp       Timestamp         `json:"timestamp"`
-	TransmissionID  string            `json:"transmission_id"`
+	Binding           string            `json:"binding"`
+	BindingGroup      string            `json:"binding_group"`
+	BounceClass       string            `json:"bounce_class"`
+	CampaignID        string            `json:"campaign_id"`
+	CustomerID        string            `json:"customer_id"`
+	DeliveryMethod    string            `json:"delv_method"`
+	DeviceToken       string            `json:"device_token"`
+	ErrorCode         string            `json:"error_code"`
+	FriendlyFrom      string            `json:"friendly_from"`
+	IPAddress         string            `json:"ip_address"`
+	IPPool            string            `json:"ip_pool"`
+	MessageID         string            `json:"message_id"`
+	MessageFrom       string            `json:"msg_from"`
+	MessageSize       Int               `json:"msg_size"`
+	Retries           Int               `json:"num_retries"`
+	RawRecipient      string            `json:"raw_rcpt_to"`
+	Metadata          map[string]string `json:"rcpt_meta"`
+	Tags              []string          `json:"rcpt_tags"`
+	Recipient         string            `json:"rcpt_to"`
+	RecipientType     string            `json:"rcpt_type"`
+	RawReason         string            `json:"raw_reason"`
+	Reason            string            `json:"reason"`
+	ReceiveProtocol   string            `json:"recv_method"`
+	RoutingDomain     string            `json:"routing_domain"`
+	SendingIP         string            `json:"sending_ip"`
+	SMSCoding         string            `json:"sms_coding"`
+	SMSDestination    string            `json:"sms_dst"`
+	SMSDestinationNPI string            `json:"sms_dst_npi"`
+	SMSDestinationTON string            `json:"sms_dst_ton"`
+	SMSSource         string            `json:"sms_src"`
+	SMSSourceNPI      string            `json:"sms_src_npi"`
+	SMSSourceTON      string            `json:"sms_src_ton"`
+	SubaccountID      string            `json:"subaccount_id"`
+	Subject           string            `json:"subject"`
+	TemplateID        string            `json:"template_id"`
+	TemplateVersion   string            `json:"template_version"`
+	Timestamp         Timestamp         `json:"timestamp"`
+	TransmissionID    string            `json:"transmission_id"`
 }
 
 // String returns a brief summary of a Bounce event
@@ -137,13 +178,17 @@ type OutOfBand struct {
 	DeliveryMethod  string    `json:"delv_method"`
 	DeviceToken     string    `json:"device_token"`
 	ErrorCode       string    `json:"error_code"`
+	IPPool          string    `json:"ip_pool"`
 	MessageID       string    `json:"message_id"`
 	MessageFrom     string    `json:"msg_from"`
+	RawRecipient    string    `json:"raw_rcpt_to"`
 	Recipient       string    `json:"rcpt_to"`
 	RawReason       string    `json:"raw_reason"`
 	Reason          string    `json:"reason"`
 	ReceiveProtocol string    `json:"recv_method"`
 	RoutingDomain   string    `json:"routing_domain"`
+	SendingIP       string    `json:"sending_ip"`
+	SubaccountID    string    `json:"subaccount_id"`
 	TemplateID      string    `json:"template_id"`
 	TemplateVersion string    `json:"template_version"`
 	Timestamp       Timestamp `json:"timestamp"`
@@ -173,13 +218,17 @@ type SpamComplaint struct {
 	DeliveryMethod  string      `json:"delv_method"`
 	FeedbackType    string      `json:"fbtype"`
 	FriendlyFrom    string      `json:"friendly_from"`
+	IPPool          string      `json:"ip_pool"`
 	MessageID       string      `json:"message_id"`
+	RawRecipient    string      `json:"raw_rcpt_to"`
 	Metadata        interface{} `json:"rcpt_meta"`
 	Tags            []string    `json:"rcpt_tags"`
 	Recipient       string      `json:"rcpt_to"`
 	RecipientType   string      `json:"rcpt_type"`
 	ReportedBy      string      `json:"report_by"`
 	ReportedTo      string      `json:"report_to"`
+	SendingIP       string      `json:"sending_ip"`
+	SubaccountID    string      `json:"subaccount_id"`
 	Subject         string      `json:"subject"`
 	TemplateID      string      `json:"template_id"`
 	TemplateVersion string      `json:"template_version"`
@@ -196,11 +245,14 @@ func (p *SpamComplaint) String() string {
 
 type PolicyRejection struct {
 	EventCommon
+	BounceClass     string      `json:"bounce_class"`
 	CampaignID      string      `json:"campaign_id"`
 	CustomerID      string      `json:"customer_id"`
 	ErrorCode       string      `json:"error_code"`
+	FriendlyFrom    string      `json:"friendly_from"`
 	MessageID       string      `json:"message_id"`
 	MessageFrom     string      `json:"msg_from"`
+	RawRecipient    string      `json:"raw_rcpt_to"`
 	Metadata        interface{} `json:"rcpt_meta"`
 	Pathway         string      `json:"pathway"`
 	PathwayGroup    string      `json:"pathway_group"`
@@ -210,6 +262,9 @@ type PolicyRejection struct {
 	Recipient       string      `json:"rcpt_to"`
 	RecipientType   string      `json:"rcpt_type"`
 	ReceiveProtocol string      `json:"recv_method"`
+	RemoteAddress   string      `json:"remote_addr"`
+	SendingIP       string      `json:"sending_ip"`
+	SubaccountID    string      `json:"subaccount_id"`
 	TemplateID      string      `json:"template_id"`
 	TemplateVersion string      `json:"template_version"`
 	Timestamp       Timestamp   `json:"timestamp"`
@@ -225,32 +280,44 @@ func (p *PolicyRejection) String() string {
 
 type Delay struct {
 	EventCommon
-	Binding         string      `json:"binding"`
-	BindingGroup    string      `json:"binding_group"`
-	BounceClass     string      `json:"bounce_class"`
-	CampaignID      string      `json:"campaign_id"`
-	CustomerID      string      `json:"customer_id"`
-	DeliveryMethod  string      `json:"delv_method"`
-	DeviceToken     string      `json:"device_token"`
-	ErrorCode       string      `json:"error_code"`
-	IPAddress       string      `json:"ip_address"`
-	MessageID       string      `json:"message_id"`
-	MessageFrom     string      `json:"msg_from"`
-	MessageSize     string      `json:"msg_size"`
-	Retries         string      `json:"num_retries"`
-	QueueTime       string      `json:"queue_time"`
-	Metadata        interface{} `json:"rcpt_meta"`
-	Tags            []string    `json:"rcpt_tags"`
-	Recipient       string      `json:"rcpt_to"`
-	RecipientType   string      `json:"rcpt_type"`
-	RawReason       string      `json:"raw_reason"`
-	Reason          string      `json:"reason"`
-	RoutingDomain   string      `json:"routing_domain"`
-	Subject         string      `json:"subject"`
-	TemplateID      string      `json:"template_id"`
-	TemplateVersion string      `json:"template_version"`
-	Timestamp       Timestamp   `json:"timestamp"`
-	TransmissionID  string      `json:"transmission_id"`
+	Binding           string      `json:"binding"`
+	BindingGroup      string      `json:"binding_group"`
+	BounceClass       string      `json:"bounce_class"`
+	CampaignID        string      `json:"campaign_id"`
+	CustomerID        string      `json:"customer_id"`
+	DeliveryMethod    string      `json:"delv_method"`
+	DeviceToken       string      `json:"device_token"`
+	ErrorCode         string      `json:"error_code"`
+	FriendlyFrom      string      `json:"friendly_from"`
+	IPAddress         string      `json:"ip_address"`
+	IPPool            string      `json:"ip_pool"`
+	MessageID         string      `json:"message_id"`
+	MessageFrom       string      `json:"msg_from"`
+	MessageSize       Int         `json:"msg_size"`
+	Retries           Int         `json:"num_retries"`
+	QueueTime         Int         `json:"queue_time"`
+	RawRecipient      string      `json:"raw_rcpt_to"`
+	Metadata          interface{} `json:"rcpt_meta"`
+	Tags              []string    `json:"rcpt_tags"`
+	Recipient         string      `json:"rcpt_to"`
+	RecipientType     string      `json:"rcpt_type"`
+	RawReason         string      `json:"raw_reason"`
+	Reason            string      `json:"reason"`
+	RoutingDomain     string      `json:"routing_domain"`
+	SendingIP         string      `json:"sending_ip"`
+	SMSCoding         string      `json:"sms_coding"`
+	SMSDestination    string      `json:"sms_dst"`
+	SMSDestinationNPI string      `json:"sms_dst_npi"`
+	SMSDestinationTON string      `json:"sms_dst_ton"`
+	SMSSource         string      `json:"sms_src"`
+	SMSSourceNPI      string      `json:"sms_src_npi"`
+	SMSSourceTON      string      `json:"sms_src_ton"`
+	SubaccountID      string      `json:"subaccount_id"`
+	Subject           string      `json:"subject"`
+	TemplateID        string      `json:"template_id"`
+	TemplateVersion   string      `json:"template_version"`
+	Timestamp         Timestamp   `json:"timestamp"`
+	TransmissionID    string      `json:"transmission_id"`
 }
 
 // String returns a brief summary of a Delay event
@@ -270,25 +337,27 @@ func (d *Delay) ECLog() string {
 
 type SMSStatus struct {
 	EventCommon
-	CustomerID     string `json:"customer_id"`
-	DeliveryMethod string `json:"delv_method"`
-	// TODO: `json:"dr_latency"`
-	IPAddress      string   `json:"ip_address"`
-	RawReason      string   `json:"raw_reason"`
-	Reason         string   `json:"reason"`
-	RoutingDomain  string   `json:"routing_domain"`
-	Destination    string   `json:"sms_dst"`
-	DestinationNPI string   `json:"sms_dst_npi"`
-	DestinationTON string   `json:"sms_dst_ton"`
-	RemoteIDs      []string `json:"sms_remoteids"`
-	Source         string   `json:"sms_src"`
-	SourceNPI      string   `json:"sms_src_npi"`
-	SourceTON      string   `json:"sms_src_ton"`
-	Text           string   `json:"sms_text"`
-	StatusType     string   `json:"stat_type"`
-	StatusState    string   `json:"stat_state"`
-	// TODO: SubAccountID string `json:"subaccount_id"`
-	Timestamp Timestamp `json:"timestamp"`
+	CustomerID            string    `json:"customer_id"`
+	DeliveryMethod        string    `json:"delv_method"`
+	DeliveryReportLatency string    `json:"dr_latency"`
+	IPAddress             string    `json:"ip_address"`
+	IPPool                string    `json:"ip_pool"`
+	RawReason             string    `json:"raw_reason"`
+	Reason                string    `json:"reason"`
+	RoutingDomain         string    `json:"routing_domain"`
+	SendingIP             string    `json:"sending_ip"`
+	Destination           string    `json:"sms_dst"`
+	DestinationNPI        string    `json:"sms_dst_npi"`
+	DestinationTON        string    `json:"sms_dst_ton"`
+	RemoteIDs             []string  `json:"sms_remoteids"`
+	Source                string    `json:"sms_src"`
+	SourceNPI             string    `json:"sms_src_npi"`
+	SourceTON             string    `json:"sms_src_ton"`
+	Text                  string    `json:"sms_text"`
+	StatusType            string    `json:"stat_type"`
+	StatusState           string    `json:"stat_state"`
+	SubaccountID          string    `json:"subaccount_id"`
+	Timestamp             Timestamp `json:"timestamp"`
 }
 
 // String returns a brief summary of a Delay event
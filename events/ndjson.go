@@ -0,0 +1,83 @@
+package events
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// ParseNDJSON reads newline-delimited JSON events, such as on-prem event logs or archived
+// webhook batches, so they can be re-parsed for backfills. Each line may hold a single event,
+// an event wrapped in an "msys" object as webhooks send it, or a whole webhook batch.
+// Blank lines are skipped.
+func ParseNDJSON(r io.Reader) (Events, error) {
+	events := Events{}
+	if err := decodeNDJSON(r, func(e Event) { events = append(events, e) }); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// StreamNDJSON is like ParseNDJSON, but sends events on a channel as they're read, like Stream.
+func StreamNDJSON(r io.Reader) (<-chan Event, <-chan error) {
+	return stream(r, nil, decodeNDJSON)
+}
+
+// decodeNDJSON passes each event in r to emit, a line at a time.
+// Lines are read whole, since log lines aren't limited in length.
+func decodeNDJSON(r io.Reader, emit func(Event)) error {
+	br := bufio.NewReader(r)
+	for lineNum := 1; ; lineNum++ {
+		line, err := br.ReadBytes('\n')
+		if err != nil && err != io.EOF {
+			return err
+		}
+
+		if trimmed := bytes.TrimSpace(line); len(trimmed) > 0 {
+			if lerr := decodeNDJSONLine(trimmed, emit); lerr != nil {
+				return fmt.Errorf("line %d: %v", lineNum, lerr)
+			}
+		}
+
+		if err == io.EOF {
+			return nil
+		}
+	}
+}
+
+func decodeNDJSONLine(line []byte, emit func(Event)) error {
+	switch line[0] {
+	case '[':
+		dec := json.NewDecoder(bytes.NewReader(line))
+		if err := expectDelim(dec, '['); err != nil {
+			return err
+		}
+		if err := decodeWebhookBatch(dec, emit); err != nil {
+			return err
+		}
+		if dec.More() {
+			return fmt.Errorf("unexpected data after webhook batch")
+		}
+		return nil
+
+	case '{':
+		var wrapper struct {
+			Msys map[string]json.RawMessage `json:"msys"`
+		}
+		if err := json.Unmarshal(line, &wrapper); err != nil {
+			return err
+		}
+		if wrapper.Msys == nil {
+			emit(ParseRawJSONEvent(line))
+			return nil
+		}
+		for _, rawEvent := range wrapper.Msys {
+			emit(ParseRawJSONEvent(rawEvent))
+		}
+		return nil
+	}
+
+	return fmt.Errorf("expected an event or webhook batch, got %q", line[0])
+}
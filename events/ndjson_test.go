@@ -0,0 +1,69 @@
+package events
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+func TestParseNDJSON(t *testing.T) {
+	payload, err := ioutil.ReadFile("sample-events.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var batch []json.RawMessage
+	if err = json.Unmarshal(payload, &batch); err != nil {
+		t.Fatal(err)
+	}
+
+	// the whole batch on one line, then each wrapped event, then a bare event
+	log := &bytes.Buffer{}
+	compact := &bytes.Buffer{}
+	json.Compact(compact, payload)
+	log.Write(compact.Bytes())
+	log.WriteString("\n\n")
+	for _, wrapped := range batch {
+		compact.Reset()
+		json.Compact(compact, wrapped)
+		log.Write(compact.Bytes())
+		log.WriteString("\r\n")
+	}
+	log.WriteString(`{"type": "open", "message_id": "000443ee14578172be22"}`)
+
+	events, err := ParseNDJSON(bytes.NewReader(log.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 47 {
+		t.Fatalf("got %d events, expected 47", len(events))
+	}
+	for _, event := range events {
+		if unknown, ok := event.(*Unknown); ok {
+			t.Fatal(unknown)
+		}
+	}
+	if o, ok := events[46].(*Open); !ok || o.MessageID != "000443ee14578172be22" {
+		t.Errorf("last event was %#v", events[46])
+	}
+
+	var count int
+	ch, errc := StreamNDJSON(bytes.NewReader(log.Bytes()))
+	for range ch {
+		count++
+	}
+	if err = <-errc; err != nil || count != 47 {
+		t.Errorf("StreamNDJSON returned %d events, %v; expected 47", count, err)
+	}
+
+	for _, bad := range []string{
+		"{\"type\": \"open\"}\n\"open\"\n",
+		"{\"type\": \"open\"\n",
+		"[{\"msys\": {}}] []\n",
+	} {
+		if _, err = ParseNDJSON(strings.NewReader(bad)); err == nil || !strings.HasPrefix(err.Error(), "line ") {
+			t.Errorf("ParseNDJSON returned %v for %q", err, bad)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package events
+
+import (
+	"sort"
+	"sync"
+)
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]func() Event{
+		"amp_click":            func() Event { return &AMPClick{} },
+		"amp_initial_open":     func() Event { return &AMPInitialOpen{} },
+		"amp_open":             func() Event { return &AMPOpen{} },
+		"bounce":               func() Event { return &Bounce{} },
+		"click":                func() Event { return &Click{} },
+		"creation":             func() Event { return &Creation{} },
+		"delay":                func() Event { return &Delay{} },
+		"delivery":             func() Event { return &Delivery{} },
+		"generation_failure":   func() Event { return &GenerationFailure{} },
+		"generation_rejection": func() Event { return &GenerationRejection{} },
+		"initial_open":         func() Event { return &InitialOpen{} },
+		"injection":            func() Event { return &Injection{} },
+		"list_unsubscribe":     func() Event { return &ListUnsubscribe{} },
+		"link_unsubscribe":     func() Event { return &LinkUnsubscribe{} },
+		"open":                 func() Event { return &Open{} },
+		"out_of_band":          func() Event { return &OutOfBand{} },
+		"policy_rejection":     func() Event { return &PolicyRejection{} },
+		"spam_complaint":       func() Event { return &SpamComplaint{} },
+		"relay_delivery":       func() Event { return &RelayDelivery{} },
+		"relay_injection":      func() Event { return &RelayInjection{} },
+		"relay_message":        func() Event { return &RelayMessage{} },
+		"relay_permfail":       func() Event { return &RelayPermfail{} },
+		"relay_rejection":      func() Event { return &RelayRejection{} },
+		"relay_tempfail":       func() Event { return &RelayTempfail{} },
+		"sms_status":           func() Event { return &SMSStatus{} },
+	}
+)
+
+// Register makes factory the source of structs EventForName returns for events of type name,
+// so decoders can be added for event types this package doesn't handle (yet), such as custom
+// or SparkPost Enterprise-specific ones. factory must return a pointer which encoding/json can
+// decode the event into. The decoder for a built-in type can be replaced this way too.
+// Register panics if name is blank or factory is nil.
+func Register(name string, factory func() Event) {
+	if name == "" {
+		panic("events: Register called with blank name")
+	}
+	if factory == nil {
+		panic("events: Register called with nil factory for " + name)
+	}
+	registryMu.Lock()
+	registry[name] = factory
+	registryMu.Unlock()
+}
+
+// RegisteredTypes returns the names of all event types EventForName has a struct for.
+func RegisteredTypes() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,54 @@
+package events
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+type customEvent struct {
+	EventCommon
+	Widget string `json:"widget"`
+}
+
+func TestRegister(t *testing.T) {
+	raw := json.RawMessage(`{"type": "widget_event", "widget": "sprocket"}`)
+	if _, ok := ParseRawJSONEvent(raw).(*RawEvent); !ok {
+		t.Fatal("unregistered event type wasn't returned as *RawEvent")
+	}
+
+	Register("widget_event", func() Event { return &customEvent{} })
+	defer func() {
+		registryMu.Lock()
+		delete(registry, "widget_event")
+		registryMu.Unlock()
+	}()
+
+	e, ok := ParseRawJSONEvent(raw).(*customEvent)
+	if !ok || e.Widget != "sprocket" || e.EventType() != "widget_event" {
+		t.Errorf("registered event type parsed as %#v", e)
+	}
+	if !ValidEventType("widget_event") {
+		t.Error("registered event type isn't valid")
+	}
+	var found bool
+	for _, name := range RegisteredTypes() {
+		found = found || name == "widget_event"
+	}
+	if !found || len(RegisteredTypes()) != 26 {
+		t.Errorf("RegisteredTypes returned %v", RegisteredTypes())
+	}
+
+	for _, test := range []struct {
+		name    string
+		factory func() Event
+	}{{"", func() Event { return &customEvent{} }}, {"widget_event", nil}} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Register(%q) didn't panic", test.name)
+				}
+			}()
+			Register(test.name, test.factory)
+		}()
+	}
+}
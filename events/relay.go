@@ -7,14 +7,18 @@ type RelayInjection struct {
 	Binding         string    `json:"binding"`
 	BindingGroup    string    `json:"binding_group"`
 	CustomerID      string    `json:"customer_id"`
+	IPPool          string    `json:"ip_pool"`
 	MessageFrom     string    `json:"msg_from"`
-	MessageSize     string    `json:"msg_size"`
+	MessageSize     Int       `json:"msg_size"`
 	Pathway         string    `json:"pathway"`
 	PathwayGroup    string    `json:"pathway_group"`
+	RawRecipient    string    `json:"raw_rcpt_to"`
 	Recipient       string    `json:"rcpt_to"`
 	ReceiveProtocol string    `json:"recv_method"`
 	RelayID         string    `json:"relay_id"`
 	RoutingDomain   string    `json:"routing_domain"`
+	SendingIP       string    `json:"sending_ip"`
+	SubaccountID    string    `json:"subaccount_id"`
 	Timestamp       Timestamp `json:"timestamp"`
 }
 
@@ -26,17 +30,21 @@ func (i *RelayInjection) String() string {
 
 type RelayRejection struct {
 	EventCommon
+	BounceClass     string    `json:"bounce_class"`
 	CustomerID      string    `json:"customer_id"`
 	ErrorCode       string    `json:"error_code"`
 	MessageFrom     string    `json:"msg_from"`
 	Pathway         string    `json:"pathway"`
 	PathwayGroup    string    `json:"pathway_group"`
+	RawRecipient    string    `json:"raw_rcpt_to"`
 	RawReason       string    `json:"raw_reason"`
 	Reason          string    `json:"reason"`
 	Recipient       string    `json:"rcpt_to"`
 	ReceiveProtocol string    `json:"recv_method"`
 	RelayID         string    `json:"relay_id"`
 	RemoteAddress   string    `json:"remote_addr"`
+	SendingIP       string    `json:"sending_ip"`
+	SubaccountID    string    `json:"subaccount_id"`
 	Timestamp       Timestamp `json:"timestamp"`
 }
 
@@ -52,14 +60,17 @@ type RelayDelivery struct {
 	BindingGroup    string    `json:"binding_group"`
 	CustomerID      string    `json:"customer_id"`
 	DeliveryMethod  string    `json:"delv_method"`
+	IPPool          string    `json:"ip_pool"`
 	MessageFrom     string    `json:"msg_from"`
 	Pathway         string    `json:"pathway"`
 	PathwayGroup    string    `json:"pathway_group"`
-	QueueTime       string    `json:"queue_time"`
+	QueueTime       Int       `json:"queue_time"`
 	ReceiveProtocol string    `json:"recv_method"`
 	RelayID         string    `json:"relay_id"`
-	Retries         string    `json:"num_retries"`
+	Retries         Int       `json:"num_retries"`
 	RoutingDomain   string    `json:"routing_domain"`
+	SendingIP       string    `json:"sending_ip"`
+	SubaccountID    string    `json:"subaccount_id"`
 	Timestamp       Timestamp `json:"timestamp"`
 }
 
@@ -76,9 +87,10 @@ type RelayTempfail struct {
 	CustomerID      string    `json:"customer_id"`
 	DeliveryMethod  string    `json:"delv_method"`
 	ErrorCode       string    `json:"error_code"`
+	IPPool          string    `json:"ip_pool"`
 	MessageFrom     string    `json:"msg_from"`
-	Retries         string    `json:"num_retries"`
-	QueueTime       string    `json:"queue_time"`
+	Retries         Int       `json:"num_retries"`
+	QueueTime       Int       `json:"queue_time"`
 	Pathway         string    `json:"pathway"`
 	PathwayGroup    string    `json:"pathway_group"`
 	RawReason       string    `json:"raw_reason"`
@@ -86,6 +98,8 @@ type RelayTempfail struct {
 	ReceiveProtocol string    `json:"recv_method"`
 	RelayID         string    `json:"relay_id"`
 	RoutingDomain   string    `json:"routing_domain"`
+	SendingIP       string    `json:"sending_ip"`
+	SubaccountID    string    `json:"subaccount_id"`
 	Timestamp       Timestamp `json:"timestamp"`
 }
 
@@ -111,7 +125,7 @@ type RelayContent struct {
 	Cc      []string            `json:"cc"`
 	Headers []map[string]string `json:"headers"`
 	Email   string              `json:"email_rfc822"`
-	Base64  bool                `json:email_rfc822_is_base64"`
+	Base64  bool                `json:"email_rfc822_is_base64"`
 }
 
 type RelayMessage struct {
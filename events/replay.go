@@ -0,0 +1,146 @@
+package events
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+// DefaultReplayBatchSize is how many events Replayer passes to Handle at once, by default.
+const DefaultReplayBatchSize = 1000
+
+// Replayer feeds archived events back through a handler, such as one rebuilding downstream
+// state after a consumer bug. Handle has the same signature as WebhookReceiver.Handle and
+// EventSink.Write, so the code handling live webhook traffic can be reused.
+type Replayer struct {
+	Handle func(ctx context.Context, events []Event) error
+	// BatchSize is how many events are passed to each call of Handle;
+	// if zero, DefaultReplayBatchSize.
+	BatchSize int
+	// Rate, if non-zero, limits how many events are replayed per second.
+	Rate float64
+
+	sleep func(ctx context.Context, d time.Duration) error
+}
+
+// ReplayFiles replays the events in each file, in order, returning how many were handled.
+// Files named *.ndjson or *.jsonl are read as newline-delimited JSON, as RotatingFileSink
+// writes them, and others as a webhook batch or API response. Files may be gzipped, if their
+// names end in ".gz". Replay stops at the first error.
+func (rp *Replayer) ReplayFiles(ctx context.Context, paths ...string) (int, error) {
+	var total int
+	for _, path := range paths {
+		n, err := rp.replayFile(ctx, path)
+		total += n
+		if err != nil {
+			return total, fmt.Errorf("%s: %w", path, err)
+		}
+	}
+	return total, nil
+}
+
+func (rp *Replayer) replayFile(ctx context.Context, path string) (int, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+
+	var r io.Reader = file
+	name := path
+	if strings.HasSuffix(name, ".gz") {
+		gz, err := gzip.NewReader(file)
+		if err != nil {
+			return 0, err
+		}
+		defer gz.Close()
+		r, name = gz, strings.TrimSuffix(name, ".gz")
+	}
+
+	ndjson := strings.HasSuffix(name, ".ndjson") || strings.HasSuffix(name, ".jsonl")
+	return rp.ReplayReader(ctx, r, ndjson)
+}
+
+// ReplayReader replays the events read from r, which holds newline-delimited JSON if ndjson
+// is set, and a webhook batch or API response otherwise. It returns how many events were handled.
+func (rp *Replayer) ReplayReader(ctx context.Context, r io.Reader, ndjson bool) (int, error) {
+	decode := func(r io.Reader, emit func(Event)) error {
+		return decodeEvents(json.NewDecoder(r), emit)
+	}
+	if ndjson {
+		decode = decodeNDJSON
+	}
+	stop := make(chan struct{})
+	events, errc := stream(r, stop, decode)
+	// If replay stops early, stop the parser rather than reading the rest of r,
+	// and wait for it so it isn't still reading r once this returns.
+	defer func() {
+		close(stop)
+		for range events {
+		}
+	}()
+
+	size := rp.BatchSize
+	if size <= 0 {
+		size = DefaultReplayBatchSize
+	}
+	sleep := rp.sleep
+	if sleep == nil {
+		sleep = sleepContext
+	}
+
+	start := time.Now()
+	var handled int
+	batch := make([]Event, 0, size)
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		if rp.Rate > 0 {
+			// wait until the events already handled have taken their share of time
+			due := start.Add(time.Duration(float64(handled) / rp.Rate * float64(time.Second)))
+			if err := sleep(ctx, time.Until(due)); err != nil {
+				return err
+			}
+		}
+		if err := rp.Handle(ctx, batch); err != nil {
+			return err
+		}
+		handled += len(batch)
+		batch = make([]Event, 0, size)
+		return nil
+	}
+
+	for e := range events {
+		batch = append(batch, e)
+		if len(batch) == size {
+			if err := flush(); err != nil {
+				return handled, err
+			}
+		}
+	}
+	if err := <-errc; err != nil {
+		return handled, err
+	}
+	return handled, flush()
+}
+
+// sleepContext waits for d, or until ctx is done.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,117 @@
+package events
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestReplayer(t *testing.T) {
+	dir, err := ioutil.TempDir("", "replay")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	batch := filepath.Join(dir, "batch.json")
+	sample, err := ioutil.ReadFile("sample-events.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = ioutil.WriteFile(batch, sample, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var lines bytes.Buffer
+	if err = NewJSONLinesSink(&lines).Write(context.Background(), sampleBatch(t)[:5]); err != nil {
+		t.Fatal(err)
+	}
+	var gz bytes.Buffer
+	zw := gzip.NewWriter(&gz)
+	zw.Write(lines.Bytes())
+	zw.Close()
+	archive := filepath.Join(dir, "events.jsonl.gz")
+	if err = ioutil.WriteFile(archive, gz.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var sizes []int
+	var waits []time.Duration
+	rp := &Replayer{
+		Handle: func(ctx context.Context, events []Event) error {
+			sizes = append(sizes, len(events))
+			return nil
+		},
+		BatchSize: 10,
+		Rate:      10,
+		sleep: func(ctx context.Context, d time.Duration) error {
+			waits = append(waits, d.Round(100*time.Millisecond))
+			return nil
+		},
+	}
+	n, err := rp.ReplayFiles(context.Background(), batch, archive)
+	if err != nil || n != 28 {
+		t.Fatalf("ReplayFiles returned %d, %v; expected 28 events", n, err)
+	}
+	if fmt.Sprint(sizes) != "[10 10 3 5]" {
+		t.Errorf("Handle got batches of %v", sizes)
+	}
+	// each batch waits for the ones before it, at 10 events a second
+	if len(waits) != 4 || waits[1] != time.Second || waits[2] != 2*time.Second {
+		t.Errorf("replay waited %v", waits)
+	}
+
+	rp.Handle = func(ctx context.Context, events []Event) error { return errors.New("consumer bug") }
+	if n, err = rp.ReplayFiles(context.Background(), batch); err == nil || n != 0 || !strings.HasPrefix(err.Error(), batch) {
+		t.Errorf("ReplayFiles returned %d, %v", n, err)
+	}
+	if _, err = rp.ReplayFiles(context.Background(), filepath.Join(dir, "missing.json")); err == nil {
+		t.Error("ReplayFiles didn't fail on a missing file")
+	}
+}
+
+// endlessReader repeats line forever.
+type endlessReader struct {
+	line []byte
+	off  int
+}
+
+func (r *endlessReader) Read(p []byte) (int, error) {
+	n := 0
+	for n < len(p) {
+		c := copy(p[n:], r.line[r.off:])
+		n += c
+		r.off = (r.off + c) % len(r.line)
+	}
+	return n, nil
+}
+
+func TestReplayer_stopsEarly(t *testing.T) {
+	handleErr := errors.New("handler failed")
+	rp := &Replayer{
+		Handle:    func(ctx context.Context, events []Event) error { return handleErr },
+		BatchSize: 10,
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := rp.ReplayReader(context.Background(), &endlessReader{line: []byte(`{"type": "delivery"}` + "\n")}, true)
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if err != handleErr {
+			t.Errorf("ReplayReader returned %v, expected the Handle error", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("ReplayReader kept reading after Handle failed")
+	}
+}
@@ -0,0 +1,140 @@
+package events
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"reflect"
+	"strings"
+)
+
+// PIIFields are the fields a Scrubber scrubs unless told otherwise: recipient addresses
+// (mailfrom is the recipient's address on unsubscribe events) and the IP addresses
+// recipients opened, clicked or relayed from.
+var PIIFields = []string{"rcpt_to", "raw_rcpt_to", "mailfrom", "ip_address", "remote_addr"}
+
+// Redacted replaces the values of scrubbed fields, unless they're hashed.
+const Redacted = "redacted"
+
+// Scrubber redacts or hashes personal data in events before they're archived or logged,
+// so event data can meet a retention policy. Scrub events before passing them to WriteCSV
+// or Loggable, and wrap sinks with Sink.
+type Scrubber struct {
+	// Fields are the JSON names of the fields to scrub; PIIFields if empty.
+	Fields []string
+	// Hash, if set, replaces values with their HMAC-SHA256 hash, keyed with Key, so scrubbed
+	// values can still be matched up with each other. Otherwise they're replaced with Redacted.
+	// Without a Key, hashes of known addresses can be looked up, so set one.
+	Hash bool
+	Key  []byte
+	// KeepDomain leaves the domain of email addresses alone, so events can still be
+	// counted by recipient domain.
+	KeepDomain bool
+}
+
+// Scrub returns a copy of e with its personal data scrubbed. e isn't changed.
+func (s *Scrubber) Scrub(e Event) Event {
+	switch orig := e.(type) {
+	case *RawEvent:
+		return &RawEvent{Type: orig.Type, JSON: s.scrubJSON(orig.JSON)}
+	case *Unknown:
+		cp := *orig
+		cp.RawJSON = s.scrubJSON(orig.RawJSON)
+		return &cp
+	}
+
+	v := reflect.ValueOf(e)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return e
+	}
+	cp := reflect.New(v.Elem().Type())
+	cp.Elem().Set(v.Elem())
+	s.scrubStruct(cp.Elem(), s.fieldSet())
+	// The JSON the event was parsed from is marshaled along with it, and may have
+	// fields the struct doesn't, so it's scrubbed too.
+	if r, ok := cp.Interface().(rawHolder); ok && len(r.rawJSON()) > 0 {
+		r.setRaw(s.scrubJSON(r.rawJSON()))
+	}
+	return cp.Interface().(Event)
+}
+
+// ScrubAll returns a scrubbed copy of each event.
+func (s *Scrubber) ScrubAll(events []Event) []Event {
+	scrubbed := make([]Event, len(events))
+	for i, e := range events {
+		scrubbed[i] = s.Scrub(e)
+	}
+	return scrubbed
+}
+
+// Sink returns an EventSink scrubbing events before writing them to sink.
+func (s *Scrubber) Sink(sink EventSink) EventSink {
+	return scrubbedSink{s, sink}
+}
+
+type scrubbedSink struct {
+	s    *Scrubber
+	sink EventSink
+}
+
+func (ss scrubbedSink) Write(ctx context.Context, events []Event) error {
+	return ss.sink.Write(ctx, ss.s.ScrubAll(events))
+}
+
+func (s *Scrubber) fieldSet() map[string]bool {
+	if len(s.Fields) == 0 {
+		return stringSet(PIIFields, false)
+	}
+	return stringSet(s.Fields, false)
+}
+
+// scrubStruct scrubs the string fields of v, including those of embedded structs.
+func (s *Scrubber) scrubStruct(v reflect.Value, fields map[string]bool) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.Anonymous && f.Type.Kind() == reflect.Struct {
+			s.scrubStruct(v.Field(i), fields)
+			continue
+		}
+		name := strings.Split(f.Tag.Get("json"), ",")[0]
+		if f.PkgPath == "" && fields[name] && f.Type.Kind() == reflect.String && v.Field(i).String() != "" {
+			v.Field(i).SetString(s.scrubValue(v.Field(i).String()))
+		}
+	}
+}
+
+// scrubJSON scrubs the top-level fields of a JSON object, keeping their order,
+// and returns raw unchanged if it isn't one.
+func (s *Scrubber) scrubJSON(raw json.RawMessage) json.RawMessage {
+	obj, err := parseObject(raw)
+	if err != nil {
+		return raw
+	}
+	for name := range s.fieldSet() {
+		var value string
+		if json.Unmarshal(obj.values[name], &value) != nil || value == "" {
+			continue
+		}
+		scrubbed, _ := json.Marshal(s.scrubValue(value))
+		obj.set(name, scrubbed)
+	}
+	return obj.marshal()
+}
+
+func (s *Scrubber) scrubValue(value string) string {
+	local, domain := value, ""
+	if s.KeepDomain {
+		if at := strings.LastIndexByte(value, '@'); at >= 0 {
+			local, domain = value[:at], value[at:]
+		}
+	}
+	if !s.Hash {
+		return Redacted + domain
+	}
+	mac := hmac.New(sha256.New, s.Key)
+	mac.Write([]byte(local))
+	return hex.EncodeToString(mac.Sum(nil)) + domain
+}
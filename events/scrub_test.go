@@ -0,0 +1,69 @@
+package events
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestScrubber(t *testing.T) {
+	orig := &LinkUnsubscribe{
+		EventCommon:     EventCommon{Type: "link_unsubscribe"},
+		ListUnsubscribe: ListUnsubscribe{Recipient: "Recipient@example.com", RawRecipient: "Recipient@example.com", CampaignID: "spring"},
+		UserAgent:       "Mozilla/5.0",
+	}
+
+	s := &Scrubber{KeepDomain: true}
+	got := s.Scrub(orig).(*LinkUnsubscribe)
+	if got.Recipient != "redacted@example.com" || got.RawRecipient != "redacted@example.com" || got.CampaignID != "spring" {
+		t.Errorf("Scrub returned %+v", got.ListUnsubscribe)
+	}
+	if orig.Recipient != "Recipient@example.com" {
+		t.Error("Scrub changed the original event")
+	}
+
+	s = &Scrubber{Hash: true, Key: []byte("secret"), Fields: []string{"rcpt_to", "user_agent"}}
+	a := s.Scrub(orig).(*LinkUnsubscribe)
+	b := s.Scrub(&Open{Recipient: "Recipient@example.com"}).(*Open)
+	if a.Recipient != b.Recipient || len(a.Recipient) != 64 || a.RawRecipient != orig.RawRecipient || a.UserAgent == orig.UserAgent {
+		t.Errorf("Scrub returned %q, %q, %q", a.Recipient, a.RawRecipient, a.UserAgent)
+	}
+
+	raw := s.Scrub(&RawEvent{Type: "brand_new", JSON: json.RawMessage(`{"type": "brand_new", "rcpt_to": "x@example.com", "n": 1}`)}).(*RawEvent)
+	if strings.Contains(string(raw.JSON), "x@example.com") || !strings.Contains(string(raw.JSON), `"n":1`) {
+		t.Errorf("Scrub returned %s", raw.JSON)
+	}
+}
+
+func TestScrubber_sink(t *testing.T) {
+	var buf bytes.Buffer
+	s := &Scrubber{}
+	sink := s.Sink(NewJSONLinesSink(&buf))
+	if err := sink.Write(context.Background(), sampleBatch(t)); err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(buf.Bytes(), []byte("recipient@example.com")) || bytes.Contains(buf.Bytes(), []byte("127.0.0.1")) {
+		t.Errorf("sink wrote personal data:\n%s", buf.Bytes())
+	}
+	if !bytes.Contains(buf.Bytes(), []byte(`"rcpt_to":"redacted"`)) {
+		t.Errorf("sink didn't redact rcpt_to")
+	}
+}
+
+func TestScrubber_rawFields(t *testing.T) {
+	// Delivery has no remote_addr field, so it's only in the JSON the event was parsed from.
+	e := ParseRawJSONEvent(json.RawMessage(`{"type": "delivery", "rcpt_to": "x@example.com",
+		"remote_addr": "5.6.7.8", "msg_size": "1337", "timestamp": "1454442600"}`))
+	out, err := json.Marshal((&Scrubber{}).Scrub(e))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"type":"delivery","rcpt_to":"redacted","remote_addr":"redacted","msg_size":"1337","timestamp":"1454442600"}`; string(out) != want {
+		t.Errorf("scrubbed event marshaled to %s, expected %s", out, want)
+	}
+	if out, _ := json.Marshal(e); !strings.Contains(string(out), "5.6.7.8") {
+		t.Errorf("Scrub changed the original event: %s", out)
+	}
+}
@@ -0,0 +1,168 @@
+package events
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// EventSink is somewhere batches of events are written, such as an archive.
+// Its Write method can be passed straight to WebhookHandler:
+//
+//	http.Handle("/webhook", events.WebhookHandler(sink.Write))
+type EventSink interface {
+	Write(ctx context.Context, events []Event) error
+}
+
+// marshalLines returns events as JSON lines, which ParseNDJSON reads back.
+// Events which couldn't be parsed are written as they were received.
+func marshalLines(events []Event) ([]byte, error) {
+	var buf bytes.Buffer
+	for _, e := range events {
+		var line []byte
+		if u, ok := e.(*Unknown); ok {
+			line = u.RawJSON
+		} else {
+			var err error
+			if line, err = json.Marshal(e); err != nil {
+				return nil, err
+			}
+		}
+		if err := json.Compact(&buf, line); err != nil {
+			return nil, err
+		}
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes(), nil
+}
+
+// JSONLinesSink writes each event as a line of JSON. It's safe for concurrent use.
+type JSONLinesSink struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewJSONLinesSink returns a JSONLinesSink writing to w.
+func NewJSONLinesSink(w io.Writer) *JSONLinesSink {
+	return &JSONLinesSink{w: w}
+}
+
+// Write writes events as JSON lines, in a single call to the underlying writer.
+func (s *JSONLinesSink) Write(ctx context.Context, events []Event) error {
+	lines, err := marshalLines(events)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, err = s.w.Write(lines)
+	return err
+}
+
+// ChannelSink sends events on a channel, to be handled elsewhere in the process.
+type ChannelSink chan<- Event
+
+// Write sends each event on the channel, giving up once ctx is done.
+func (s ChannelSink) Write(ctx context.Context, events []Event) error {
+	for _, e := range events {
+		select {
+		case s <- e:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+// RotatingFileSink writes JSON lines to a file, which is renamed once it reaches MaxBytes
+// and replaced with a new one. Rotated files are named for the time they were rotated,
+// such as "events.jsonl.20161014T150405.000000000". It's safe for concurrent use.
+type RotatingFileSink struct {
+	Path     string
+	MaxBytes int64
+
+	mu   sync.Mutex
+	file *os.File
+	size int64
+	now  func() time.Time
+}
+
+// NewRotatingFileSink opens the file at path for appending, creating it as needed,
+// and returns a RotatingFileSink writing to it.
+func NewRotatingFileSink(path string, maxBytes int64) (*RotatingFileSink, error) {
+	if maxBytes <= 0 {
+		return nil, fmt.Errorf("RotatingFileSink requires a positive MaxBytes")
+	}
+	s := &RotatingFileSink{Path: path, MaxBytes: maxBytes, now: time.Now}
+	if err := s.open(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Write appends events to the file as JSON lines, rotating it first if they'd take it
+// over MaxBytes. A batch is never split across files, so one larger than MaxBytes gets
+// a file to itself.
+func (s *RotatingFileSink) Write(ctx context.Context, events []Event) error {
+	lines, err := marshalLines(events)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.file == nil {
+		return fmt.Errorf("RotatingFileSink is closed")
+	}
+	if s.size > 0 && s.size+int64(len(lines)) > s.MaxBytes {
+		if err = s.rotate(); err != nil {
+			return err
+		}
+	}
+	n, err := s.file.Write(lines)
+	s.size += int64(n)
+	return err
+}
+
+// Close closes the current file.
+func (s *RotatingFileSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.file == nil {
+		return nil
+	}
+	err := s.file.Close()
+	s.file = nil
+	return err
+}
+
+func (s *RotatingFileSink) open() error {
+	file, err := os.OpenFile(s.Path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+	s.file, s.size = file, info.Size()
+	return nil
+}
+
+func (s *RotatingFileSink) rotate() error {
+	if err := s.file.Close(); err != nil {
+		return err
+	}
+	s.file = nil
+	rotated := s.Path + "." + s.now().UTC().Format("20060102T150405.000000000")
+	if err := os.Rename(s.Path, rotated); err != nil {
+		return err
+	}
+	return s.open()
+}
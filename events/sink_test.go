@@ -0,0 +1,122 @@
+package events
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func sampleBatch(t *testing.T) Events {
+	t.Helper()
+	file, err := os.Open("sample-events.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	events, err := ParseWebhookBatch(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return events
+}
+
+func TestJSONLinesSink(t *testing.T) {
+	batch := sampleBatch(t)
+	batch = append(batch, ParseRawJSONEvent(json.RawMessage(`{"type": "bounce", "bounce_class": []}`)))
+
+	var buf bytes.Buffer
+	if err := NewJSONLinesSink(&buf).Write(context.Background(), batch); err != nil {
+		t.Fatal(err)
+	}
+	if n := bytes.Count(buf.Bytes(), []byte("\n")); n != 24 {
+		t.Fatalf("wrote %d lines, expected 24", n)
+	}
+
+	// what's written parses back into the same events
+	back, err := ParseNDJSON(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range batch {
+		if back[i].EventType() != batch[i].EventType() {
+			t.Errorf("event %d was %s, read back as %s", i, batch[i].EventType(), back[i].EventType())
+		}
+	}
+	if d, ok := back[1].(*Delivery); !ok || d.MessageSize != 1337 || d.Timestamp.Unix() != 1454442600 {
+		t.Errorf("delivery read back as %#v", back[1])
+	}
+}
+
+func TestChannelSink(t *testing.T) {
+	ch := make(chan Event, 1)
+	sink := ChannelSink(ch)
+	batch := Events{&Open{}, &Click{}}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := sink.Write(ctx, batch); err != context.DeadlineExceeded {
+		t.Errorf("Write to a full channel returned %v", err)
+	}
+
+	ch2 := make(chan Event, 2)
+	if err := ChannelSink(ch2).Write(context.Background(), batch); err != nil || len(ch2) != 2 {
+		t.Errorf("Write returned %v, sent %d events", err, len(ch2))
+	}
+}
+
+func TestRotatingFileSink(t *testing.T) {
+	dir, err := ioutil.TempDir("", "sink")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "events.jsonl")
+	if _, err = NewRotatingFileSink(path, 0); err == nil {
+		t.Error("NewRotatingFileSink accepted a zero MaxBytes")
+	}
+	sink, err := NewRotatingFileSink(path, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tick := time.Unix(1454442600, 0)
+	sink.now = func() time.Time { tick = tick.Add(time.Second); return tick }
+
+	small := Events{&RawEvent{Type: "brand_new", JSON: json.RawMessage(`{"type": "brand_new"}`)}} // 21 bytes a line
+	for i := 0; i < 5; i++ {
+		if err = sink.Write(context.Background(), small); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// a batch bigger than MaxBytes gets a file of its own
+	if err = sink.Write(context.Background(), sampleBatch(t)); err != nil {
+		t.Fatal(err)
+	}
+	if err = sink.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err = sink.Write(context.Background(), small); err == nil {
+		t.Error("Write to a closed sink succeeded")
+	}
+
+	rotated, _ := filepath.Glob(path + ".*")
+	if len(rotated) != 2 {
+		t.Fatalf("rotated files are %v, expected 2", rotated)
+	}
+	for _, name := range append(rotated, path) {
+		contents, _ := ioutil.ReadFile(name)
+		events, err := ParseNDJSON(bytes.NewReader(contents))
+		t.Logf("%s: %d bytes, %d events", filepath.Base(name), len(contents), len(events))
+		if err != nil {
+			t.Errorf("%s doesn't parse: %v", name, err)
+		}
+	}
+	if last, _ := ioutil.ReadFile(path); bytes.Count(last, []byte("\n")) != 23 {
+		t.Errorf("current file doesn't hold just the large batch")
+	}
+}
@@ -0,0 +1,54 @@
+//go:build go1.21
+// +build go1.21
+
+package events
+
+import (
+	"log/slog"
+	"sort"
+	"time"
+)
+
+// LogAttrs returns the fields of e, as Flatten does, as slog attributes sorted by key.
+func LogAttrs(e Event) []slog.Attr {
+	flat := Flatten(e)
+	keys := make([]string, 0, len(flat))
+	for k := range flat {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	attrs := make([]slog.Attr, len(keys))
+	for i, k := range keys {
+		switch v := flat[k].(type) {
+		case time.Time:
+			attrs[i] = slog.Time(k, v)
+		case string:
+			attrs[i] = slog.String(k, v)
+		case int64:
+			attrs[i] = slog.Int64(k, v)
+		case float64:
+			attrs[i] = slog.Float64(k, v)
+		case bool:
+			attrs[i] = slog.Bool(k, v)
+		default:
+			attrs[i] = slog.Any(k, v)
+		}
+	}
+	return attrs
+}
+
+// Loggable wraps e so it's logged as a group of its fields:
+//
+//	logger.Info("webhook event", "event", events.Loggable(e))
+func Loggable(e Event) slog.LogValuer {
+	return loggable{e}
+}
+
+type loggable struct {
+	Event
+}
+
+func (l loggable) LogValue() slog.Value {
+	return slog.GroupValue(LogAttrs(l.Event)...)
+}
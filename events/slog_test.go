@@ -0,0 +1,31 @@
+//go:build go1.21
+// +build go1.21
+
+package events
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"testing"
+)
+
+func TestLoggable(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+	e := ParseRawJSONEvent(json.RawMessage(`{"type": "bounce", "rcpt_to": "recipient@example.com",
+		"bounce_class": "10", "num_retries": "2", "timestamp": "1454442600"}`))
+	logger.Info("webhook event", "event", Loggable(e))
+
+	var line struct {
+		Msg   string                 `json:"msg"`
+		Event map[string]interface{} `json:"event"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &line); err != nil {
+		t.Fatal(err)
+	}
+	if line.Event["rcpt_to"] != "recipient@example.com" || line.Event["num_retries"] != float64(2) ||
+		line.Event["timestamp"] != "2016-02-02T19:50:00Z" || line.Event["bounce_class"] != "10" {
+		t.Errorf("logged %s", buf.Bytes())
+	}
+}
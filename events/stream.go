@@ -0,0 +1,111 @@
+package events
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// streamBuffer is how many parsed events Stream holds before waiting for them to be read.
+const streamBuffer = 64
+
+// Stream parses events from r as they're read, and sends them on the returned Event channel,
+// so memory use stays flat however large the input is. r may hold a webhook batch, or an
+// Events API or Event Samples response, whose "results" array is streamed.
+// The Event channel is closed once r is exhausted or an error occurs; the error channel
+// then yields the error, if any, and is closed. All events must be read to avoid leaking
+// the parsing goroutine.
+func Stream(r io.Reader) (<-chan Event, <-chan error) {
+	return stream(r, nil, func(r io.Reader, emit func(Event)) error {
+		return decodeEvents(json.NewDecoder(r), emit)
+	})
+}
+
+// errStreamStopped is what reads fail with once a stream is stopped.
+var errStreamStopped = errors.New("stream stopped")
+
+// stream runs decode over r in a goroutine, sending the events it emits on the returned
+// channel. Once stop is closed, events are dropped and reads from r fail, so decode
+// returns without reading the rest of r. A nil stop never stops the stream.
+func stream(r io.Reader, stop <-chan struct{}, decode func(io.Reader, func(Event)) error) (<-chan Event, <-chan error) {
+	events := make(chan Event, streamBuffer)
+	errc := make(chan error, 1)
+
+	go func() {
+		defer close(errc)
+		defer close(events)
+		emit := func(e Event) {
+			select {
+			case events <- e:
+			case <-stop:
+			}
+		}
+		if err := decode(stoppableReader{r, stop}, emit); err != nil {
+			errc <- err
+		}
+	}()
+
+	return events, errc
+}
+
+// stoppableReader reads from r until stop is closed.
+type stoppableReader struct {
+	r    io.Reader
+	stop <-chan struct{}
+}
+
+func (s stoppableReader) Read(p []byte) (int, error) {
+	select {
+	case <-s.stop:
+		return 0, errStreamStopped
+	default:
+		return s.r.Read(p)
+	}
+}
+
+// decodeEvents passes each event in a webhook batch or API response to emit, as it's decoded.
+func decodeEvents(dec *json.Decoder, emit func(Event)) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+
+	switch tok {
+	case json.Delim('['):
+		return decodeWebhookBatch(dec, emit)
+
+	case json.Delim('{'):
+		for dec.More() {
+			key, err := dec.Token()
+			if err != nil {
+				return err
+			}
+			if key != "results" {
+				// Skip other fields, such as "links" and "total_count".
+				var skip json.RawMessage
+				if err = dec.Decode(&skip); err != nil {
+					return err
+				}
+				continue
+			}
+
+			if err = expectDelim(dec, '['); err != nil {
+				return err
+			}
+			for dec.More() {
+				var rawEvent json.RawMessage
+				if err = dec.Decode(&rawEvent); err != nil {
+					return err
+				}
+				emit(ParseRawJSONEvent(rawEvent))
+			}
+			if err = expectDelim(dec, ']'); err != nil {
+				return err
+			}
+		}
+		return expectDelim(dec, '}')
+	}
+
+	return fmt.Errorf("expected a webhook batch or API response, got %v", tok)
+}
@@ -0,0 +1,83 @@
+package events
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"testing"
+)
+
+// drain reads everything Stream sends.
+func drain(events <-chan Event, errc <-chan error) ([]Event, error) {
+	var list []Event
+	for e := range events {
+		list = append(list, e)
+	}
+	return list, <-errc
+}
+
+func TestStream(t *testing.T) {
+	file, err := os.Open("sample-events.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	list, err := drain(Stream(file))
+	if err != nil || len(list) != 23 {
+		t.Fatalf("Stream returned %d events, %v; expected 23", len(list), err)
+	}
+	if _, ok := list[0].(*Bounce); !ok {
+		t.Errorf("first event was %#v", list[0])
+	}
+
+	api := `{"results": [{"type": "delivery", "message_id": "a"}, {"type": "open", "message_id": "b"}],
+		"links": {"next": "/api/v1/events/message?cursor=c"}, "total_count": 2}`
+	list, err = drain(Stream(strings.NewReader(api)))
+	if err != nil || len(list) != 2 {
+		t.Fatalf("Stream returned %d events, %v; expected 2", len(list), err)
+	}
+	if o, ok := list[1].(*Open); !ok || o.MessageID != "b" {
+		t.Errorf("second event was %#v", list[1])
+	}
+
+	for _, bad := range []string{
+		``,
+		`"results"`,
+		`[{"msys": {"message_event": {"type": "bounce"}}}`,
+		`{"results": [{"type": "bounce"}`,
+	} {
+		if _, err = drain(Stream(strings.NewReader(bad))); err == nil {
+			t.Errorf("Stream accepted %q", bad)
+		}
+	}
+}
+
+func TestStream_large(t *testing.T) {
+	const n = 20000
+	pr, pw := io.Pipe()
+	go func() {
+		io.WriteString(pw, "[")
+		for i := 0; i < n; i++ {
+			if i > 0 {
+				io.WriteString(pw, ",")
+			}
+			fmt.Fprintf(pw, `{"msys": {"message_event": {"type": "delivery", "message_id": "%d"}}}`, i)
+		}
+		io.WriteString(pw, "]")
+		pw.Close()
+	}()
+
+	events, errc := Stream(pr)
+	var count int
+	for e := range events {
+		if d, ok := e.(*Delivery); !ok || d.MessageID != fmt.Sprint(count) {
+			t.Fatalf("event %d was %#v", count, e)
+		}
+		count++
+	}
+	if err := <-errc; err != nil || count != n {
+		t.Errorf("Stream returned %d events, %v; expected %d", count, err, n)
+	}
+}
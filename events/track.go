@@ -10,10 +10,13 @@ type Click struct {
 	GeoIP           *GeoIP      `json:"geo_ip"`
 	IPAddress       string      `json:"ip_address"`
 	MessageID       string      `json:"message_id"`
+	RawRecipient    string      `json:"raw_rcpt_to"`
 	Metadata        interface{} `json:"rcpt_meta"`
 	Tags            []string    `json:"rcpt_tags"`
 	Recipient       string      `json:"rcpt_to"`
 	RecipientType   string      `json:"rcpt_type"`
+	SendingIP       string      `json:"sending_ip"`
+	SubaccountID    string      `json:"subaccount_id"`
 	TargetLinkName  string      `json:"target_link_name"`
 	TargetLinkURL   string      `json:"target_link_url"`
 	TemplateID      string      `json:"template_id"`
@@ -21,6 +24,9 @@ type Click struct {
 	Timestamp       Timestamp   `json:"timestamp"`
 	TransmissionID  string      `json:"transmission_id"`
 	UserAgent       string      `json:"user_agent"`
+	// UserAgentParsed is SparkPost's breakdown of UserAgent, which not all events have;
+	// see ParsedUserAgent.
+	UserAgentParsed *UserAgentInfo `json:"user_agent_parsed"`
 }
 
 // String returns a brief summary of a Click event
@@ -37,15 +43,21 @@ type Open struct {
 	GeoIP           *GeoIP      `json:"geo_ip"`
 	IPAddress       string      `json:"ip_address"`
 	MessageID       string      `json:"message_id"`
+	RawRecipient    string      `json:"raw_rcpt_to"`
 	Metadata        interface{} `json:"rcpt_meta"`
 	Tags            []string    `json:"rcpt_tags"`
 	Recipient       string      `json:"rcpt_to"`
 	RecipientType   string      `json:"rcpt_type"`
+	SendingIP       string      `json:"sending_ip"`
+	SubaccountID    string      `json:"subaccount_id"`
 	TemplateID      string      `json:"template_id"`
 	TemplateVersion string      `json:"template_version"`
 	Timestamp       Timestamp   `json:"timestamp"`
 	TransmissionID  string      `json:"transmission_id"`
 	UserAgent       string      `json:"user_agent"`
+	// UserAgentParsed is SparkPost's breakdown of UserAgent, which not all events have;
+	// see ParsedUserAgent.
+	UserAgentParsed *UserAgentInfo `json:"user_agent_parsed"`
 }
 
 // String returns a brief summary of an Open event
@@ -53,3 +65,40 @@ func (o *Open) String() string {
 	return fmt.Sprintf("%s O %s %s",
 		o.Timestamp, o.TransmissionID, o.Recipient)
 }
+
+// InitialOpen is an Open tracked by the pixel at the top of the message, so it's recorded
+// even when the recipient doesn't scroll as far as the pixel at the bottom.
+type InitialOpen Open
+
+// String returns a brief summary of an InitialOpen event
+func (o *InitialOpen) String() string {
+	return fmt.Sprintf("%s IO %s %s",
+		o.Timestamp, o.TransmissionID, o.Recipient)
+}
+
+// AMPOpen is an Open of the AMP part of a message.
+type AMPOpen Open
+
+// String returns a brief summary of an AMPOpen event
+func (o *AMPOpen) String() string {
+	return fmt.Sprintf("%s AO %s %s",
+		o.Timestamp, o.TransmissionID, o.Recipient)
+}
+
+// AMPInitialOpen is an InitialOpen of the AMP part of a message.
+type AMPInitialOpen Open
+
+// String returns a brief summary of an AMPInitialOpen event
+func (o *AMPInitialOpen) String() string {
+	return fmt.Sprintf("%s AIO %s %s",
+		o.Timestamp, o.TransmissionID, o.Recipient)
+}
+
+// AMPClick is a Click on a link in the AMP part of a message.
+type AMPClick Click
+
+// String returns a brief summary of an AMPClick event
+func (c *AMPClick) String() string {
+	return fmt.Sprintf("%s AC %s %s => %s",
+		c.Timestamp, c.TransmissionID, c.Recipient, c.TargetLinkURL)
+}
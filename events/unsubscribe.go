@@ -6,12 +6,16 @@ type ListUnsubscribe struct {
 	EventCommon
 	CampaignID      string      `json:"campaign_id"`
 	CustomerID      string      `json:"customer_id"`
+	FriendlyFrom    string      `json:"friendly_from"`
 	MessageFrom     string      `json:"mailfrom"`
 	MessageID       string      `json:"message_id"`
+	RawRecipient    string      `json:"raw_rcpt_to"`
 	Metadata        interface{} `json:"rcpt_meta"`
 	Tags            []string    `json:"rcpt_tags"`
 	Recipient       string      `json:"rcpt_to"`
 	RecipientType   string      `json:"rcpt_type"`
+	SendingIP       string      `json:"sending_ip"`
+	SubaccountID    string      `json:"subaccount_id"`
 	TemplateID      string      `json:"template_id"`
 	TemplateVersion string      `json:"template_version"`
 	Timestamp       Timestamp   `json:"timestamp"`
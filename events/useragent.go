@@ -0,0 +1,126 @@
+package events
+
+import "strings"
+
+// UserAgentInfo describes the software an engagement event came from.
+// It matches the "user_agent_parsed" object SparkPost sends with newer events.
+type UserAgentInfo struct {
+	AgentFamily  string `json:"agent_family"`
+	DeviceBrand  string `json:"device_brand"`
+	DeviceFamily string `json:"device_family"`
+	OSFamily     string `json:"os_family"`
+	OSVersion    string `json:"os_version"`
+	IsMobile     bool   `json:"is_mobile"`
+	IsProxy      bool   `json:"is_proxy"`
+	IsPrefetched bool   `json:"is_prefetched"`
+}
+
+// ParsedUserAgent returns SparkPost's breakdown of the user agent if the event has one,
+// and otherwise parses UserAgent with ParseUserAgent.
+func (c *Click) ParsedUserAgent() UserAgentInfo {
+	return parsedUserAgent(c.UserAgentParsed, c.UserAgent)
+}
+
+// ParsedUserAgent returns SparkPost's breakdown of the user agent if the event has one,
+// and otherwise parses UserAgent with ParseUserAgent.
+func (o *Open) ParsedUserAgent() UserAgentInfo {
+	return parsedUserAgent(o.UserAgentParsed, o.UserAgent)
+}
+
+func (o *InitialOpen) ParsedUserAgent() UserAgentInfo    { return (*Open)(o).ParsedUserAgent() }
+func (o *AMPOpen) ParsedUserAgent() UserAgentInfo        { return (*Open)(o).ParsedUserAgent() }
+func (o *AMPInitialOpen) ParsedUserAgent() UserAgentInfo { return (*Open)(o).ParsedUserAgent() }
+func (c *AMPClick) ParsedUserAgent() UserAgentInfo       { return (*Click)(c).ParsedUserAgent() }
+
+func parsedUserAgent(parsed *UserAgentInfo, ua string) UserAgentInfo {
+	if parsed != nil {
+		return *parsed
+	}
+	return ParseUserAgent(ua)
+}
+
+// ParseUserAgent picks the browser or mail client, operating system and device out of
+// a user agent string. It knows only the common agents mail is read with; anything
+// else has a family of "Other". IsPrefetched can't be told from the string, so it's never set.
+func ParseUserAgent(ua string) UserAgentInfo {
+	info := UserAgentInfo{AgentFamily: "Other", DeviceFamily: "Other", OSFamily: "Other"}
+	if ua == "" {
+		return info
+	}
+
+	// Mail providers fetching images on their users' behalf.
+	for _, proxy := range []string{"GoogleImageProxy", "YahooMailProxy"} {
+		if strings.Contains(ua, proxy) {
+			info.AgentFamily, info.IsProxy = proxy, true
+			return info
+		}
+	}
+
+	switch {
+	case strings.Contains(ua, "Edg/") || strings.Contains(ua, "Edge/"):
+		info.AgentFamily = "Edge"
+	case strings.Contains(ua, "OPR/") || strings.Contains(ua, "Opera"):
+		info.AgentFamily = "Opera"
+	case strings.Contains(ua, "Chrome/") || strings.Contains(ua, "CriOS/"):
+		info.AgentFamily = "Chrome"
+	case strings.Contains(ua, "Firefox/") || strings.Contains(ua, "FxiOS/"):
+		info.AgentFamily = "Firefox"
+	case strings.Contains(ua, "Thunderbird/"):
+		info.AgentFamily = "Thunderbird"
+	case strings.Contains(ua, "Microsoft Outlook") || strings.Contains(ua, "MSOffice"):
+		info.AgentFamily = "Outlook"
+	case strings.Contains(ua, "MSIE ") || strings.Contains(ua, "Trident/"):
+		info.AgentFamily = "IE"
+	case strings.Contains(ua, "Safari/") && strings.Contains(ua, "Version/"):
+		info.AgentFamily = "Safari"
+	case strings.Contains(ua, "AppleWebKit/"):
+		// Apple Mail and iOS Mail are WebKit without Safari's tokens.
+		info.AgentFamily = "Apple Mail"
+	}
+
+	switch {
+	case strings.Contains(ua, "iPhone") || strings.Contains(ua, "iPad") || strings.Contains(ua, "iPod"):
+		info.OSFamily = "iOS"
+		info.OSVersion = versionAfter(ua, " OS ")
+	case strings.Contains(ua, "Mac OS X"):
+		info.OSFamily = "Mac OS X"
+		info.OSVersion = versionAfter(ua, "Mac OS X ")
+	case strings.Contains(ua, "Android"):
+		info.OSFamily = "Android"
+		info.OSVersion = versionAfter(ua, "Android ")
+	case strings.Contains(ua, "Windows"):
+		info.OSFamily = "Windows"
+		info.OSVersion = versionAfter(ua, "Windows NT ")
+	case strings.Contains(ua, "CrOS"):
+		info.OSFamily = "Chrome OS"
+	case strings.Contains(ua, "Linux"):
+		info.OSFamily = "Linux"
+	}
+
+	for _, device := range []string{"iPhone", "iPad", "iPod", "Macintosh"} {
+		if strings.Contains(ua, device) {
+			info.DeviceBrand, info.DeviceFamily = "Apple", strings.TrimSuffix(device, "intosh")
+			break
+		}
+	}
+
+	info.IsMobile = strings.Contains(ua, "Mobi") || strings.Contains(ua, "iPhone") || strings.Contains(ua, "iPod")
+	return info
+}
+
+// versionAfter returns the version number following prefix in ua, with underscores
+// (as in "Mac OS X 10_10_3") turned into dots.
+func versionAfter(ua, prefix string) string {
+	i := strings.Index(ua, prefix)
+	if i < 0 {
+		return ""
+	}
+	rest := ua[i+len(prefix):]
+	end := strings.IndexFunc(rest, func(r rune) bool {
+		return !(r >= '0' && r <= '9' || r == '.' || r == '_')
+	})
+	if end < 0 {
+		end = len(rest)
+	}
+	return strings.Trim(strings.Replace(rest[:end], "_", ".", -1), ".")
+}
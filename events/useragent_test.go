@@ -0,0 +1,56 @@
+package events
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParseUserAgent(t *testing.T) {
+	for _, test := range []struct {
+		ua       string
+		expected UserAgentInfo
+	}{
+		{"Mozilla/5.0 (Macintosh; Intel Mac OS X 10_10_3) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/41.0.2272.118 Safari/537.36",
+			UserAgentInfo{AgentFamily: "Chrome", DeviceBrand: "Apple", DeviceFamily: "Mac", OSFamily: "Mac OS X", OSVersion: "10.10.3"}},
+		{"Mozilla/5.0 (iPhone; CPU iPhone OS 14_4 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Mobile/15E148",
+			UserAgentInfo{AgentFamily: "Apple Mail", DeviceBrand: "Apple", DeviceFamily: "iPhone", OSFamily: "iOS", OSVersion: "14.4", IsMobile: true}},
+		{"Mozilla/5.0 (Linux; Android 11; Pixel 5) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/90.0.4430.91 Mobile Safari/537.36",
+			UserAgentInfo{AgentFamily: "Chrome", DeviceFamily: "Other", OSFamily: "Android", OSVersion: "11", IsMobile: true}},
+		{"Mozilla/4.0 (compatible; MSIE 7.0; Windows NT 10.0; WOW64; Trident/7.0; Microsoft Outlook 16.0.4266)",
+			UserAgentInfo{AgentFamily: "Outlook", DeviceFamily: "Other", OSFamily: "Windows", OSVersion: "10.0"}},
+		{"Mozilla/5.0 (Windows NT 5.1; rv:11.0) Gecko Firefox/11.0 (via ggpht.com GoogleImageProxy)",
+			UserAgentInfo{AgentFamily: "GoogleImageProxy", DeviceFamily: "Other", OSFamily: "Other", IsProxy: true}},
+		{"", UserAgentInfo{AgentFamily: "Other", DeviceFamily: "Other", OSFamily: "Other"}},
+	} {
+		if got := ParseUserAgent(test.ua); got != test.expected {
+			t.Errorf("ParseUserAgent(%q) returned %+v, expected %+v", test.ua, got, test.expected)
+		}
+	}
+}
+
+func TestParsedUserAgent(t *testing.T) {
+	for _, e := range sampleBatch(t) {
+		if c, ok := e.(*Click); ok {
+			if c.GeoIP == nil || c.GeoIP.City != "Columbia" || c.GeoIP.Latitude != 39.1749 {
+				t.Errorf("click has geo_ip %+v", c.GeoIP)
+			}
+			if ua := c.ParsedUserAgent(); ua.AgentFamily != "Chrome" || ua.OSVersion != "10.10.3" {
+				t.Errorf("click user agent parsed as %+v", ua)
+			}
+		}
+	}
+
+	var o AMPOpen
+	err := json.Unmarshal([]byte(`{"type": "amp_open", "user_agent": "Mozilla/5.0",
+		"geo_ip": {"city": "Columbia", "latitude": 39.1749, "postal_code": "21046", "zip": 21046},
+		"user_agent_parsed": {"agent_family": "Gmail", "os_family": "Android", "is_prefetched": true}}`), &o)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ua := o.ParsedUserAgent(); ua.AgentFamily != "Gmail" || !ua.IsPrefetched {
+		t.Errorf("user_agent_parsed decoded as %+v", ua)
+	}
+	if o.GeoIP.PostalCode != "21046" || o.GeoIP.Zip != 21046 {
+		t.Errorf("geo_ip decoded as %+v", o.GeoIP)
+	}
+}
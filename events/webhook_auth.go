@@ -0,0 +1,61 @@
+package events
+
+import (
+	"crypto/subtle"
+	"errors"
+	"net/http"
+	"strings"
+)
+
+// ErrUnauthenticated is returned by an Authenticator when a request lacks valid credentials.
+var ErrUnauthenticated = errors.New("webhook request isn't authenticated")
+
+// WebhookTokenHeader is the header SparkPost sends a webhook's legacy auth_token in.
+const WebhookTokenHeader = "X-MessageSystems-Webhook-Token"
+
+// Authenticator checks the credentials of a webhook request, returning ErrUnauthenticated
+// if they're missing or wrong. See WebhookReceiver.Authenticate.
+type Authenticator func(r *http.Request) error
+
+// BasicAuth accepts requests from a webhook with auth_type "basic" and these auth_credentials.
+func BasicAuth(username, password string) Authenticator {
+	return func(r *http.Request) error {
+		u, p, ok := r.BasicAuth()
+		if !ok || !secureEqual(u, username) || !secureEqual(p, password) {
+			return ErrUnauthenticated
+		}
+		return nil
+	}
+}
+
+// BearerToken accepts requests from a webhook with auth_type "oauth2". SparkPost gets an access
+// token from the auth_request_details url with the client id and secret, then sends it with each
+// batch; valid reports whether the token is one that url issued and hasn't expired.
+func BearerToken(valid func(token string) bool) Authenticator {
+	return func(r *http.Request) error {
+		auth := r.Header.Get("Authorization")
+		const prefix = "Bearer "
+		if len(auth) <= len(prefix) || !strings.EqualFold(auth[:len(prefix)], prefix) {
+			return ErrUnauthenticated
+		}
+		if !valid(auth[len(prefix):]) {
+			return ErrUnauthenticated
+		}
+		return nil
+	}
+}
+
+// WebhookToken accepts requests from a webhook with this auth_token, sent in WebhookTokenHeader.
+func WebhookToken(token string) Authenticator {
+	return func(r *http.Request) error {
+		if t := r.Header.Get(WebhookTokenHeader); t == "" || !secureEqual(t, token) {
+			return ErrUnauthenticated
+		}
+		return nil
+	}
+}
+
+// secureEqual compares credentials in constant time, so they can't be guessed by timing responses.
+func secureEqual(a, b string) bool {
+	return subtle.ConstantTimeCompare([]byte(a), []byte(b)) == 1
+}
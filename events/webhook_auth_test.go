@@ -0,0 +1,76 @@
+package events
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestAuthenticators(t *testing.T) {
+	request := func(header, value string) *http.Request {
+		r := httptest.NewRequest("POST", "/webhook", nil)
+		if header != "" {
+			r.Header.Set(header, value)
+		}
+		return r
+	}
+
+	basic := BasicAuth("sparkpost", "s3cret")
+	ok := request("", "")
+	ok.SetBasicAuth("sparkpost", "s3cret")
+	wrong := request("", "")
+	wrong.SetBasicAuth("sparkpost", "guess")
+	bearer := BearerToken(func(token string) bool { return token == "issued-token" })
+	token := WebhookToken("5ebe2294ecd0e0f08eab7690d2a6ee69")
+
+	for i, c := range []struct {
+		auth Authenticator
+		r    *http.Request
+		ok   bool
+	}{
+		{basic, ok, true},
+		{basic, wrong, false},
+		{basic, request("", ""), false},
+		{bearer, request("Authorization", "Bearer issued-token"), true},
+		{bearer, request("Authorization", "bearer issued-token"), true},
+		{bearer, request("Authorization", "Bearer expired-token"), false},
+		{bearer, request("Authorization", "Bearer "), false},
+		{bearer, ok, false},
+		{token, request(WebhookTokenHeader, "5ebe2294ecd0e0f08eab7690d2a6ee69"), true},
+		{token, request(WebhookTokenHeader, "5ebe2294"), false},
+		{token, request("", ""), false},
+	} {
+		err := c.auth(c.r)
+		if c.ok && err != nil {
+			t.Errorf("case %d: rejected: %v", i, err)
+		} else if !c.ok && err != ErrUnauthenticated {
+			t.Errorf("case %d: returned %v, expected ErrUnauthenticated", i, err)
+		}
+	}
+}
+
+func TestWebhookHandler_authenticate(t *testing.T) {
+	called := false
+	h := WebhookHandler(func(context.Context, []Event) error {
+		called = true
+		return nil
+	})
+	h.Authenticate = BasicAuth("sparkpost", "s3cret")
+
+	batch := `[{"msys": {"message_event": {"type": "delivery"}}}]`
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("POST", "/webhook", strings.NewReader(batch)))
+	if w.Code != http.StatusUnauthorized || called {
+		t.Errorf("unauthenticated batch got %d (handled %v)", w.Code, called)
+	}
+
+	r := httptest.NewRequest("POST", "/webhook", strings.NewReader(batch))
+	r.SetBasicAuth("sparkpost", "s3cret")
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	if w.Code != http.StatusOK || !called {
+		t.Errorf("authenticated batch got %d (handled %v)", w.Code, called)
+	}
+}
@@ -0,0 +1,119 @@
+package events
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sync"
+)
+
+// DefaultMaxAttempts is how many times Handle may fail for a batch before a WebhookReceiver
+// with a DeadLetter sink gives up on it, unless MaxAttempts is set.
+const DefaultMaxAttempts = 5
+
+// maxTrackedBatches bounds the failed batches a WebhookReceiver counts attempts for.
+// Past it, counting starts over, which only delays dead-lettering.
+const maxTrackedBatches = 10000
+
+// DeadLetterSink stores the batches a WebhookReceiver gives up on, so they can be looked
+// into and replayed later. batch is the body of the request, and cause the last error
+// Handle returned for it.
+type DeadLetterSink interface {
+	WriteBatch(ctx context.Context, batchID string, batch []byte, cause error) error
+}
+
+// DeadLetterDir is a DeadLetterSink writing each batch to a file of its own in the directory,
+// named for the batch id with a .json extension. Replayer.ReplayFiles reads them back.
+type DeadLetterDir string
+
+func (d DeadLetterDir) WriteBatch(ctx context.Context, batchID string, batch []byte, cause error) error {
+	return os.WriteFile(filepath.Join(string(d), batchID+".json"), batch, 0600)
+}
+
+// DeadLetterFile is a DeadLetterSink appending each batch to the file as a line of JSON.
+// Name it with a .ndjson extension for Replayer.ReplayFiles to read it back.
+type DeadLetterFile string
+
+func (f DeadLetterFile) WriteBatch(ctx context.Context, batchID string, batch []byte, cause error) error {
+	file, err := os.OpenFile(string(f), os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+	if err != nil {
+		return err
+	}
+	if err = writeBatchLine(file, batch); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// DeadLetterWriter is a DeadLetterSink writing each batch to an io.Writer as a line of JSON.
+// It's safe for concurrent use.
+type DeadLetterWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewDeadLetterWriter returns a DeadLetterWriter writing to w.
+func NewDeadLetterWriter(w io.Writer) *DeadLetterWriter {
+	return &DeadLetterWriter{w: w}
+}
+
+func (s *DeadLetterWriter) WriteBatch(ctx context.Context, batchID string, batch []byte, cause error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return writeBatchLine(s.w, batch)
+}
+
+func writeBatchLine(w io.Writer, batch []byte) error {
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, batch); err != nil {
+		return err
+	}
+	buf.WriteByte('\n')
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// batchIDPattern matches batch ids safe to use in file names.
+var batchIDPattern = regexp.MustCompile(`^[A-Za-z0-9_-]{1,128}$`)
+
+// batchID identifies a batch across SparkPost's retries: by the batch id SparkPost sends,
+// or failing that by a hash of its body.
+func batchID(r *http.Request, body []byte) string {
+	if id := r.Header.Get("X-MessageSystems-Batch-ID"); batchIDPattern.MatchString(id) {
+		return id
+	}
+	sum := sha256.Sum256(body)
+	return hex.EncodeToString(sum[:])
+}
+
+// batchAttempts counts how many times Handle has failed for each batch.
+type batchAttempts struct {
+	mu     sync.Mutex
+	failed map[string]int
+}
+
+// fail records a failure for the batch, returning how many it's had.
+func (a *batchAttempts) fail(id string) int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.failed == nil || len(a.failed) >= maxTrackedBatches {
+		a.failed = map[string]int{}
+	}
+	a.failed[id]++
+	return a.failed[id]
+}
+
+// forget stops counting failures for the batch.
+func (a *batchAttempts) forget(id string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	delete(a.failed, id)
+}
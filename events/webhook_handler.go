@@ -0,0 +1,173 @@
+package events
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// DefaultMaxBatchBytes is the largest batch a WebhookReceiver accepts unless MaxBytes is set.
+// SparkPost batches are far smaller.
+const DefaultMaxBatchBytes = 10 << 20
+
+// WebhookReceiver is an http.Handler for the batches of events SparkPost POSTs to a webhook.
+// It responds 200 only once Handle has returned without error, so SparkPost retries a batch
+// that failed. Errors wrapping ErrQueueFull get a 503 response, and other errors a 500.
+// With a DeadLetter sink, a batch Handle keeps failing for is eventually stored there and
+// acknowledged instead, so it doesn't hold up SparkPost's queue of batches for the webhook.
+// Define one with WebhookHandler.
+type WebhookReceiver struct {
+	// Handle is called with the events of each batch.
+	Handle func(ctx context.Context, events []Event) error
+
+	// MaxBytes limits the size of a batch; DefaultMaxBatchBytes if zero.
+	MaxBytes int64
+	// Timeout, if non-zero, limits how long Handle has, through its ctx.
+	Timeout time.Duration
+	// Authenticate, if set, checks each request before its body is read.
+	// Requests it rejects get a 401 response.
+	Authenticate Authenticator
+	// OnError, if set, is called with any error the batch is rejected for,
+	// and with the error of each batch that's dead-lettered.
+	OnError func(r *http.Request, err error)
+
+	// DeadLetter, if set, is given a batch once Handle has failed for it MaxAttempts times,
+	// not counting ErrQueueFull, and the batch then gets a 200 response. Batches are told
+	// apart by the batch id SparkPost sends, and attempts are counted by this receiver only,
+	// so each instance of a load-balanced endpoint counts its own.
+	DeadLetter DeadLetterSink
+	// MaxAttempts is how many times Handle may fail for a batch; DefaultMaxAttempts if zero.
+	MaxAttempts int
+
+	attempts batchAttempts
+}
+
+// WebhookHandler returns a WebhookReceiver calling fn with the events of each batch.
+func WebhookHandler(fn func(ctx context.Context, events []Event) error) *WebhookReceiver {
+	return &WebhookReceiver{Handle: fn}
+}
+
+func (h *WebhookReceiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		w.Header().Set("Allow", "POST")
+		h.fail(w, r, http.StatusMethodNotAllowed, errors.New("webhook batches must be POSTed"))
+		return
+	}
+
+	if h.Authenticate != nil {
+		if err := h.Authenticate(r); err != nil {
+			h.fail(w, r, http.StatusUnauthorized, err)
+			return
+		}
+	}
+
+	max := h.MaxBytes
+	if max == 0 {
+		max = DefaultMaxBatchBytes
+	}
+	// the body is only kept when it may need to be dead-lettered
+	limited := &limitedBody{r: http.MaxBytesReader(w, r.Body, max), max: max}
+	var body []byte
+	var events Events
+	var err error
+	if h.DeadLetter != nil {
+		if body, err = io.ReadAll(limited); err == nil {
+			events, err = ParseWebhookBatch(bytes.NewReader(body))
+		}
+	} else {
+		events, err = ParseWebhookBatch(limited)
+	}
+	if err != nil {
+		if limited.tooLarge() {
+			h.fail(w, r, http.StatusRequestEntityTooLarge, err)
+		} else {
+			h.fail(w, r, http.StatusBadRequest, err)
+		}
+		return
+	}
+
+	// the empty batch sent to validate a webhook only needs a 200
+	if len(events) > 0 {
+		ctx := r.Context()
+		if h.Timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, h.Timeout)
+			defer cancel()
+		}
+		err = h.Handle(ctx, events)
+		if h.DeadLetter != nil {
+			if err == nil {
+				h.attempts.forget(batchID(r, body))
+			} else if !errors.Is(err, ErrQueueFull) {
+				err = h.deadLetter(r, body, err)
+			}
+		}
+		if err != nil {
+			if errors.Is(err, ErrQueueFull) {
+				// SparkPost will retry, which slows it down while the queue drains
+				h.fail(w, r, http.StatusServiceUnavailable, err)
+			} else {
+				h.fail(w, r, http.StatusInternalServerError, err)
+			}
+			return
+		}
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// limitedBody counts the bytes read from a request body limited by http.MaxBytesReader,
+// so a body over the limit can be told apart from one which isn't a valid batch.
+type limitedBody struct {
+	r   io.Reader
+	n   int64
+	max int64
+	err error
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	n, err := b.r.Read(p)
+	b.n += int64(n)
+	if err != nil && err != io.EOF {
+		b.err = err
+	}
+	return n, err
+}
+
+// tooLarge reports whether reading stopped at the limit.
+func (b *limitedBody) tooLarge() bool {
+	return b.err != nil && b.n >= b.max
+}
+
+// deadLetter counts a failure of Handle for the batch, and once it's had MaxAttempts,
+// writes the batch to DeadLetter. The returned error is nil if the batch was written,
+// and otherwise what the batch should be rejected for.
+func (h *WebhookReceiver) deadLetter(r *http.Request, body []byte, cause error) error {
+	max := h.MaxAttempts
+	if max <= 0 {
+		max = DefaultMaxAttempts
+	}
+	id := batchID(r, body)
+	n := h.attempts.fail(id)
+	if n < max {
+		return cause
+	}
+	if err := h.DeadLetter.WriteBatch(r.Context(), id, body, cause); err != nil {
+		return fmt.Errorf("dead-lettering batch %s: %v (after %v)", id, err, cause)
+	}
+	h.attempts.forget(id)
+	if h.OnError != nil {
+		h.OnError(r, fmt.Errorf("batch %s dead-lettered after %d attempts: %w", id, n, cause))
+	}
+	return nil
+}
+
+func (h *WebhookReceiver) fail(w http.ResponseWriter, r *http.Request, code int, err error) {
+	if h.OnError != nil {
+		h.OnError(r, err)
+	}
+	http.Error(w, http.StatusText(code), code)
+}
@@ -0,0 +1,164 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWebhookHandler(t *testing.T) {
+	samples, err := ioutil.ReadFile("sample-events.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []Event
+	var handleErr error
+	var deadline bool
+	h := WebhookHandler(func(ctx context.Context, events []Event) error {
+		got = events
+		_, deadline = ctx.Deadline()
+		return handleErr
+	})
+	var rejected []error
+	h.OnError = func(r *http.Request, err error) { rejected = append(rejected, err) }
+
+	post := func(method, body string) int {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(method, "/webhook", strings.NewReader(body)))
+		return w.Code
+	}
+
+	if code := post("POST", string(samples)); code != http.StatusOK {
+		t.Errorf("batch got %d", code)
+	}
+	if len(got) != 23 || deadline {
+		t.Errorf("Handle got %d events (deadline %v)", len(got), deadline)
+	}
+
+	// the validation batch doesn't reach Handle
+	got = nil
+	if code := post("POST", `[{"msys":{}}]`); code != http.StatusOK || got != nil {
+		t.Errorf("validation batch got %d, Handle saw %v", code, got)
+	}
+
+	h.Timeout = time.Second
+	handleErr = errors.New("database is down")
+	if code := post("POST", string(samples)); code != http.StatusInternalServerError {
+		t.Errorf("failed batch got %d", code)
+	}
+	if !deadline {
+		t.Error("Handle's ctx had no deadline")
+	}
+
+	h.MaxBytes = 100
+	for body, want := range map[string]int{
+		string(samples):   http.StatusRequestEntityTooLarge,
+		`{"results": []}`: http.StatusBadRequest,
+		// at the limit, but not a batch
+		strings.Repeat("x", 100): http.StatusBadRequest,
+	} {
+		if code := post("POST", body); code != want {
+			t.Errorf("got %d, expected %d", code, want)
+		}
+	}
+	if code := post("GET", ""); code != http.StatusMethodNotAllowed {
+		t.Errorf("GET got %d", code)
+	}
+	if len(rejected) != 5 || rejected[0] != handleErr {
+		t.Errorf("OnError saw %v", rejected)
+	}
+}
+
+func TestWebhookHandler_deadLetter(t *testing.T) {
+	samples, err := ioutil.ReadFile("sample-events.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	handleErr := errors.New("poison batch")
+	h := WebhookHandler(func(ctx context.Context, events []Event) error { return handleErr })
+	var dead strings.Builder
+	h.DeadLetter = NewDeadLetterWriter(&dead)
+	h.MaxAttempts = 3
+	var rejected []error
+	h.OnError = func(r *http.Request, err error) { rejected = append(rejected, err) }
+
+	post := func(batchID string) int {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest("POST", "/webhook", strings.NewReader(string(samples)))
+		r.Header.Set("X-MessageSystems-Batch-ID", batchID)
+		h.ServeHTTP(w, r)
+		return w.Code
+	}
+
+	var codes []int
+	for i := 0; i < 3; i++ {
+		codes = append(codes, post("batch-1"))
+	}
+	if codes[0] != http.StatusInternalServerError || codes[1] != http.StatusInternalServerError || codes[2] != http.StatusOK {
+		t.Errorf("attempts got %v", codes)
+	}
+	if len(rejected) != 3 || !errors.Is(rejected[2], handleErr) || !strings.Contains(rejected[2].Error(), "batch-1") {
+		t.Errorf("OnError saw %v", rejected)
+	}
+	batches, err := ParseNDJSON(strings.NewReader(dead.String()))
+	if err != nil || len(batches) != 23 {
+		t.Errorf("dead-lettered %d events (%v)", len(batches), err)
+	}
+
+	// the count starts over once a batch is dead-lettered, and a full queue doesn't count
+	handleErr = ErrQueueFull
+	for i := 0; i < 3; i++ {
+		if code := post("batch-1"); code != http.StatusServiceUnavailable {
+			t.Errorf("full queue got %d", code)
+		}
+	}
+
+	// batches without an id are told apart by their body
+	dir := t.TempDir()
+	h.DeadLetter = DeadLetterDir(dir)
+	h.MaxAttempts = 1
+	handleErr = errors.New("still broken")
+	if code := post("../escape"); code != http.StatusOK {
+		t.Errorf("batch got %d", code)
+	}
+	files, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+	if len(files) != 1 || len(filepath.Base(files[0])) != 64+len(".json") {
+		t.Errorf("dead-letter dir has %v", files)
+	}
+
+	h.DeadLetter = DeadLetterDir(filepath.Join(dir, "missing"))
+	if code := post("batch-2"); code != http.StatusInternalServerError {
+		t.Errorf("batch which couldn't be dead-lettered got %d", code)
+	}
+
+	// the body read for dead-lettering is limited too
+	h.MaxBytes = 100
+	if code := post("batch-3"); code != http.StatusRequestEntityTooLarge {
+		t.Errorf("oversized batch got %d", code)
+	}
+}
+
+func TestDeadLetterFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dead.ndjson")
+	sink := DeadLetterFile(path)
+	for _, batch := range []string{`[{"msys": {"message_event": {"type": "delivery"}}}]`, "[\n{\"msys\": {}}\n]"} {
+		if err := sink.WriteBatch(context.Background(), "id", []byte(batch), nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "[{\"msys\":{\"message_event\":{\"type\":\"delivery\"}}}]\n[{\"msys\":{}}]\n" {
+		t.Errorf("dead-letter file has %q", data)
+	}
+}
@@ -0,0 +1,114 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+var (
+	// ErrQueueFull is returned by WebhookQueue.Enqueue when the queue has no room for a batch.
+	ErrQueueFull = errors.New("webhook queue is full")
+	// ErrQueueClosed is returned by WebhookQueue.Enqueue once the queue is closed.
+	ErrQueueClosed = errors.New("webhook queue is closed")
+)
+
+// WebhookQueue holds webhook batches in a bounded queue, for a pool of workers to process,
+// so SparkPost gets a quick response however slow processing is. Enqueue can be passed
+// straight to WebhookHandler:
+//
+//	q := events.NewWebhookQueue(process, 100, 4)
+//	http.Handle("/webhook", events.WebhookHandler(q.Enqueue))
+//
+// When the queue is full, the WebhookReceiver responds 503, and SparkPost's retries hold
+// batches back until processing catches up. Batches are acknowledged once queued, so process
+// errors can't be retried by SparkPost; see OnError.
+type WebhookQueue struct {
+	// OnError, if set, is called with each batch process returns an error for.
+	// Set it before the first call to Enqueue.
+	OnError func(events []Event, err error)
+
+	process func(ctx context.Context, events []Event) error
+	queue   chan []Event
+	ctx     context.Context
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+
+	mu     sync.RWMutex
+	closed bool
+}
+
+// NewWebhookQueue returns a WebhookQueue holding up to size batches, started with workers
+// goroutines calling process for each batch. size and workers are at least 1.
+func NewWebhookQueue(process func(ctx context.Context, events []Event) error, size, workers int) *WebhookQueue {
+	if size < 1 {
+		size = 1
+	}
+	if workers < 1 {
+		workers = 1
+	}
+
+	q := &WebhookQueue{process: process, queue: make(chan []Event, size)}
+	q.ctx, q.cancel = context.WithCancel(context.Background())
+	q.wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go q.work()
+	}
+	return q
+}
+
+// Enqueue adds a batch to the queue, returning ErrQueueFull if there's no room for it.
+// It doesn't wait for the batch to be processed.
+func (q *WebhookQueue) Enqueue(ctx context.Context, events []Event) error {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	if q.closed {
+		return ErrQueueClosed
+	}
+	select {
+	case q.queue <- events:
+		return nil
+	default:
+		return ErrQueueFull
+	}
+}
+
+// Len returns the number of batches waiting to be processed.
+func (q *WebhookQueue) Len() int {
+	return len(q.queue)
+}
+
+// Close stops the queue accepting batches, and waits for those already queued to be processed.
+// If ctx is done first, the context passed to process is cancelled, and ctx's error returned.
+func (q *WebhookQueue) Close(ctx context.Context) error {
+	q.mu.Lock()
+	if !q.closed {
+		q.closed = true
+		close(q.queue)
+	}
+	q.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		q.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		q.cancel()
+		return nil
+	case <-ctx.Done():
+		q.cancel()
+		return ctx.Err()
+	}
+}
+
+func (q *WebhookQueue) work() {
+	defer q.wg.Done()
+	for events := range q.queue {
+		if err := q.process(q.ctx, events); err != nil && q.OnError != nil {
+			q.OnError(events, err)
+		}
+	}
+}
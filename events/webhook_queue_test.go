@@ -0,0 +1,83 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestWebhookQueue(t *testing.T) {
+	release := make(chan struct{})
+	var mu sync.Mutex
+	var processed, failed int
+	q := NewWebhookQueue(func(ctx context.Context, events []Event) error {
+		<-release
+		mu.Lock()
+		defer mu.Unlock()
+		processed += len(events)
+		if events[0].(*Delivery).MessageID == "bad" {
+			return errors.New("downstream is down")
+		}
+		return nil
+	}, 2, 1)
+	q.OnError = func(events []Event, err error) { failed++ }
+
+	h := WebhookHandler(q.Enqueue)
+	post := func(id string) int {
+		body := `[{"msys": {"message_event": {"type": "delivery", "message_id": "` + id + `"}}}]`
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest("POST", "/webhook", strings.NewReader(body)))
+		return w.Code
+	}
+
+	// the worker takes the first batch, then two fill the queue
+	if code := post("bad"); code != http.StatusOK {
+		t.Fatalf("first batch got %d", code)
+	}
+	for q.Len() > 0 {
+		time.Sleep(time.Millisecond)
+	}
+	for _, id := range []string{"a", "b"} {
+		if code := post(id); code != http.StatusOK {
+			t.Errorf("batch %s got %d", id, code)
+		}
+	}
+	if code := post("c"); code != http.StatusServiceUnavailable {
+		t.Errorf("batch over the limit got %d, expected 503", code)
+	}
+	if q.Len() != 2 {
+		t.Errorf("queue holds %d batches, expected 2", q.Len())
+	}
+
+	close(release)
+	if err := q.Close(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if processed != 3 || failed != 1 {
+		t.Errorf("processed %d batches, %d failed; expected 3, 1", processed, failed)
+	}
+	if err := q.Enqueue(context.Background(), nil); err != ErrQueueClosed {
+		t.Errorf("Enqueue after Close returned %v", err)
+	}
+}
+
+func TestWebhookQueue_closeTimeout(t *testing.T) {
+	q := NewWebhookQueue(func(ctx context.Context, events []Event) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}, 1, 1)
+	if err := q.Enqueue(context.Background(), Events{&Open{}}); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := q.Close(ctx); err != context.DeadlineExceeded {
+		t.Errorf("Close returned %v", err)
+	}
+}
@@ -0,0 +1,262 @@
+package events
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strconv"
+)
+
+// Parsed events marshal back to the JSON they were parsed from: fields keep their order and
+// form (numbers sent as strings stay strings, and so on), fields the struct doesn't have are
+// kept, and fields the event didn't have are left out, unless they've since been set.
+// Changed fields are marshaled from the struct. Events created in code marshal as usual.
+
+// The plain types have the fields but not the methods of the event structs,
+// so MarshalJSON can marshal them without recursing.
+type (
+	plainBounce            Bounce
+	plainClick             Click
+	plainCreation          Creation
+	plainDelay             Delay
+	plainDelivery          Delivery
+	plainGenerationFailure GenerationFailure
+	plainInjection         Injection
+	plainListUnsubscribe   ListUnsubscribe
+	plainOpen              Open
+	plainOutOfBand         OutOfBand
+	plainPolicyRejection   PolicyRejection
+	plainRelayDelivery     RelayDelivery
+	plainRelayInjection    RelayInjection
+	plainRelayMessage      RelayMessage
+	plainRelayRejection    RelayRejection
+	plainRelayTempfail     RelayTempfail
+	plainSMSStatus         SMSStatus
+	plainSpamComplaint     SpamComplaint
+)
+
+func (e *Bounce) MarshalJSON() ([]byte, error) { return wireJSON(e.raw, (*plainBounce)(e)) }
+func (e *Click) MarshalJSON() ([]byte, error)  { return wireJSON(e.raw, (*plainClick)(e)) }
+func (e *AMPClick) MarshalJSON() ([]byte, error) {
+	return wireJSON(e.raw, (*plainClick)(e))
+}
+func (e *Creation) MarshalJSON() ([]byte, error) { return wireJSON(e.raw, (*plainCreation)(e)) }
+func (e *Delay) MarshalJSON() ([]byte, error)    { return wireJSON(e.raw, (*plainDelay)(e)) }
+func (e *Delivery) MarshalJSON() ([]byte, error) { return wireJSON(e.raw, (*plainDelivery)(e)) }
+func (e *GenerationFailure) MarshalJSON() ([]byte, error) {
+	return wireJSON(e.raw, (*plainGenerationFailure)(e))
+}
+func (e *GenerationRejection) MarshalJSON() ([]byte, error) {
+	return wireJSON(e.raw, (*plainGenerationFailure)(e))
+}
+func (e *Injection) MarshalJSON() ([]byte, error) { return wireJSON(e.raw, (*plainInjection)(e)) }
+func (e *ListUnsubscribe) MarshalJSON() ([]byte, error) {
+	return wireJSON(e.raw, (*plainListUnsubscribe)(e))
+}
+
+// MarshalJSON of LinkUnsubscribe marshals the embedded ListUnsubscribe on its own, since
+// its MarshalJSON would otherwise be promoted and marshal only that part of the event.
+func (e *LinkUnsubscribe) MarshalJSON() ([]byte, error) {
+	return wireJSON(e.raw, (*plainListUnsubscribe)(&e.ListUnsubscribe), struct {
+		EventCommon
+		UserAgent string `json:"user_agent"`
+	}{e.EventCommon, e.UserAgent})
+}
+
+func (e *Open) MarshalJSON() ([]byte, error)           { return wireJSON(e.raw, (*plainOpen)(e)) }
+func (e *InitialOpen) MarshalJSON() ([]byte, error)    { return wireJSON(e.raw, (*plainOpen)(e)) }
+func (e *AMPOpen) MarshalJSON() ([]byte, error)        { return wireJSON(e.raw, (*plainOpen)(e)) }
+func (e *AMPInitialOpen) MarshalJSON() ([]byte, error) { return wireJSON(e.raw, (*plainOpen)(e)) }
+func (e *OutOfBand) MarshalJSON() ([]byte, error)      { return wireJSON(e.raw, (*plainOutOfBand)(e)) }
+func (e *PolicyRejection) MarshalJSON() ([]byte, error) {
+	return wireJSON(e.raw, (*plainPolicyRejection)(e))
+}
+func (e *RelayDelivery) MarshalJSON() ([]byte, error) {
+	return wireJSON(e.raw, (*plainRelayDelivery)(e))
+}
+func (e *RelayInjection) MarshalJSON() ([]byte, error) {
+	return wireJSON(e.raw, (*plainRelayInjection)(e))
+}
+func (e *RelayMessage) MarshalJSON() ([]byte, error) {
+	return wireJSON(e.raw, (*plainRelayMessage)(e))
+}
+func (e *RelayRejection) MarshalJSON() ([]byte, error) {
+	return wireJSON(e.raw, (*plainRelayRejection)(e))
+}
+func (e *RelayTempfail) MarshalJSON() ([]byte, error) {
+	return wireJSON(e.raw, (*plainRelayTempfail)(e))
+}
+func (e *RelayPermfail) MarshalJSON() ([]byte, error) {
+	return wireJSON(e.raw, (*plainRelayTempfail)(e))
+}
+func (e *SMSStatus) MarshalJSON() ([]byte, error) { return wireJSON(e.raw, (*plainSMSStatus)(e)) }
+func (e *SpamComplaint) MarshalJSON() ([]byte, error) {
+	return wireJSON(e.raw, (*plainSpamComplaint)(e))
+}
+
+// MarshalJSON returns the JSON the event was parsed from.
+func (e *Unknown) MarshalJSON() ([]byte, error) {
+	if len(e.RawJSON) == 0 {
+		return []byte("null"), nil
+	}
+	return e.RawJSON, nil
+}
+
+// structJSON marshals e as an event created in code would be, with fields in the form of
+// the struct, such as numbers as numbers, rather than as parsed.
+func structJSON(e Event) ([]byte, error) {
+	v := reflect.ValueOf(e)
+	if _, ok := e.(rawHolder); !ok || v.Kind() != reflect.Ptr || v.IsNil() {
+		return json.Marshal(e)
+	}
+	cp := reflect.New(v.Elem().Type())
+	cp.Elem().Set(v.Elem())
+	cp.Interface().(rawHolder).setRaw(nil)
+	return json.Marshal(cp.Interface())
+}
+
+// wireJSON marshals values, which must marshal as JSON objects, into a single object,
+// with later values' fields overriding earlier ones, and merges it onto raw.
+func wireJSON(raw json.RawMessage, values ...interface{}) ([]byte, error) {
+	var fields *object
+	for _, v := range values {
+		data, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		o, err := parseObject(data)
+		if err != nil {
+			return nil, err
+		}
+		if fields == nil {
+			fields = o
+			continue
+		}
+		for _, name := range o.names {
+			fields.set(name, o.values[name])
+		}
+	}
+	if len(raw) == 0 {
+		return fields.marshal(), nil
+	}
+	return mergeWire(raw, fields.marshal()), nil
+}
+
+// mergeWire returns value in the form of orig: orig itself if value means the same,
+// and otherwise value, quoted if it's a number orig sent as a string. Objects are merged
+// field by field, dropping fields orig didn't have which are blank in value.
+func mergeWire(orig, value json.RawMessage) json.RawMessage {
+	if sameJSON(orig, value) {
+		return orig
+	}
+	origObj, err1 := parseObject(orig)
+	valueObj, err2 := parseObject(value)
+	if err1 == nil && err2 == nil {
+		merged := &object{values: map[string]json.RawMessage{}}
+		for _, name := range origObj.names {
+			v, ok := valueObj.values[name]
+			if !ok {
+				v = origObj.values[name]
+			} else {
+				v = mergeWire(origObj.values[name], v)
+			}
+			merged.set(name, v)
+		}
+		for _, name := range valueObj.names {
+			if _, ok := origObj.values[name]; !ok && !blankJSON(valueObj.values[name]) {
+				merged.set(name, valueObj.values[name])
+			}
+		}
+		return merged.marshal()
+	}
+	if s, err := strconv.Unquote(string(orig)); err == nil {
+		if _, err := strconv.ParseFloat(s, 64); err == nil && isNumber(value) {
+			return json.RawMessage(strconv.Quote(string(value)))
+		}
+	}
+	return value
+}
+
+// sameJSON reports whether a and b decode to the same value, counting numbers sent as
+// strings as the same as the numbers.
+func sameJSON(a, b json.RawMessage) bool {
+	if bytes.Equal(a, b) {
+		return true
+	}
+	if s, err := strconv.Unquote(string(a)); err == nil && isNumber(b) {
+		x, err1 := strconv.ParseFloat(s, 64)
+		y, err2 := strconv.ParseFloat(string(b), 64)
+		return err1 == nil && err2 == nil && x == y
+	}
+	var x, y interface{}
+	if json.Unmarshal(a, &x) != nil || json.Unmarshal(b, &y) != nil {
+		return false
+	}
+	return reflect.DeepEqual(x, y)
+}
+
+func isNumber(data json.RawMessage) bool {
+	_, err := strconv.ParseFloat(string(data), 64)
+	return err == nil
+}
+
+// blankJSON reports whether data is the zero value marshaled for a field.
+func blankJSON(data json.RawMessage) bool {
+	switch string(data) {
+	case "null", `""`, "0", "false", "[]", "{}":
+		return true
+	}
+	return false
+}
+
+// object is a JSON object which keeps the order of its fields.
+type object struct {
+	names  []string
+	values map[string]json.RawMessage
+}
+
+func parseObject(data []byte) (*object, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if err := expectDelim(dec, '{'); err != nil {
+		return nil, err
+	}
+	o := &object{values: map[string]json.RawMessage{}}
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		var value json.RawMessage
+		if err := dec.Decode(&value); err != nil {
+			return nil, err
+		}
+		o.set(tok.(string), value)
+	}
+	if err := expectDelim(dec, '}'); err != nil {
+		return nil, err
+	}
+	return o, nil
+}
+
+func (o *object) set(name string, value json.RawMessage) {
+	if _, ok := o.values[name]; !ok {
+		o.names = append(o.names, name)
+	}
+	o.values[name] = value
+}
+
+func (o *object) marshal() json.RawMessage {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, name := range o.names {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, _ := json.Marshal(name)
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(o.values[name])
+	}
+	buf.WriteByte('}')
+	return buf.Bytes()
+}
@@ -0,0 +1,84 @@
+package events
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"testing"
+)
+
+// wireBytes marshals e as json.Marshal does, without escaping HTML, compacted.
+func wireBytes(t *testing.T, e Event) string {
+	t.Helper()
+	var buf, out bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(e); err != nil {
+		t.Fatalf("%T: %v", e, err)
+	}
+	if err := json.Compact(&out, buf.Bytes()); err != nil {
+		t.Fatal(err)
+	}
+	return out.String()
+}
+
+func compactJSON(t *testing.T, raw json.RawMessage) string {
+	t.Helper()
+	var out bytes.Buffer
+	if err := json.Compact(&out, raw); err != nil {
+		t.Fatal(err)
+	}
+	return out.String()
+}
+
+func TestMarshalJSON_roundTrip(t *testing.T) {
+	data, err := os.ReadFile("sample-events.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var batch []struct {
+		Msys map[string]json.RawMessage `json:"msys"`
+	}
+	if err = json.Unmarshal(data, &batch); err != nil {
+		t.Fatal(err)
+	}
+	var raws []json.RawMessage
+	for _, wrapper := range batch {
+		for _, raw := range wrapper.Msys {
+			raws = append(raws, raw)
+		}
+	}
+	docs := loadDocumentation(t)
+	for _, eventType := range docs.EventTypes() {
+		raws = append(raws, docs.Schema(eventType).SampleJSON())
+	}
+
+	for _, raw := range raws {
+		e := ParseRawJSONEvent(raw)
+		if _, ok := e.(*Unknown); ok {
+			t.Errorf("couldn't parse %s", raw)
+			continue
+		}
+		if got, want := wireBytes(t, e), compactJSON(t, raw); got != want {
+			t.Errorf("%T marshaled to\n%s\nexpected\n%s", e, got, want)
+		}
+	}
+}
+
+func TestMarshalJSON_changes(t *testing.T) {
+	e := ParseRawJSONEvent(json.RawMessage(`{"type": "delivery", "msg_size": "1337", "queue_time": 12,
+		"timestamp": "1454442600", "new_field": {"a": 1}}`)).(*Delivery)
+	e.MessageSize = 2048
+	e.QueueTime = 13
+	e.CampaignID = "spring"
+	want := `{"type":"delivery","msg_size":"2048","queue_time":13,"timestamp":"1454442600","new_field":{"a":1},"campaign_id":"spring"}`
+	if got := wireBytes(t, e); got != want {
+		t.Errorf("got %s, expected %s", got, want)
+	}
+
+	// Events created in code marshal every field.
+	got := wireBytes(t, &Delivery{EventCommon: EventCommon{Type: "delivery"}, MessageSize: 1})
+	if !bytes.Contains([]byte(got), []byte(`"msg_size":1,`)) || !bytes.Contains([]byte(got), []byte(`"campaign_id":""`)) {
+		t.Errorf("got %s", got)
+	}
+}
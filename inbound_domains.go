@@ -0,0 +1,84 @@
+package gosparkpost
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// https://developers.sparkpost.com/api/#/reference/inbound-domains
+var inboundDomainsPathFormat = "/api/v%d/inbound-domains"
+
+// InboundDomain is the JSON structure accepted by and returned from the SparkPost Inbound Domains API.
+// Mail to an inbound domain is handed to the RelayWebhook matching it.
+type InboundDomain struct {
+	Domain string `json:"domain"`
+}
+
+// Create registers domain as an inbound domain.
+func (c *Client) InboundDomainCreate(domain string) (res *Response, err error) {
+	if domain == "" {
+		err = fmt.Errorf("Create called with blank domain")
+		return
+	}
+
+	path := fmt.Sprintf(inboundDomainsPathFormat, c.Config.ApiVersion)
+	res, err = c.doJSON(context.Background(), "POST", c.Config.BaseUrl+path, InboundDomain{Domain: domain}, "InboundDomain", "create")
+	if res.conflict() {
+		// handle inbound domain-specific ones
+		err = &prettyError{msg: fmt.Sprintf("Inbound domain [%s] already exists", domain), cause: res.SPError()}
+	}
+
+	return
+}
+
+// List returns all inbound domains in the system.
+func (c *Client) InboundDomains() ([]InboundDomain, *Response, error) {
+	path := fmt.Sprintf(inboundDomainsPathFormat, c.Config.ApiVersion)
+	res, err := c.doJSON(context.Background(), "GET", c.Config.BaseUrl+path, nil, "InboundDomain", "list")
+	if err != nil {
+		return nil, res, err
+	}
+
+	var list []InboundDomain
+	if err = res.DecodeResults(&list); err != nil {
+		return nil, res, err
+	}
+	return list, res, nil
+}
+
+// InboundDomain returns the specified inbound domain.
+func (c *Client) InboundDomain(domain string) (*InboundDomain, *Response, error) {
+	if domain == "" {
+		return nil, nil, fmt.Errorf("Retrieve called with blank domain")
+	}
+
+	path := fmt.Sprintf(inboundDomainsPathFormat, c.Config.ApiVersion)
+	res, err := c.doJSON(context.Background(), "GET", fmt.Sprintf("%s%s/%s", c.Config.BaseUrl, path, url.PathEscape(domain)), nil, "InboundDomain", "retrieve")
+	if err != nil {
+		return nil, res, err
+	}
+
+	d := &InboundDomain{}
+	if err = res.DecodeResults(d); err != nil {
+		return nil, res, err
+	}
+	return d, res, nil
+}
+
+// Delete removes the specified inbound domain.
+func (c *Client) InboundDomainDelete(domain string) (res *Response, err error) {
+	if domain == "" {
+		err = fmt.Errorf("Delete called with blank domain")
+		return
+	}
+
+	path := fmt.Sprintf(inboundDomainsPathFormat, c.Config.ApiVersion)
+	res, err = c.doJSON(context.Background(), "DELETE", fmt.Sprintf("%s%s/%s", c.Config.BaseUrl, path, url.PathEscape(domain)), nil, "InboundDomain", "delete")
+	if res.conflict() {
+		// handle inbound domain-specific ones
+		err = &prettyError{msg: fmt.Sprintf("Inbound domain [%s] is in use by a relay webhook", domain), cause: res.SPError()}
+	}
+
+	return
+}
@@ -0,0 +1,88 @@
+package gosparkpost
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestInboundDomains(t *testing.T) {
+	testSetup(t)
+	defer testTeardown()
+
+	// set up the response handlers
+	path := fmt.Sprintf(inboundDomainsPathFormat, testClient.Config.ApiVersion)
+	var created InboundDomain
+	testMux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json; charset=utf8")
+		if r.Method == "POST" {
+			json.NewDecoder(r.Body).Decode(&created)
+			w.Write([]byte(`{"results": {"message": "Successful"}}`))
+			return
+		}
+		w.Write([]byte(`{"results": [{"domain": "inbound.example.com"}, {"domain": "inbound2.example.com"}]}`))
+	})
+	var methods []string
+	testMux.HandleFunc(path+"/inbound.example.com", func(w http.ResponseWriter, r *http.Request) {
+		methods = append(methods, r.Method)
+		if r.Method == "DELETE" {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json; charset=utf8")
+		w.Write([]byte(`{"results": {"domain": "inbound.example.com"}}`))
+	})
+
+	if res, err := testClient.InboundDomainCreate("inbound.example.com"); err != nil {
+		testFailVerbose(t, res, "InboundDomainCreate returned error: %v", err)
+	}
+	if created.Domain != "inbound.example.com" {
+		t.Errorf("server saw %+v", created)
+	}
+
+	list, res, err := testClient.InboundDomains()
+	if err != nil {
+		testFailVerbose(t, res, "InboundDomains returned error: %v", err)
+	}
+	if len(list) != 2 || list[1].Domain != "inbound2.example.com" {
+		t.Errorf("InboundDomains returned %+v", list)
+	}
+
+	d, res, err := testClient.InboundDomain("inbound.example.com")
+	if err != nil {
+		testFailVerbose(t, res, "InboundDomain returned error: %v", err)
+	}
+	if d == nil || d.Domain != "inbound.example.com" {
+		t.Errorf("InboundDomain returned %+v", d)
+	}
+
+	if res, err = testClient.InboundDomainDelete("inbound.example.com"); err != nil {
+		testFailVerbose(t, res, "InboundDomainDelete returned error: %v", err)
+	}
+	if fmt.Sprint(methods) != "[GET DELETE]" {
+		t.Errorf("server saw methods %v", methods)
+	}
+}
+
+func TestInboundDomain_notFound(t *testing.T) {
+	testSetup(t)
+	defer testTeardown()
+
+	// set up the response handler
+	path := fmt.Sprintf(inboundDomainsPathFormat, testClient.Config.ApiVersion)
+	testMux.HandleFunc(path+"/missing.example.com", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json; charset=utf8")
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"errors": [{"message": "resource not found", "code": "1600"}]}`))
+	})
+
+	d, _, err := testClient.InboundDomain("missing.example.com")
+	if d != nil || !errors.Is(err, ErrNotFound) {
+		t.Errorf("InboundDomain returned %+v, %v; expected ErrNotFound", d, err)
+	}
+	if _, err = testClient.InboundDomainCreate(""); err == nil {
+		t.Error("InboundDomainCreate accepted a blank domain")
+	}
+}
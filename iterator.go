@@ -0,0 +1,139 @@
+package gosparkpost
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// Iterator steps through the results of a list endpoint, fetching further pages as needed:
+//
+//	it := client.IterSuppressions(nil)
+//	for it.Next(ctx) {
+//		entry := it.Item().(*SuppressionEntry)
+//	}
+//	if err := it.Err(); err != nil {
+//		// handle error
+//	}
+type Iterator interface {
+	// Next advances to the next item, returning false when there are no more items or an error occurred.
+	Next(ctx context.Context) bool
+	// Item returns the current item. Its type depends on the endpoint.
+	Item() interface{}
+	// Err returns the error that stopped iteration, if any.
+	Err() error
+}
+
+// resultIterator is an Iterator over the "results" array of a paged endpoint.
+type resultIterator struct {
+	client *Client
+	url    string
+	noun   string
+	decode func(json.RawMessage) (interface{}, error)
+
+	pager *Paginator
+	items []json.RawMessage
+	item  interface{}
+	err   error
+}
+
+func (it *resultIterator) Next(ctx context.Context) bool {
+	for it.err == nil {
+		if len(it.items) > 0 {
+			it.item, it.err = it.decode(it.items[0])
+			it.items = it.items[1:]
+			return it.err == nil
+		}
+
+		var res *Response
+		if it.pager == nil {
+			res, it.err = it.first(ctx)
+		} else if it.pager.More() {
+			res, it.err = it.pager.Next(ctx)
+		} else {
+			return false
+		}
+		if it.err != nil {
+			return false
+		}
+
+		var page struct {
+			Results []json.RawMessage `json:"results"`
+		}
+		if it.err = json.Unmarshal(res.Body, &page); it.err != nil {
+			return false
+		}
+		it.items = page.Results
+	}
+	return false
+}
+
+func (it *resultIterator) first(ctx context.Context) (*Response, error) {
+	res, err := it.client.doJSON(ctx, "GET", it.url, nil, it.noun, "list")
+	if err != nil {
+		return res, err
+	}
+
+	it.pager, err = it.client.NewPaginator(res, 0)
+	return res, err
+}
+
+func (it *resultIterator) Item() interface{} { return it.item }
+
+func (it *resultIterator) Err() error { return it.err }
+
+// IterTransmissions returns an Iterator over the same results as Transmissions. Items are *Transmission.
+func (c *Client) IterTransmissions(campaignID, templateID *string) Iterator {
+	return &resultIterator{
+		client: c,
+		url:    c.transmissionsUrl(campaignID, templateID),
+		noun:   "Transmission",
+		decode: func(raw json.RawMessage) (interface{}, error) {
+			t := &Transmission{}
+			return t, unmarshalJSON(raw, t, c.Config.StrictDecoding)
+		},
+	}
+}
+
+// IterSuppressions returns an Iterator over the same results as SuppressionSearch,
+// following any further pages. Items are *SuppressionEntry.
+func (c *Client) IterSuppressions(parameters map[string]string) Iterator {
+	path := fmt.Sprintf(suppressionListsPathFormat, c.Config.ApiVersion)
+	return &resultIterator{
+		client: c,
+		url:    buildUrl(c, path, parameters),
+		noun:   "SuppressionEntry",
+		decode: func(raw json.RawMessage) (interface{}, error) {
+			e := &SuppressionEntry{}
+			return e, unmarshalJSON(raw, e, c.Config.StrictDecoding)
+		},
+	}
+}
+
+// IterSuppressionSearch returns an Iterator over every suppression entry matching opts.
+// Cursor-based paging is used, starting from the first page unless opts.Cursor is set,
+// so all pages can be drained however many entries there are. Items are *SuppressionEntry.
+func (c *Client) IterSuppressionSearch(opts *SuppressionSearchOptions) Iterator {
+	o := SuppressionSearchOptions{}
+	if opts != nil {
+		o = *opts
+	}
+	if o.Cursor == "" {
+		o.Cursor = "initial"
+	}
+	return c.IterSuppressions(o.Map())
+}
+
+// IterMessageEvents returns an Iterator over the same results as MessageEvents,
+// following any further pages. Items are events.Event.
+func (c *Client) IterMessageEvents(params map[string]string) Iterator {
+	path := fmt.Sprintf(messageEventsPathFormat, "", c.Config.ApiVersion)
+	return &resultIterator{
+		client: c,
+		url:    buildUrl(c, path, params),
+		noun:   "MessageEvents",
+		decode: func(raw json.RawMessage) (interface{}, error) {
+			return c.parseEvent(raw), nil
+		},
+	}
+}
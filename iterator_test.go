@@ -0,0 +1,107 @@
+package gosparkpost
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestIterSuppressions(t *testing.T) {
+	testSetup(t)
+	defer testTeardown()
+
+	// set up the response handler
+	path := fmt.Sprintf(suppressionListsPathFormat, testClient.Config.ApiVersion)
+	testMux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		w.Header().Set("Content-Type", "application/json; charset=utf8")
+		if r.URL.Query().Get("cursor") == "" {
+			fmt.Fprintf(w, `{"results": [{"recipient": "a@example.com"}, {"recipient": "b@example.com"}],
+				"links": {"next": "%s?cursor=2"}}`, path)
+			return
+		}
+		w.Write([]byte(`{"results": [{"recipient": "c@example.com"}], "links": {}}`))
+	})
+
+	it := testClient.IterSuppressions(nil)
+	seen := []string{}
+	for it.Next(context.Background()) {
+		seen = append(seen, it.Item().(*SuppressionEntry).Recipient)
+	}
+	if err := it.Err(); err != nil {
+		t.Fatalf("iterator returned error: %v", err)
+	}
+	if fmt.Sprint(seen) != "[a@example.com b@example.com c@example.com]" {
+		t.Errorf("iterator returned %v", seen)
+	}
+}
+
+func TestIterSuppressionSearch(t *testing.T) {
+	testSetup(t)
+	defer testTeardown()
+
+	// set up the response handler, serving three one-entry pages
+	var queries []string
+	path := fmt.Sprintf(suppressionListsPathFormat, testClient.Config.ApiVersion)
+	testMux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		queries = append(queries, r.URL.RawQuery)
+		w.Header().Set("Content-Type", "application/json; charset=utf8")
+		switch r.URL.Query().Get("cursor") {
+		case "initial":
+			fmt.Fprintf(w, `{"results": [{"recipient": "a@example.com"}], "total_count": 3,
+				"links": [{"href": "%s?cursor=c2&per_page=1", "rel": "next"}]}`, path)
+		case "c2":
+			fmt.Fprintf(w, `{"results": [{"recipient": "b@example.com"}], "total_count": 3,
+				"links": [{"href": "%s?cursor=c3&per_page=1", "rel": "next"}]}`, path)
+		default:
+			w.Write([]byte(`{"results": [{"recipient": "c@example.com"}], "total_count": 3, "links": []}`))
+		}
+	})
+
+	it := testClient.IterSuppressionSearch(&SuppressionSearchOptions{Types: []string{"transactional"}, PerPage: 1})
+	seen := []string{}
+	for it.Next(context.Background()) {
+		seen = append(seen, it.Item().(*SuppressionEntry).Recipient)
+	}
+	if err := it.Err(); err != nil {
+		t.Fatalf("iterator returned error: %v", err)
+	}
+	if fmt.Sprint(seen) != "[a@example.com b@example.com c@example.com]" {
+		t.Errorf("iterator returned %v", seen)
+	}
+	if len(queries) != 3 || queries[0] != "cursor=initial&per_page=1&types=transactional" {
+		t.Errorf("iterator sent queries %q", queries)
+	}
+
+	// a single page keeps its links for manual paging
+	list, res, err := testClient.SuppressionSearch((&SuppressionSearchOptions{Cursor: "initial", PerPage: 1}).Map())
+	if err != nil {
+		testFailVerbose(t, res, "SuppressionSearch returned error: %v", err)
+	}
+	if list.TotalCount != 3 || list.Links.Next() != path+"?cursor=c2&per_page=1" {
+		t.Errorf("SuppressionSearch returned links %v and total %d", list.Links, list.TotalCount)
+	}
+}
+
+func TestIterTransmissions_error(t *testing.T) {
+	testSetup(t)
+	defer testTeardown()
+
+	// set up the response handler
+	path := fmt.Sprintf(transmissionsPathFormat, testClient.Config.ApiVersion)
+	testMux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json; charset=utf8")
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"errors": [{"message": "Unauthorized."}]}`))
+	})
+
+	it := testClient.IterTransmissions(nil, nil)
+	if it.Next(context.Background()) {
+		t.Fatal("iterator returned an item for an error response")
+	}
+	if it.Err() == nil {
+		t.Fatal("iterator didn't return an error")
+	}
+}
@@ -1,11 +1,12 @@
 package gosparkpost
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
-	"net/url"
-	"strings"
+	"strconv"
+	"time"
 
 	"github.com/SparkPost/gosparkpost/events"
 )
@@ -17,6 +18,100 @@ var (
 	messageEventsSamplesPathFormat = "%s/api/v%d/message-events/events/samples"
 )
 
+// MaxEventsPerPage is the largest page size accepted by the message events API.
+const MaxEventsPerPage = 10000
+
+// MaxEventsResults is the number of events a single search can page through.
+// Narrow the search, by time for instance, to see events past it.
+const MaxEventsResults = 10000
+
+// ErrEventsWindow is returned by EventsPage.EachEvent when a search matched more than MaxEventsResults events.
+var ErrEventsWindow = errors.New("message events search matched more events than can be paged through")
+
+// MessageEventsRetention is how long the message events API keeps events for.
+const MessageEventsRetention = 10 * 24 * time.Hour
+
+// ErrEventsRetention is returned by MessageEventsInRange, along with the events it found,
+// when the range started before MessageEventsRetention ago, so older events weren't searched.
+var ErrEventsRetention = errors.New("message events search starts before events are retained")
+
+// eventsNow is the time MessageEventsInRange counts retention from.
+var eventsNow = time.Now
+
+// MessageEventsSearchOptions holds the filters accepted by the message events endpoint.
+// Zero values are omitted from the query.
+type MessageEventsSearchOptions struct {
+	// From and To limit results to events in that window; the API defaults to the last 24 hours.
+	From time.Time
+	To   time.Time
+	// Events is any of the event types known to events.ValidEventType, such as "bounce".
+	Events          []string
+	Recipients      []string
+	MessageIDs      []string
+	CampaignIDs     []string
+	TemplateIDs     []string
+	TransmissionIDs []string
+	// BounceClasses are the numeric bounce classification codes, such as 10 for an invalid recipient.
+	BounceClasses []int
+	// Reason matches bounce and delay reasons containing it; % is a wildcard.
+	Reason      string
+	Subaccounts []int
+
+	// PerPage is the number of events in each page, up to MaxEventsPerPage.
+	// Page is the 1-based page to return.
+	PerPage int
+	Page    int
+}
+
+// Validate checks the event types and page size, which the API would reject.
+func (o *MessageEventsSearchOptions) Validate() error {
+	if o == nil {
+		return nil
+	}
+	for _, etype := range o.Events {
+		if !events.ValidEventType(etype) {
+			return fmt.Errorf("Invalid event type [%s]", etype)
+		}
+	}
+	if o.PerPage < 0 || o.PerPage > MaxEventsPerPage {
+		return fmt.Errorf("MessageEventsSearchOptions.PerPage must be between 1 and %d", MaxEventsPerPage)
+	}
+	return nil
+}
+
+// Map returns the search parameters, for use with MessageEvents and IterMessageEvents.
+func (o *MessageEventsSearchOptions) Map() map[string]string {
+	if o == nil {
+		return nil
+	}
+	p := NewParams().TimeRange(o.From, o.To).
+		List("events", o.Events).
+		List("recipients", o.Recipients).
+		List("message_ids", o.MessageIDs).
+		List("campaign_ids", o.CampaignIDs).
+		List("template_ids", o.TemplateIDs).
+		List("transmission_ids", o.TransmissionIDs).
+		List("bounce_classes", itoaAll(o.BounceClasses)).
+		Set("reason", o.Reason).
+		List("subaccounts", itoaAll(o.Subaccounts))
+	if o.PerPage > 0 {
+		p.Int("per_page", o.PerPage)
+	}
+	if o.Page > 0 {
+		p.Int("page", o.Page)
+	}
+	return p.Map()
+}
+
+// itoaAll returns the decimal representation of each of ns.
+func itoaAll(ns []int) []string {
+	out := make([]string, len(ns))
+	for i, n := range ns {
+		out[i] = strconv.Itoa(n)
+	}
+	return out
+}
+
 type EventsPage struct {
 	client *Client
 
@@ -29,79 +124,313 @@ type EventsPage struct {
 }
 
 // https://developers.sparkpost.com/api/#/reference/message-events/events-samples/search-for-message-events
-func (c *Client) MessageEvents(params map[string]string) (*EventsPage, error) {
-	url, err := url.Parse(fmt.Sprintf(messageEventsPathFormat, c.Config.BaseUrl, c.Config.ApiVersion))
+func (c *Client) MessageEvents(params map[string]string) (*EventsPage, *Response, error) {
+	return c.MessageEventsContext(context.Background(), params)
+}
+
+// MessageEventsContext is like MessageEvents, but the request is bound to ctx.
+func (c *Client) MessageEventsContext(ctx context.Context, params map[string]string) (*EventsPage, *Response, error) {
+	finalUrl := ParamsFromMap(params).Url(fmt.Sprintf(messageEventsPathFormat, c.Config.BaseUrl, c.Config.ApiVersion))
+
+	// Send off our request
+	res, err := c.doJSON(ctx, "GET", finalUrl, nil, "", "")
+	if err != nil {
+		return nil, res, err
+	}
+
+	// Get the Content
+	bodyBytes, err := res.ReadBody()
+	if err != nil {
+		return nil, res, err
+	}
+
+	var eventsPage EventsPage
+	err = json.Unmarshal(bodyBytes, &eventsPage)
 	if err != nil {
+		return nil, res, err
+	}
+
+	eventsPage.client = c
+
+	return &eventsPage, res, nil
+}
+
+// MessageEventsSearch returns the page of events matching opts. Use EventsPage.Next for later pages.
+func (c *Client) MessageEventsSearch(opts *MessageEventsSearchOptions) (*EventsPage, *Response, error) {
+	return c.MessageEventsSearchContext(context.Background(), opts)
+}
+
+// MessageEventsSearchContext is like MessageEventsSearch, but the request is bound to ctx.
+func (c *Client) MessageEventsSearchContext(ctx context.Context, opts *MessageEventsSearchOptions) (*EventsPage, *Response, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, nil, err
+	}
+	return c.MessageEventsContext(ctx, opts.Map())
+}
+
+// messageIDsQueryLimit is the length of the message_ids parameter MessageEventsByMessageIDs
+// sends in one request, which keeps its URLs well under common length limits.
+const messageIDsQueryLimit = 4000
+
+// messageEvent is an event along with the id of its message.
+type messageEvent struct {
+	id    string
+	event events.Event
+}
+
+// MessageEventsByMessageIDs returns the events of each of the messages with the specified ids,
+// keyed by message id. Messages without events are left out. The ids are split across as
+// many requests as needed, each filtered by opts, whose MessageIDs are ignored. Note the API
+// only searches the last 24 hours unless opts.From is set.
+func (c *Client) MessageEventsByMessageIDs(ctx context.Context, ids []string, opts *MessageEventsSearchOptions) (map[string][]events.Event, error) {
+	o := MessageEventsSearchOptions{}
+	if opts != nil {
+		o = *opts
+	}
+	o.Page = 0
+	if o.PerPage == 0 {
+		o.PerPage = MaxEventsPerPage
+	}
+	if err := o.Validate(); err != nil {
 		return nil, err
 	}
 
-	if len(params) > 0 {
-		q := url.Query()
-		for k, v := range params {
-			q.Add(k, v)
+	byID := map[string][]events.Event{}
+	for len(ids) > 0 {
+		n, size := 0, 0
+		for n < len(ids) && (n == 0 || size+len(ids[n])+1 <= messageIDsQueryLimit) {
+			size += len(ids[n]) + 1
+			n++
+		}
+		o.MessageIDs, ids = ids[:n], ids[n:]
+
+		path := fmt.Sprintf(messageEventsPathFormat, "", c.Config.ApiVersion)
+		it := &resultIterator{
+			client: c,
+			url:    buildUrl(c, path, o.Map()),
+			noun:   "MessageEvents",
+			decode: func(raw json.RawMessage) (interface{}, error) {
+				var e struct {
+					MessageID string `json:"message_id"`
+				}
+				if err := json.Unmarshal(raw, &e); err != nil {
+					return nil, err
+				}
+				return messageEvent{e.MessageID, c.parseEvent(raw)}, nil
+			},
+		}
+		for it.Next(ctx) {
+			e := it.Item().(messageEvent)
+			byID[e.id] = append(byID[e.id], e.event)
+		}
+		if err := it.Err(); err != nil {
+			return nil, err
 		}
-		url.RawQuery = q.Encode()
 	}
+	return byID, nil
+}
+
+// MessageEventsEach is like MessageEvents, but decodes the response as it's read,
+// calling fn for each event instead of building a page of results in memory.
+// If fn returns an error, no more events are read and that error is returned.
+func (c *Client) MessageEventsEach(params map[string]string, fn func(events.Event) error) (*Response, error) {
+	finalUrl := ParamsFromMap(params).Url(fmt.Sprintf(messageEventsPathFormat, c.Config.BaseUrl, c.Config.ApiVersion))
 
 	// Send off our request
-	res, err := c.HttpGet(url.String())
+	res, err := c.HttpGet(finalUrl)
 	if err != nil {
-		return nil, err
+		return res, err
 	}
 
 	// Assert that we got a JSON Content-Type back
 	if err = res.AssertJson(); err != nil {
-		return nil, err
+		return res, err
+	}
+
+	if !res.success() {
+		if err = res.ParseResponse(); err != nil {
+			return res, err
+		}
+		return res, res.SPError()
+	}
+
+	err = res.EachResult(func(raw json.RawMessage) error {
+		return fn(c.parseEvent(raw))
+	})
+	if err == nil && len(res.Errors) > 0 {
+		err = res.SPError()
+	}
+	return res, err
+}
+
+// Next retrieves the page of events following this one.
+// ErrEmptyPage is returned when there are no more pages.
+func (events *EventsPage) Next() (*EventsPage, *Response, error) {
+	return events.NextContext(context.Background())
+}
+
+// NextContext is like Next, but the request is bound to ctx.
+func (events *EventsPage) NextContext(ctx context.Context) (*EventsPage, *Response, error) {
+	if events.nextPage == "" {
+		return nil, nil, ErrEmptyPage
+	}
+
+	// Send off our request
+	pageUrl, err := events.client.pageUrl(events.nextPage)
+	if err != nil {
+		return nil, nil, err
+	}
+	res, err := events.client.doJSON(ctx, "GET", pageUrl, nil, "", "")
+	if err != nil {
+		return nil, res, err
 	}
 
 	// Get the Content
 	bodyBytes, err := res.ReadBody()
 	if err != nil {
-		return nil, err
+		return nil, res, err
 	}
 
 	var eventsPage EventsPage
 	err = json.Unmarshal(bodyBytes, &eventsPage)
 	if err != nil {
-		return nil, err
+		return nil, res, err
 	}
 
-	eventsPage.client = c
+	eventsPage.client = events.client
 
-	return &eventsPage, nil
+	return &eventsPage, res, nil
 }
 
-func (events *EventsPage) Next() (*EventsPage, error) {
-	if events.nextPage == "" {
-		return nil, ErrEmptyPage
+// EachEvent calls fn for each event on this page, then on each page following it,
+// which are fetched under ctx. If fn returns an error, no more events are read and
+// that error is returned.
+//
+// A search can only be paged through as far as its first MaxEventsResults events.
+// Once fn has been called that many times, counting from this page, ErrEventsWindow
+// is returned if the search matched more events.
+func (ep *EventsPage) EachEvent(ctx context.Context, fn func(events.Event) error) error {
+	seen := 0
+	for page := ep; ; {
+		for _, e := range page.Events {
+			if err := fn(e); err != nil {
+				return err
+			}
+			seen++
+		}
+
+		if seen >= MaxEventsResults {
+			if page.nextPage != "" || page.TotalCount > seen {
+				return ErrEventsWindow
+			}
+			return nil
+		}
+		if page.nextPage == "" {
+			return nil
+		}
+
+		next, _, err := page.NextContext(ctx)
+		if err != nil {
+			return err
+		}
+		page = next
 	}
+}
 
-	// Send off our request
-	res, err := events.client.HttpGet(events.client.Config.BaseUrl + events.nextPage)
-	if err != nil {
+// MessageEventsInRange returns all the events matching opts between opts.From and opts.To,
+// newest first, however many there are. The range is split into as many searches as needed
+// for none to match more than MaxEventsResults events, and events found by more than one
+// search are only returned once. A zero To is now, and a zero From is 24 hours before To,
+// as in the API. opts.Page is ignored.
+//
+// A range starting before MessageEventsRetention ago is searched from then instead,
+// and ErrEventsRetention is returned along with the events.
+func (c *Client) MessageEventsInRange(ctx context.Context, opts *MessageEventsSearchOptions) (events.Events, error) {
+	o := MessageEventsSearchOptions{}
+	if opts != nil {
+		o = *opts
+	}
+	o.Page = 0
+	if o.PerPage == 0 {
+		o.PerPage = MaxEventsPerPage
+	}
+	if err := o.Validate(); err != nil {
 		return nil, err
 	}
 
-	// Assert that we got a JSON Content-Type back
-	if err = res.AssertJson(); err != nil {
-		return nil, err
+	now := eventsNow()
+	if o.To.IsZero() {
+		o.To = now
+	}
+	if o.From.IsZero() {
+		o.From = o.To.Add(-24 * time.Hour)
+	}
+	var warning error
+	// From is sent to the minute, so round up to keep it within retention.
+	if oldest := now.Add(-MessageEventsRetention).Truncate(time.Minute).Add(time.Minute); o.From.Before(oldest) {
+		o.From, warning = oldest, ErrEventsRetention
+	}
+	if !o.From.Before(o.To) {
+		return events.Events{}, warning
 	}
 
-	// Get the Content
-	bodyBytes, err := res.ReadBody()
+	found := events.Events{}
+	seen := map[string]bool{}
+	err := c.searchEventsRange(ctx, o, func(e events.Event) {
+		if env, ok := e.(events.Envelope); ok && env.EventID() != "" {
+			if seen[env.EventID()] {
+				return
+			}
+			seen[env.EventID()] = true
+		}
+		found = append(found, e)
+	})
 	if err != nil {
 		return nil, err
 	}
+	return found, warning
+}
 
-	var eventsPage EventsPage
-	err = json.Unmarshal(bodyBytes, &eventsPage)
+// searchEventsRange passes each event matching o to emit, newest first. When o matches more
+// than MaxEventsResults events, its range is halved, to the minute, and each half searched.
+func (c *Client) searchEventsRange(ctx context.Context, o MessageEventsSearchOptions, emit func(events.Event)) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	page, _, err := c.MessageEventsSearchContext(ctx, &o)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	eventsPage.client = events.client
+	err = ErrEventsWindow
+	if page.TotalCount <= MaxEventsResults {
+		var matched events.Events
+		err = page.EachEvent(ctx, func(e events.Event) error {
+			matched = append(matched, e)
+			return nil
+		})
+		if err == nil {
+			for _, e := range matched {
+				emit(e)
+			}
+			return nil
+		}
+	}
+	if err != ErrEventsWindow {
+		return err
+	}
 
-	return &eventsPage, nil
+	mid := o.From.Add(o.To.Sub(o.From) / 2).Truncate(time.Minute)
+	if !mid.After(o.From) || !mid.Before(o.To) {
+		return ErrEventsWindow
+	}
+	// The halves share the minute they meet at, as the API includes both ends of a range.
+	newer, older := o, o
+	newer.From, older.To = mid, mid
+	if err = c.searchEventsRange(ctx, newer, emit); err != nil {
+		return err
+	}
+	return c.searchEventsRange(ctx, older, emit)
 }
 
 func (ep *EventsPage) UnmarshalJSON(data []byte) error {
@@ -146,52 +475,47 @@ func (ep *EventsPage) UnmarshalJSON(data []byte) error {
 }
 
 // Samples requests a list of example event data.
-func (c *Client) EventSamples(types *[]string) (*events.Events, error) {
-	url, err := url.Parse(fmt.Sprintf(messageEventsSamplesPathFormat, c.Config.BaseUrl, c.Config.ApiVersion))
-	if err != nil {
-		return nil, err
-	}
+func (c *Client) EventSamples(types *[]string) (*events.Events, *Response, error) {
+	params := NewParams()
 
 	// Filter out types.
 	if types != nil {
 		// validate types
 		for _, etype := range *types {
 			if !events.ValidEventType(etype) {
-				return nil, fmt.Errorf("Invalid event type [%s]", etype)
+				return nil, nil, fmt.Errorf("Invalid event type [%s]", etype)
 			}
 		}
-
-		// get the query string object so we can modify it
-		q := url.Query()
-		// add the requested events and re-encode
-		q.Set("events", strings.Join(*types, ","))
-		url.RawQuery = q.Encode()
+		params.List("events", *types)
 	}
 
 	// Send off our request
-	res, err := c.HttpGet(url.String())
+	res, err := c.doJSON(context.Background(), "GET", params.Url(fmt.Sprintf(messageEventsSamplesPathFormat, c.Config.BaseUrl, c.Config.ApiVersion)), nil, "", "")
 	if err != nil {
-		return nil, err
-	}
-
-	// Assert that we got a JSON Content-Type back
-	if err = res.AssertJson(); err != nil {
-		return nil, err
+		return nil, res, err
 	}
 
 	// Get the Content
 	bodyBytes, err := res.ReadBody()
 	if err != nil {
-		return nil, err
+		return nil, res, err
 	}
 
 	var events events.Events
 	err = json.Unmarshal(bodyBytes, &events)
 	if err != nil {
-		return nil, err
+		return nil, res, err
 	}
 
-	return &events, nil
+	return &events, res, nil
+}
+
+// parseEvent parses a raw event, following Config.StrictDecoding.
+func (c *Client) parseEvent(raw json.RawMessage) events.Event {
+	if c.Config.StrictDecoding {
+		return events.ParseRawJSONEventStrict(raw)
+	}
+	return events.ParseRawJSONEvent(raw)
 }
 
 // ParseEvents function is left only for backward-compatibility. Events are parsed by events pkg.
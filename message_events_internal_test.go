@@ -0,0 +1,372 @@
+package gosparkpost
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/SparkPost/gosparkpost/events"
+)
+
+func TestMessageEventsSearchOptions(t *testing.T) {
+	o := &MessageEventsSearchOptions{
+		From:            time.Date(2016, 2, 1, 8, 0, 0, 0, time.UTC),
+		To:              time.Date(2016, 2, 2, 8, 0, 0, 0, time.UTC),
+		Events:          []string{"bounce", "delay"},
+		Recipients:      []string{"foo@example.com"},
+		MessageIDs:      []string{"000443ee14578172be22"},
+		CampaignIDs:     []string{"spring sale"},
+		TemplateIDs:     []string{"winter_sale"},
+		TransmissionIDs: []string{"11713562166689858"},
+		BounceClasses:   []int{10, 30},
+		Reason:          "%mailbox full%",
+		Subaccounts:     []int{101},
+		PerPage:         1000,
+		Page:            2,
+	}
+	if err := o.Validate(); err != nil {
+		t.Error(err)
+	}
+	out := ParamsFromMap(o.Map()).Encode()
+	if out != "bounce_classes=10%2C30&campaign_ids=spring+sale&events=bounce%2Cdelay&from=2016-02-01T08%3A00&message_ids=000443ee14578172be22&page=2&per_page=1000&reason=%25mailbox+full%25&recipients=foo%40example.com&subaccounts=101&template_ids=winter_sale&to=2016-02-02T08%3A00&transmission_ids=11713562166689858" {
+		t.Errorf("MessageEventsSearchOptions encoded as %q", out)
+	}
+
+	for _, bad := range []*MessageEventsSearchOptions{
+		{Events: []string{"bounce", "bogus"}},
+		{PerPage: MaxEventsPerPage + 1},
+	} {
+		if err := bad.Validate(); err == nil {
+			t.Errorf("Validate accepted %+v", bad)
+		}
+	}
+	if m := (*MessageEventsSearchOptions)(nil).Map(); m != nil {
+		t.Errorf("nil options mapped to %v", m)
+	}
+}
+
+func TestMessageEventsSearch(t *testing.T) {
+	testSetup(t)
+	defer testTeardown()
+
+	// set up the response handler
+	var query url.Values
+	path := fmt.Sprintf(messageEventsPathFormat, "", testClient.Config.ApiVersion)
+	testMux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		query = r.URL.Query()
+		w.Header().Set("Content-Type", "application/json; charset=utf8")
+		w.Write([]byte(`{"results": [
+			{"type": "bounce", "message_id": "000443ee14578172be22", "bounce_class": "10", "rcpt_to": "foo@example.com",
+			 "timestamp": "2016-02-01T09:12:55.000+00:00"},
+			{"type": "delivery", "message_id": "000443ee14578172be23", "rcpt_to": "bar@example.com",
+			 "timestamp": "2016-02-01T09:12:56.000+00:00"}
+		], "total_count": 2, "links": []}`))
+	})
+
+	page, res, err := testClient.MessageEventsSearch(&MessageEventsSearchOptions{
+		Events:  []string{"bounce", "delivery"},
+		PerPage: 2,
+	})
+	if err != nil {
+		testFailVerbose(t, res, "MessageEventsSearch returned error: %v", err)
+	}
+	if query.Get("events") != "bounce,delivery" || query.Get("per_page") != "2" {
+		t.Errorf("query was %v", query)
+	}
+	if page.TotalCount != 2 || len(page.Events) != 2 {
+		t.Fatalf("MessageEventsSearch returned %d (of %d) events", len(page.Events), page.TotalCount)
+	}
+	if b, ok := page.Events[0].(*events.Bounce); !ok || b.Recipient != "foo@example.com" {
+		t.Errorf("first event was %#v", page.Events[0])
+	}
+	if _, ok := page.Events[1].(*events.Delivery); !ok {
+		t.Errorf("second event was %T", page.Events[1])
+	}
+
+	if _, _, err = testClient.MessageEventsSearch(&MessageEventsSearchOptions{Events: []string{"bogus"}}); err == nil {
+		t.Error("MessageEventsSearch accepted an unknown event type")
+	}
+}
+
+func TestMessageEventsEach_status(t *testing.T) {
+	testSetup(t)
+	defer testTeardown()
+
+	// set up the response handler
+	status, body := http.StatusNonAuthoritativeInfo, `{"results": [{"type": "delivery"}, {"type": "bounce"}]}`
+	path := fmt.Sprintf(messageEventsPathFormat, "", testClient.Config.ApiVersion)
+	testMux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json; charset=utf8")
+		w.WriteHeader(status)
+		w.Write([]byte(body))
+	})
+
+	// any 2xx response is decoded
+	var types []string
+	collect := func(e events.Event) error { types = append(types, e.EventType()); return nil }
+	res, err := testClient.MessageEventsEach(nil, collect)
+	if err != nil || fmt.Sprint(types) != "[delivery bounce]" {
+		testFailVerbose(t, res, "MessageEventsEach returned %v after events %v", err, types)
+	}
+
+	// an errors array in a successful response is returned as an SPError
+	status, body = http.StatusOK, `{"results": [], "errors": [{"message": "From must be before to", "code": "1200"}]}`
+	_, err = testClient.MessageEventsEach(nil, collect)
+	var spErr *SPError
+	if !errors.As(err, &spErr) || spErr.Code != "1200" {
+		t.Errorf("MessageEventsEach returned %v, expected an SPError", err)
+	}
+}
+
+func TestEventsPageEachEvent(t *testing.T) {
+	testSetup(t)
+	defer testTeardown()
+
+	// set up the response handler
+	var pages []string
+	path := fmt.Sprintf(messageEventsPathFormat, "", testClient.Config.ApiVersion)
+	testMux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		page := r.URL.Query().Get("page")
+		pages = append(pages, page)
+		w.Header().Set("Content-Type", "application/json; charset=utf8")
+		switch page {
+		case "":
+			w.Write([]byte(`{"results": [{"type": "delivery", "message_id": "1"}, {"type": "delivery", "message_id": "2"}],
+				"total_count": 3, "links": [{"href": "/api/v1/message-events?page=2&per_page=2", "rel": "next"}]}`))
+		case "2":
+			w.Write([]byte(`{"results": [{"type": "open", "message_id": "3"}], "total_count": 3,
+				"links": [{"href": "/api/v1/message-events?page=1&per_page=2", "rel": "previous"}]}`))
+		}
+	})
+
+	page, res, err := testClient.MessageEventsSearch(&MessageEventsSearchOptions{PerPage: 2})
+	if err != nil {
+		testFailVerbose(t, res, "MessageEventsSearch returned error: %v", err)
+	}
+	var types []string
+	err = page.EachEvent(context.Background(), func(e events.Event) error {
+		types = append(types, e.EventType())
+		return nil
+	})
+	if err != nil {
+		t.Error(err)
+	}
+	if fmt.Sprint(types) != "[delivery delivery open]" || fmt.Sprint(pages) != "[ 2]" {
+		t.Errorf("EachEvent saw %v from pages %v", types, pages)
+	}
+
+	// an error from fn stops iteration before the next page is fetched
+	pages = nil
+	stop := errors.New("stop")
+	if err = page.EachEvent(context.Background(), func(events.Event) error { return stop }); err != stop {
+		t.Errorf("EachEvent returned %v, expected the error from fn", err)
+	}
+	if len(pages) != 0 {
+		t.Errorf("EachEvent fetched pages %v after fn failed", pages)
+	}
+
+	// the next page isn't fetched once ctx is done
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err = page.EachEvent(ctx, func(events.Event) error { return nil }); !errors.Is(err, context.Canceled) {
+		t.Errorf("EachEvent returned %v, expected context.Canceled", err)
+	}
+}
+
+func TestEventsPageEachEvent_window(t *testing.T) {
+	testSetup(t)
+	defer testTeardown()
+
+	// set up the response handler
+	requests := 0
+	path := fmt.Sprintf(messageEventsPathFormat, "", testClient.Config.ApiVersion)
+	testMux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		event := `{"type": "delivery"}`
+		w.Header().Set("Content-Type", "application/json; charset=utf8")
+		fmt.Fprintf(w, `{"results": [%s%s], "total_count": 25000,
+			"links": [{"href": "/api/v1/message-events?page=2&per_page=10000", "rel": "next"}]}`,
+			event, strings.Repeat(","+event, MaxEventsPerPage-1))
+	})
+
+	page, res, err := testClient.MessageEventsSearch(&MessageEventsSearchOptions{PerPage: MaxEventsPerPage})
+	if err != nil {
+		testFailVerbose(t, res, "MessageEventsSearch returned error: %v", err)
+	}
+	n := 0
+	err = page.EachEvent(context.Background(), func(events.Event) error {
+		n++
+		return nil
+	})
+	if err != ErrEventsWindow {
+		t.Errorf("EachEvent returned %v, expected ErrEventsWindow", err)
+	}
+	if n != MaxEventsResults || requests != 1 {
+		t.Errorf("EachEvent saw %d events in %d requests", n, requests)
+	}
+}
+
+func TestMessageEventsByMessageIDs(t *testing.T) {
+	testSetup(t)
+	defer testTeardown()
+
+	// set up the response handler
+	var requested [][]string
+	path := fmt.Sprintf(messageEventsPathFormat, "", testClient.Config.ApiVersion)
+	testMux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		q := r.URL.Query()
+		if q.Get("from") != "2016-02-01T08:00" || q.Get("per_page") != "10000" {
+			t.Errorf("query was %v", q)
+		}
+		ids := strings.Split(q.Get("message_ids"), ",")
+		requested = append(requested, ids)
+
+		var results []string
+		for _, id := range ids {
+			if id == "nothing-happened" {
+				continue
+			}
+			results = append(results,
+				fmt.Sprintf(`{"type": "delivery", "message_id": %q}`, id),
+				fmt.Sprintf(`{"type": "injection", "message_id": %q}`, id))
+		}
+		w.Header().Set("Content-Type", "application/json; charset=utf8")
+		fmt.Fprintf(w, `{"results": [%s], "total_count": %d}`, strings.Join(results, ","), len(results))
+	})
+
+	ids := []string{"nothing-happened"}
+	for i := 0; len(ids) < 300; i++ {
+		ids = append(ids, fmt.Sprintf("%020d", i))
+	}
+	byID, err := testClient.MessageEventsByMessageIDs(context.Background(), ids, &MessageEventsSearchOptions{
+		From:       time.Date(2016, 2, 1, 8, 0, 0, 0, time.UTC),
+		MessageIDs: []string{"ignored"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 300 ids of about 20 bytes don't fit in one request
+	if len(requested) != 2 || len(requested[0])+len(requested[1]) != len(ids) {
+		t.Errorf("ids were requested in %d chunks", len(requested))
+	}
+	for _, chunk := range requested {
+		if n := len(strings.Join(chunk, ",")); n > messageIDsQueryLimit {
+			t.Errorf("chunk of %d bytes sent", n)
+		}
+	}
+	if len(byID) != len(ids)-1 {
+		t.Errorf("MessageEventsByMessageIDs returned %d messages", len(byID))
+	}
+	if list := byID[ids[1]]; len(list) != 2 || list[0].EventType() != "delivery" || list[1].EventType() != "injection" {
+		t.Errorf("events of %s were %v", ids[1], list)
+	}
+	if _, ok := byID["nothing-happened"]; ok {
+		t.Error("a message without events was returned")
+	}
+}
+
+func TestMessageEventsInRange(t *testing.T) {
+	testSetup(t)
+	defer testTeardown()
+
+	at := func(clock string) time.Time {
+		tm, err := time.Parse(TimeLayout, "2016-02-01T"+clock)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return tm
+	}
+	// newest first, as the API returns them
+	stored := []time.Time{at("10:45"), at("09:00"), at("08:30"), at("08:00")}
+
+	// set up the response handler
+	var searched []string
+	path := fmt.Sprintf(messageEventsPathFormat, "", testClient.Config.ApiVersion)
+	testMux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		searched = append(searched, q.Get("from")+"/"+q.Get("to"))
+		from, _ := time.Parse(TimeLayout, q.Get("from"))
+		to, _ := time.Parse(TimeLayout, q.Get("to"))
+		w.Header().Set("Content-Type", "application/json; charset=utf8")
+		// pretend searches longer than an hour match too many events
+		if to.Sub(from) > time.Hour {
+			fmt.Fprint(w, `{"results": [], "total_count": 20000}`)
+			return
+		}
+		var results []string
+		for _, tm := range stored {
+			if !tm.Before(from) && !tm.After(to) {
+				results = append(results, fmt.Sprintf(`{"type": "delivery", "event_id": "%d", "timestamp": "%d"}`, tm.Unix(), tm.Unix()))
+			}
+		}
+		fmt.Fprintf(w, `{"results": [%s], "total_count": %d}`, strings.Join(results, ","), len(results))
+	})
+
+	defer func(now func() time.Time) { eventsNow = now }(eventsNow)
+	eventsNow = func() time.Time { return at("12:00") }
+	found, err := testClient.MessageEventsInRange(context.Background(), &MessageEventsSearchOptions{From: at("08:00")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var times []time.Time
+	for _, e := range found {
+		times = append(times, e.(events.Envelope).EventTime().UTC())
+	}
+	if fmt.Sprint(times) != fmt.Sprint(stored) {
+		t.Errorf("MessageEventsInRange returned events at %v", times)
+	}
+	if len(searched) != 7 || searched[0] != "2016-02-01T08:00/2016-02-01T12:00" {
+		t.Errorf("searched %v", searched)
+	}
+
+	// A range reaching past retention is searched from the oldest whole minute retained.
+	searched = nil
+	eventsNow = func() time.Time { return at("08:59").Add(MessageEventsRetention + 30*time.Second) }
+	found, err = testClient.MessageEventsInRange(context.Background(), &MessageEventsSearchOptions{
+		From: at("08:00"), To: at("10:00"),
+	})
+	if err != ErrEventsRetention {
+		t.Errorf("MessageEventsInRange returned %v, expected ErrEventsRetention", err)
+	}
+	if len(found) != 1 || len(searched) != 1 || searched[0] != "2016-02-01T09:00/2016-02-01T10:00" {
+		t.Errorf("searched %v and found %v", searched, found)
+	}
+}
+
+func TestMessageEventsInRange_canceled(t *testing.T) {
+	testSetup(t)
+	defer testTeardown()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// set up the response handler, which cancels the search while it's answering the first request
+	path := fmt.Sprintf(messageEventsPathFormat, "", testClient.Config.ApiVersion)
+	testMux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		cancel()
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+		w.Header().Set("Content-Type", "application/json; charset=utf8")
+		fmt.Fprint(w, `{"results": [], "total_count": 0}`)
+	})
+
+	start := time.Now()
+	_, err := testClient.MessageEventsInRange(ctx, &MessageEventsSearchOptions{From: time.Now().Add(-time.Hour)})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("MessageEventsInRange returned %v, expected context.Canceled", err)
+	}
+	if time.Since(start) > 2*time.Second {
+		t.Error("the first search request wasn't interrupted")
+	}
+}
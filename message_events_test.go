@@ -36,7 +36,7 @@ func TestMessageEvents(t *testing.T) {
 	params := map[string]string{
 		"per_page": "10",
 	}
-	eventsPage, err := client.MessageEvents(params)
+	eventsPage, _, err := client.MessageEvents(params)
 	if err != nil {
 		t.Error(err)
 		return
@@ -48,7 +48,8 @@ func TestMessageEvents(t *testing.T) {
 
 	for _, ev := range eventsPage.Events {
 		switch event := ev.(type) {
-		case *events.Click, *events.Open, *events.GenerationFailure, *events.GenerationRejection,
+		case *events.Click, *events.Open, *events.InitialOpen, *events.AMPClick,
+			*events.AMPOpen, *events.AMPInitialOpen, *events.GenerationFailure, *events.GenerationRejection,
 			*events.ListUnsubscribe, *events.LinkUnsubscribe, *events.PolicyRejection,
 			*events.RelayInjection, *events.RelayRejection, *events.RelayDelivery,
 			*events.RelayTempfail, *events.RelayPermfail, *events.SpamComplaint, *events.SMSStatus:
@@ -69,7 +70,7 @@ func TestMessageEvents(t *testing.T) {
 		}
 	}
 
-	eventsPage, err = eventsPage.Next()
+	eventsPage, _, err = eventsPage.Next()
 	if err != nil && err != sp.ErrEmptyPage {
 		t.Error(err)
 	} else {
@@ -103,7 +104,7 @@ func TestAllEventsSamples(t *testing.T) {
 		return
 	}
 
-	e, err := client.EventSamples(nil)
+	e, _, err := client.EventSamples(nil)
 	if err != nil {
 		t.Error(err)
 		return
@@ -162,7 +163,7 @@ func TestFilteredEventsSamples(t *testing.T) {
 	}
 
 	types := []string{"open", "click", "bounce"}
-	e, err := client.EventSamples(&types)
+	e, _, err := client.EventSamples(&types)
 	if err != nil {
 		t.Error(err)
 		return
@@ -181,3 +182,33 @@ func TestFilteredEventsSamples(t *testing.T) {
 		}
 	}
 }
+
+// TestWebhookEventSamplesDrift compares the event structs with the samples the API returns,
+// when an account is configured, to catch fields SparkPost has added since the vendored samples.
+func TestWebhookEventSamplesDrift(t *testing.T) {
+	cfgMap, err := test.LoadConfig()
+	if err != nil {
+		t.Skip(err)
+	}
+	cfg, err := sp.NewConfig(cfgMap)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var client sp.Client
+	if err = client.Init(cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	_, res, err := client.WebhookEventSamples(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	drift, err := events.CheckSamples(res.Results)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, d := range drift {
+		t.Error(d)
+	}
+}
@@ -0,0 +1,114 @@
+package gosparkpost
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sync"
+	"time"
+)
+
+// MetricsCache stores the responses to metrics queries, so identical queries made while an
+// entry is fresh don't count against the API's rate limits. See Config.MetricsCache.
+// Implementations must be safe for concurrent use.
+type MetricsCache interface {
+	// Get returns the response body stored under key, if there is one.
+	Get(key string) ([]byte, bool)
+	// Set stores a successful response body under key.
+	Set(key string, body []byte)
+}
+
+// TTLCache is an in-memory MetricsCache whose entries expire TTL after they're set.
+type TTLCache struct {
+	TTL time.Duration
+
+	mu      sync.Mutex
+	entries map[string]ttlEntry
+	swept   time.Time
+	now     func() time.Time
+}
+
+type ttlEntry struct {
+	body    []byte
+	expires time.Time
+}
+
+// NewTTLCache returns an empty TTLCache whose entries expire after ttl.
+func NewTTLCache(ttl time.Duration) *TTLCache {
+	return &TTLCache{TTL: ttl, entries: map[string]ttlEntry{}, now: time.Now}
+}
+
+// Get returns the body stored under key, unless it has expired.
+func (c *TTLCache) Get(key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if !c.now().Before(e.expires) {
+		delete(c.entries, key)
+		return nil, false
+	}
+	return e.body, true
+}
+
+// Set stores body under key for TTL. Expired entries are removed at most once per TTL.
+func (c *TTLCache) Set(key string, body []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	if now.Sub(c.swept) >= c.TTL {
+		for k, e := range c.entries {
+			if !now.Before(e.expires) {
+				delete(c.entries, k)
+			}
+		}
+		c.swept = now
+	}
+	c.entries[key] = ttlEntry{body: body, expires: now.Add(c.TTL)}
+}
+
+// Len returns the number of entries held, including any which have expired but not yet been removed.
+func (c *TTLCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.entries)
+}
+
+// metricsCacheKey identifies a query by its url, the credentials it's made with and the
+// subaccount it's made on behalf of, since the same query returns different counters for
+// each account and subaccount. The credentials are hashed, so they aren't held in the cache.
+func (c *Client) metricsCacheKey(url string) string {
+	sub := c.subaccount()
+	if sub == "" {
+		sub = "0"
+	}
+	header, auth := c.Config.authorization()
+	account := sha256.Sum256([]byte(header + ": " + auth))
+	return hex.EncodeToString(account[:16]) + " " + sub + " " + url
+}
+
+// cachedResponse returns the Response stored under key in Config.MetricsCache, if there is one.
+func (c *Client) cachedResponse(key string) *Response {
+	if c.Config.MetricsCache == nil {
+		return nil
+	}
+	body, ok := c.Config.MetricsCache.Get(key)
+	if !ok {
+		return nil
+	}
+
+	res := &Response{
+		Body:           body,
+		Cached:         true,
+		errorBodyLimit: c.Config.ErrorBodyLimit,
+		strict:         c.Config.StrictDecoding,
+	}
+	if err := json.Unmarshal(body, res); err != nil {
+		return nil
+	}
+	return res
+}
@@ -0,0 +1,124 @@
+package gosparkpost
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestTTLCache(t *testing.T) {
+	now := time.Date(2016, 2, 1, 8, 0, 0, 0, time.UTC)
+	c := NewTTLCache(time.Minute)
+	c.now = func() time.Time { return now }
+
+	c.Set("a", []byte("1"))
+	if b, ok := c.Get("a"); !ok || string(b) != "1" {
+		t.Errorf("Get returned %q, %v", b, ok)
+	}
+	if _, ok := c.Get("b"); ok {
+		t.Error("Get returned an entry that was never set")
+	}
+
+	now = now.Add(30 * time.Second)
+	c.Set("b", []byte("2"))
+	now = now.Add(30 * time.Second)
+	if _, ok := c.Get("a"); ok {
+		t.Error("Get returned an expired entry")
+	}
+	if _, ok := c.Get("b"); !ok {
+		t.Error("Get didn't return a fresh entry")
+	}
+
+	// expired entries are swept out as new ones are set
+	now = now.Add(time.Minute)
+	c.Set("c", []byte("3"))
+	if c.Len() != 1 {
+		t.Errorf("cache holds %d entries, expected 1", c.Len())
+	}
+}
+
+func TestMetricsCache(t *testing.T) {
+	testSetup(t)
+	defer testTeardown()
+
+	// set up the response handler
+	requests := 0
+	path := fmt.Sprintf(deliverabilityMetricPathFormat, testClient.Config.ApiVersion)
+	testMux.HandleFunc(path+"/domain", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json; charset=utf8")
+		w.Write([]byte(`{"results": [{"domain": "gmail.com", "count_injected": 100}]}`))
+	})
+
+	testClient.Config.MetricsCache = NewTTLCache(time.Minute)
+	defer func() { testClient.Config.MetricsCache = nil }()
+
+	p := &MetricsParams{From: time.Date(2016, 2, 1, 8, 0, 0, 0, time.UTC)}
+	for i := 0; i < 2; i++ {
+		list, res, err := testClient.DeliverabilityByDomain(p)
+		if err != nil {
+			testFailVerbose(t, res, "DeliverabilityByDomain returned error: %v", err)
+		}
+		if len(list) != 1 || list[0].CountInjected != 100 {
+			t.Errorf("DeliverabilityByDomain returned %+v", list)
+		}
+		if res.Cached != (i == 1) {
+			t.Errorf("request %d had Cached %v", i, res.Cached)
+		}
+	}
+	if requests != 1 {
+		t.Errorf("%d requests were made, expected 1", requests)
+	}
+
+	// the legacy query shares the cache
+	wrapper, res, err := testClient.QueryDeliverabilityMetrics("domain", ParamsFromMap(p.Map()).Map())
+	if err != nil {
+		testFailVerbose(t, res, "QueryDeliverabilityMetrics returned error: %v", err)
+	}
+	if len(wrapper.Results) != 1 || !res.Cached || requests != 1 {
+		t.Errorf("QueryDeliverabilityMetrics returned %+v after %d requests", wrapper.Results, requests)
+	}
+
+	// a different subaccount or query isn't served from the cache
+	if _, _, err = testClient.WithSubaccount(101).DeliverabilityByDomain(p); err != nil {
+		t.Error(err)
+	}
+	p.Domains = []string{"gmail.com"}
+	if _, _, err = testClient.DeliverabilityByDomain(p); err != nil {
+		t.Error(err)
+	}
+	if requests != 3 {
+		t.Errorf("%d requests were made, expected 3", requests)
+	}
+}
+
+func TestMetricsCache_clientPool(t *testing.T) {
+	testSetup(t)
+	defer testTeardown()
+
+	// set up the response handler
+	path := fmt.Sprintf(deliverabilityMetricPathFormat, testClient.Config.ApiVersion)
+	testMux.HandleFunc(path+"/domain", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json; charset=utf8")
+		fmt.Fprintf(w, `{"results": [{"domain": %q, "count_injected": 100}]}`, r.Header.Get("Authorization"))
+	})
+
+	pool, err := NewClientPool(&Config{BaseUrl: testClient.Config.BaseUrl, ApiKey: "master",
+		MetricsCache: NewTTLCache(time.Minute)}, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pool.base.Client = testClient.Client
+
+	p := &MetricsParams{From: time.Date(2016, 2, 1, 8, 0, 0, 0, time.UTC)}
+	for _, key := range []string{"tenant-a", "tenant-b", "tenant-a"} {
+		list, res, err := pool.ApiKey(key).DeliverabilityByDomain(p)
+		if err != nil {
+			testFailVerbose(t, res, "DeliverabilityByDomain returned error: %v", err)
+		}
+		if len(list) != 1 || list[0].Domain != key {
+			t.Errorf("%s was served the results of %+v", key, list)
+		}
+	}
+}
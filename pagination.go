@@ -0,0 +1,137 @@
+package gosparkpost
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Links holds the paging links returned by list endpoints, keyed by rel
+// ("next", "previous", "first", "last").
+// SparkPost returns these either as an array of {"href", "rel"} objects,
+// or as an object mapping rel to href; both are accepted.
+type Links map[string]string
+
+// UnmarshalJSON decodes either form of the links returned by the API.
+func (l *Links) UnmarshalJSON(data []byte) error {
+	links := Links{}
+	trimmed := strings.TrimSpace(string(data))
+	switch {
+	case trimmed == "null":
+	case strings.HasPrefix(trimmed, "["):
+		var list []struct {
+			Href string `json:"href"`
+			Rel  string `json:"rel"`
+		}
+		if err := json.Unmarshal(data, &list); err != nil {
+			return err
+		}
+		for _, link := range list {
+			links[link.Rel] = link.Href
+		}
+	default:
+		var obj map[string]string
+		if err := json.Unmarshal(data, &obj); err != nil {
+			return err
+		}
+		for rel, href := range obj {
+			links[rel] = href
+		}
+	}
+	*l = links
+	return nil
+}
+
+// Next returns the link to the next page, or an empty string if there isn't one.
+func (l Links) Next() string {
+	return l["next"]
+}
+
+// Paginator follows the "next" links of a list endpoint, one page at a time.
+type Paginator struct {
+	// MaxPages caps the number of pages, including the first one. Zero means no limit.
+	MaxPages int
+
+	client *Client
+	next   string
+	pages  int
+}
+
+// NewPaginator returns a Paginator which fetches the pages following first.
+func (c *Client) NewPaginator(first *Response, maxPages int) (*Paginator, error) {
+	p := &Paginator{MaxPages: maxPages, client: c, pages: 1}
+	if err := p.setNext(first); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// More reports whether there's another page to fetch.
+func (p *Paginator) More() bool {
+	if p.MaxPages > 0 && p.pages >= p.MaxPages {
+		return false
+	}
+	return p.next != ""
+}
+
+// Next fetches the next page. The returned Response has already been read,
+// so its Body is ready to be decoded. ErrEmptyPage is returned when there
+// are no more pages, or MaxPages has been reached.
+func (p *Paginator) Next(ctx context.Context) (*Response, error) {
+	if !p.More() {
+		return nil, ErrEmptyPage
+	}
+
+	pageUrl, err := p.client.pageUrl(p.next)
+	if err != nil {
+		return nil, err
+	}
+	res, err := p.client.doJSON(ctx, "GET", pageUrl, nil, "", "")
+	if err != nil {
+		return res, err
+	}
+
+	p.pages++
+	if err = p.setNext(res); err != nil {
+		return res, err
+	}
+	return res, nil
+}
+
+func (p *Paginator) setNext(res *Response) error {
+	body, err := res.ReadBody()
+	if err != nil {
+		return err
+	}
+	var page struct {
+		Links Links `json:"links"`
+	}
+	if err = json.Unmarshal(body, &page); err != nil {
+		return err
+	}
+	p.next = page.Links.Next()
+	return nil
+}
+
+// pageUrl turns the href of a paging link, usually relative to the API host, into a full url.
+// Requests for pages carry the credentials, so links to any other host, or to the API host
+// over another scheme, are rejected rather than followed.
+func (c *Client) pageUrl(href string) (string, error) {
+	link, err := url.Parse(href)
+	if err != nil {
+		return "", fmt.Errorf("invalid paging link %q: %v", href, err)
+	}
+	if !link.IsAbs() && link.Host == "" {
+		return c.Config.BaseUrl + href, nil
+	}
+	base, err := url.Parse(c.Config.BaseUrl)
+	if err != nil {
+		return "", err
+	}
+	if link.Host == "" || !strings.EqualFold(link.Scheme, base.Scheme) || !strings.EqualFold(link.Host, base.Host) {
+		return "", fmt.Errorf("paging link %q isn't on the API host %s", href, base.Host)
+	}
+	return href, nil
+}
@@ -0,0 +1,138 @@
+package gosparkpost
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func testPaginatorHandler(t *testing.T, pages int) {
+	testMux.HandleFunc("/pages", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		page := 1
+		fmt.Sscanf(r.URL.Query().Get("page"), "%d", &page)
+		w.Header().Set("Content-Type", "application/json; charset=utf8")
+
+		links := "[]"
+		if page < pages {
+			if page%2 == 0 {
+				// object form
+				links = fmt.Sprintf(`{"next": "/pages?page=%d"}`, page+1)
+			} else {
+				// array form
+				links = fmt.Sprintf(`[{"href": "/pages?page=%d", "rel": "next"}]`, page+1)
+			}
+		}
+		fmt.Fprintf(w, `{"results": [%d], "links": %s}`, page, links)
+	})
+}
+
+func TestPaginator(t *testing.T) {
+	testSetup(t)
+	defer testTeardown()
+	testPaginatorHandler(t, 3)
+
+	first, err := testClient.HttpGet(testClient.Config.BaseUrl + "/pages")
+	if err != nil {
+		t.Fatalf("first page request returned error: %v", err)
+	}
+	p, err := testClient.NewPaginator(first, 0)
+	if err != nil {
+		t.Fatalf("NewPaginator returned error: %v", err)
+	}
+
+	seen := []int{}
+	for p.More() {
+		res, err := p.Next(context.Background())
+		if err != nil {
+			testFailVerbose(t, res, "Next returned error: %v", err)
+		}
+		var page struct {
+			Results []int `json:"results"`
+		}
+		if err = json.Unmarshal(res.Body, &page); err != nil {
+			t.Fatal(err)
+		}
+		seen = append(seen, page.Results...)
+	}
+	if fmt.Sprint(seen) != "[2 3]" {
+		t.Errorf("paginator returned pages %v, expected [2 3]", seen)
+	}
+	if _, err = p.Next(context.Background()); err != ErrEmptyPage {
+		t.Errorf("expected ErrEmptyPage after the last page, got %v", err)
+	}
+}
+
+func TestPaginator_maxPages(t *testing.T) {
+	testSetup(t)
+	defer testTeardown()
+	testPaginatorHandler(t, 10)
+
+	first, err := testClient.HttpGet(testClient.Config.BaseUrl + "/pages")
+	if err != nil {
+		t.Fatalf("first page request returned error: %v", err)
+	}
+	p, err := testClient.NewPaginator(first, 3)
+	if err != nil {
+		t.Fatalf("NewPaginator returned error: %v", err)
+	}
+
+	fetched := 0
+	for p.More() {
+		if _, err = p.Next(context.Background()); err != nil {
+			t.Fatalf("Next returned error: %v", err)
+		}
+		fetched++
+	}
+	if fetched != 2 {
+		t.Errorf("paginator fetched %d pages after the first, expected 2", fetched)
+	}
+}
+
+func TestEnvelope(t *testing.T) {
+	testSetup(t)
+	defer testTeardown()
+
+	// set up the response handler
+	path := fmt.Sprintf(messageEventsPathFormat, "", testClient.Config.ApiVersion)
+	testMux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json; charset=utf8")
+		w.Write([]byte(`{"results": [{"type": "bounce"}], "total_count": 42,
+			"links": [{"href": "/api/v1/message-events?page=2", "rel": "next"}]}`))
+	})
+
+	page, res, err := testClient.MessageEvents(nil)
+	if err != nil {
+		testFailVerbose(t, res, "MessageEvents returned error: %v", err)
+	}
+	if res.TotalCount != 42 || page.TotalCount != 42 {
+		t.Errorf("total_count is %d in Response, %d in EventsPage, expected 42", res.TotalCount, page.TotalCount)
+	}
+	if next := res.Links.Next(); next != "/api/v1/message-events?page=2" {
+		t.Errorf("next link is %q", next)
+	}
+}
+
+func TestPageUrl(t *testing.T) {
+	c := &Client{Config: &Config{BaseUrl: "https://api.sparkpost.com"}}
+	for href, want := range map[string]string{
+		"/api/v1/message-events?page=2":                          "https://api.sparkpost.com/api/v1/message-events?page=2",
+		"https://api.sparkpost.com/api/v1/message-events?page=2": "https://api.sparkpost.com/api/v1/message-events?page=2",
+		"https://API.sparkpost.com/api/v1/suppression-list":      "https://API.sparkpost.com/api/v1/suppression-list",
+		"http://api.sparkpost.com/api/v1/message-events?page=2":  "",
+		"https://evil.example.com/api/v1/message-events?page=2":  "",
+		"//evil.example.com/api/v1/message-events?page=2":        "",
+		"https://api.sparkpost.com.evil.example.com/api/v1":      "",
+	} {
+		got, err := c.pageUrl(href)
+		if want == "" {
+			if err == nil {
+				t.Errorf("pageUrl(%q) returned %q, expected an error", href, got)
+			}
+		} else if err != nil || got != want {
+			t.Errorf("pageUrl(%q) returned %q, %v", href, got, err)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package gosparkpost
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TimeLayout is the format SparkPost expects for times passed as query parameters, such as from and to.
+const TimeLayout = "2006-01-02T15:04"
+
+// Params builds the query string of an API request.
+// Setters return the Params, so calls can be chained:
+//
+//	p := NewParams().List("events", []string{"bounce", "delivery"}).TimeRange(from, to)
+//	url := p.Url(base)
+type Params struct {
+	values url.Values
+}
+
+// NewParams returns an empty Params.
+func NewParams() *Params {
+	return &Params{values: url.Values{}}
+}
+
+// ParamsFromMap returns Params holding each key and value in m.
+func ParamsFromMap(m map[string]string) *Params {
+	p := NewParams()
+	for k, v := range m {
+		p.values.Set(k, v)
+	}
+	return p
+}
+
+// Set sets key to value, replacing any existing value. Empty values are ignored.
+func (p *Params) Set(key, value string) *Params {
+	if value != "" {
+		p.values.Set(key, value)
+	}
+	return p
+}
+
+// Int sets key to the decimal representation of n.
+func (p *Params) Int(key string, n int) *Params {
+	p.values.Set(key, strconv.Itoa(n))
+	return p
+}
+
+// Bool sets key to "true" or "false".
+func (p *Params) Bool(key string, b bool) *Params {
+	p.values.Set(key, strconv.FormatBool(b))
+	return p
+}
+
+// List sets key to the comma-joined items. An empty list is ignored.
+func (p *Params) List(key string, items []string) *Params {
+	if len(items) > 0 {
+		p.values.Set(key, strings.Join(items, ","))
+	}
+	return p
+}
+
+// Time sets key to t, converted to UTC and formatted with TimeLayout. A zero time is ignored.
+func (p *Params) Time(key string, t time.Time) *Params {
+	if !t.IsZero() {
+		p.values.Set(key, t.UTC().Format(TimeLayout))
+	}
+	return p
+}
+
+// TimeRange sets the from and to parameters. Zero times are ignored.
+func (p *Params) TimeRange(from, to time.Time) *Params {
+	return p.Time("from", from).Time("to", to)
+}
+
+// Get returns the value of key, or an empty string if it isn't set.
+func (p *Params) Get(key string) string {
+	return p.values.Get(key)
+}
+
+// Len returns the number of parameters set.
+func (p *Params) Len() int {
+	return len(p.values)
+}
+
+// Map returns the parameters as a map, for endpoints that accept one.
+func (p *Params) Map() map[string]string {
+	m := make(map[string]string, len(p.values))
+	for k := range p.values {
+		m[k] = p.values.Get(k)
+	}
+	return m
+}
+
+// Encode returns the URL-encoded query string, sorted by key.
+func (p *Params) Encode() string {
+	return p.values.Encode()
+}
+
+// Url returns base with the query string appended, if there is one.
+func (p *Params) Url(base string) string {
+	if len(p.values) == 0 {
+		return base
+	}
+	return base + "?" + p.Encode()
+}
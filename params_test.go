@@ -0,0 +1,37 @@
+package gosparkpost
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParams(t *testing.T) {
+	loc := time.FixedZone("UTC+2", 2*60*60)
+	from := time.Date(2016, 5, 1, 10, 30, 45, 0, loc)
+	to := time.Date(2016, 5, 2, 0, 0, 0, 0, time.UTC)
+
+	for idx, test := range []struct {
+		params *Params
+		out    string
+	}{
+		{NewParams(), ""},
+		{NewParams().Set("campaign_id", "").List("events", nil).Time("from", time.Time{}), ""},
+		{NewParams().Set("campaign_id", "a b&c"), "campaign_id=a+b%26c"},
+		{NewParams().List("events", []string{"bounce", "delivery"}), "events=bounce%2Cdelivery"},
+		{NewParams().TimeRange(from, to), "from=2016-05-01T08%3A30&to=2016-05-02T00%3A00"},
+		{NewParams().Int("per_page", 100).Bool("show_recipients", true), "per_page=100&show_recipients=true"},
+		{ParamsFromMap(map[string]string{"a": "1", "b": ""}), "a=1&b="},
+	} {
+		if out := test.params.Encode(); out != test.out {
+			t.Errorf("Params.Encode (%d) => %q, want %q", idx, out, test.out)
+		}
+	}
+
+	base := "https://api.sparkpost.com/api/v1/message-events"
+	if u := NewParams().Url(base); u != base {
+		t.Errorf("Params.Url with no params => %q", u)
+	}
+	if u := NewParams().Set("a", "b").Url(base); u != base+"?a=b" {
+		t.Errorf("Params.Url => %q", u)
+	}
+}
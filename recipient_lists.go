@@ -1,6 +1,7 @@
 package gosparkpost
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"reflect"
@@ -22,6 +23,14 @@ type RecipientList struct {
 	Accepted *int `json:"total_accepted_recipients,omitempty"`
 }
 
+// RecipientListResults is the "results" object returned when a RecipientList is created.
+type RecipientListResults struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Accepted int    `json:"total_accepted_recipients"`
+	Rejected int    `json:"total_rejected_recipients"`
+}
+
 func (rl *RecipientList) String() string {
 	n := 0
 	if rl.Recipients != nil {
@@ -152,96 +161,122 @@ func (c *Client) RecipientListCreate(rl *RecipientList) (id string, res *Respons
 		return
 	}
 
-	jsonBytes, err := json.Marshal(rl)
+	path := fmt.Sprintf(recipListsPathFormat, c.Config.ApiVersion)
+	url := fmt.Sprintf("%s%s", c.Config.BaseUrl, path)
+	res, err = c.doJSON(context.Background(), "POST", url, rl, "RecipientList", "create")
 	if err != nil {
 		return
 	}
 
+	var results RecipientListResults
+	if err = res.DecodeResults(&results); err != nil {
+		return id, res, err
+	}
+	id = results.ID
+	if id == "" {
+		return id, res, res.unexpected("Unexpected response to Recipient List creation (id)")
+	}
+
+	return
+}
+
+func (c *Client) RecipientLists() (*[]RecipientList, *Response, error) {
 	path := fmt.Sprintf(recipListsPathFormat, c.Config.ApiVersion)
 	url := fmt.Sprintf("%s%s", c.Config.BaseUrl, path)
-	res, err = c.HttpPost(url, jsonBytes)
+	res, err := c.doJSON(context.Background(), "GET", url, nil, "RecipientList", "list")
 	if err != nil {
-		return
+		return nil, res, err
 	}
 
-	if err = res.AssertJson(); err != nil {
-		return
+	var list []RecipientList
+	if err = res.DecodeResults(&list); err != nil {
+		return nil, res, err
 	}
+	return &list, res, nil
+}
 
-	err = res.ParseResponse()
+// RecipientList returns the RecipientList with the specified id.
+// Recipients are only included when showRecipients is true; use RecipientListEach
+// to read them without holding the whole list in memory.
+func (c *Client) RecipientList(id string, showRecipients bool) (*RecipientList, *Response, error) {
+	res, err := c.recipientListGet(id, showRecipients)
 	if err != nil {
-		return
+		return nil, res, err
 	}
 
-	if res.HTTP.StatusCode == 200 {
-		var ok bool
-		var results map[string]interface{}
-		if results, ok = res.Results.(map[string]interface{}); !ok {
-			return id, res, fmt.Errorf("Unexpected response to Recipient List creation (results)")
-		}
-		id, ok = results["id"].(string)
-		if !ok {
-			return id, res, fmt.Errorf("Unexpected response to Recipient List creation (id)")
-		}
+	if err = res.ParseResponse(); err != nil {
+		return nil, res, err
+	}
+	rl := &RecipientList{}
+	if err = res.DecodeResults(rl); err != nil {
+		return nil, res, err
+	}
+	return rl, res, nil
+}
 
-	} else if len(res.Errors) > 0 {
-		// handle common errors
-		err = res.PrettyError("RecipientList", "create")
-		if err != nil {
-			return
-		}
+// RecipientListEach is like RecipientList with showRecipients set, but decodes the
+// recipients as they're read, calling fn for each one instead of building the full list
+// in memory. The returned RecipientList has the list metadata, and nil Recipients.
+// If fn returns an error, no more recipients are read and that error is returned.
+func (c *Client) RecipientListEach(id string, fn func(*Recipient) error) (*RecipientList, *Response, error) {
+	res, err := c.recipientListGet(id, true)
+	if err != nil {
+		return nil, res, err
+	}
 
-		code := res.HTTP.StatusCode
-		if code == 400 || code == 422 {
-			eobj := res.Errors[0]
-			err = fmt.Errorf("%s: %s\n%s", eobj.Code, eobj.Message, eobj.Description)
-		} else { // everything else
-			err = fmt.Errorf("%d: %s", code, string(res.Body))
+	rest, err := res.eachResultsField("recipients", func(raw json.RawMessage) error {
+		r := &Recipient{}
+		if err := unmarshalJSON(raw, r, c.Config.StrictDecoding); err != nil {
+			return err
 		}
+		return fn(r)
+	})
+	if err == nil && len(res.Errors) > 0 {
+		err = res.SPError()
+	}
+	if err != nil {
+		return nil, res, err
 	}
 
-	return
+	meta, err := json.Marshal(rest)
+	if err != nil {
+		return nil, res, err
+	}
+	rl := &RecipientList{}
+	if err = unmarshalJSON(meta, rl, c.Config.StrictDecoding); err != nil {
+		return nil, res, err
+	}
+	return rl, res, nil
 }
 
-func (c *Client) RecipientLists() (*[]RecipientList, *Response, error) {
+// recipientListGet requests the RecipientList with the specified id, and handles
+// any error response. The body of a successful response is left unread.
+func (c *Client) recipientListGet(id string, showRecipients bool) (*Response, error) {
+	if id == "" {
+		return nil, fmt.Errorf("Retrieve called with blank id")
+	}
+
 	path := fmt.Sprintf(recipListsPathFormat, c.Config.ApiVersion)
-	url := fmt.Sprintf("%s%s", c.Config.BaseUrl, path)
+	url := NewParams().Bool("show_recipients", showRecipients).Url(fmt.Sprintf("%s%s/%s", c.Config.BaseUrl, path, id))
 	res, err := c.HttpGet(url)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 
 	if err = res.AssertJson(); err != nil {
-		return nil, res, err
+		return res, err
 	}
 
-	if res.HTTP.StatusCode == 200 {
-		var body []byte
-		body, err = res.ReadBody()
-		if err != nil {
-			return nil, res, err
-		}
-		rllist := map[string][]RecipientList{}
-		if err = json.Unmarshal(body, &rllist); err != nil {
-			return nil, res, err
-		} else if list, ok := rllist["results"]; ok {
-			return &list, res, nil
+	if !res.success() {
+		if err = res.ParseResponse(); err != nil {
+			return res, err
 		}
-		return nil, res, fmt.Errorf("Unexpected response to RecipientList list")
-
-	} else {
-		err = res.ParseResponse()
+		err = res.PrettyError("RecipientList", "retrieve")
 		if err != nil {
-			return nil, res, err
-		}
-		if len(res.Errors) > 0 {
-			err = res.PrettyError("RecipientList", "list")
-			if err != nil {
-				return nil, res, err
-			}
+			return res, err
 		}
-		return nil, res, fmt.Errorf("%d: %s", res.HTTP.StatusCode, string(res.Body))
+		return res, res.SPError()
 	}
 
-	return nil, res, err
+	return res, nil
 }
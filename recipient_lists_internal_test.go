@@ -0,0 +1,132 @@
+package gosparkpost
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+var recipientListRetrieveSuccess = `{
+  "results": {
+    "id": "unique_id_4_graduate_students_list",
+    "name": "graduate_students",
+    "description": "An email list of graduate students at UMBC",
+    "attributes": {"internal_id": 112, "list_group_id": 12321},
+    "total_accepted_recipients": 2,
+    "recipients": [
+      {"address": {"email": "alice@example.com", "name": "Alice"}, "tags": ["grad"]},
+      {"address": {"email": "bob@example.com"}, "return_path": "bounces@example.com"}
+    ]
+  }
+}`
+
+func TestRecipientList(t *testing.T) {
+	testSetup(t)
+	defer testTeardown()
+
+	// set up the response handler
+	var query string
+	path := fmt.Sprintf(recipListsPathFormat, testClient.Config.ApiVersion)
+	testMux.HandleFunc(path+"/unique_id_4_graduate_students_list", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		query = r.URL.RawQuery
+		w.Header().Set("Content-Type", "application/json; charset=utf8")
+		w.Write([]byte(recipientListRetrieveSuccess))
+	})
+
+	rl, res, err := testClient.RecipientList("unique_id_4_graduate_students_list", true)
+	if err != nil {
+		testFailVerbose(t, res, "RecipientList returned error: %v", err)
+	}
+	if query != "show_recipients=true" {
+		t.Errorf("query string was %q", query)
+	}
+	if rl == nil || rl.Recipients == nil {
+		t.Fatalf("RecipientList returned %+v, expected recipients", rl)
+	}
+	if len(*rl.Recipients) != 2 || rl.Accepted == nil || *rl.Accepted != 2 {
+		t.Errorf("unexpected list %s", rl)
+	}
+}
+
+func TestRecipientListEach(t *testing.T) {
+	testSetup(t)
+	defer testTeardown()
+
+	// set up the response handler
+	var query string
+	path := fmt.Sprintf(recipListsPathFormat, testClient.Config.ApiVersion)
+	testMux.HandleFunc(path+"/unique_id_4_graduate_students_list", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		query = r.URL.RawQuery
+		w.Header().Set("Content-Type", "application/json; charset=utf8")
+		w.Write([]byte(recipientListRetrieveSuccess))
+	})
+
+	var seen []string
+	rl, res, err := testClient.RecipientListEach("unique_id_4_graduate_students_list", func(r *Recipient) error {
+		a, err := ParseAddress(r.Address)
+		seen = append(seen, a.Email)
+		return err
+	})
+	if err != nil {
+		testFailVerbose(t, res, "RecipientListEach returned error: %v", err)
+	}
+	if query != "show_recipients=true" {
+		t.Errorf("query string was %q", query)
+	}
+	if len(seen) != 2 || seen[0] != "alice@example.com" || seen[1] != "bob@example.com" {
+		t.Errorf("RecipientListEach saw %v", seen)
+	}
+	if rl == nil {
+		t.Fatal("RecipientListEach returned nil metadata")
+	}
+	if rl.Name != "graduate_students" || rl.Recipients != nil || rl.Accepted == nil || *rl.Accepted != 2 {
+		t.Errorf("unexpected metadata %s", rl)
+	}
+
+	// an error from fn stops the stream
+	stop := errors.New("stop")
+	n := 0
+	_, _, err = testClient.RecipientListEach("unique_id_4_graduate_students_list", func(r *Recipient) error {
+		n++
+		return stop
+	})
+	if err != stop || n != 1 {
+		t.Errorf("RecipientListEach returned %v after %d recipients", err, n)
+	}
+
+	// an errors array in a successful response is returned as an SPError
+	testMux.HandleFunc(path+"/warned", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json; charset=utf8")
+		w.WriteHeader(http.StatusNonAuthoritativeInfo)
+		w.Write([]byte(`{"results": {"id": "warned", "recipients": []}, "errors": [{"message": "partial list", "code": "1200"}]}`))
+	})
+	_, _, err = testClient.RecipientListEach("warned", func(r *Recipient) error { return nil })
+	var spErr *SPError
+	if !errors.As(err, &spErr) || spErr.Code != "1200" {
+		t.Errorf("RecipientListEach returned %v, expected an SPError", err)
+	}
+}
+
+func TestRecipientList_notFound(t *testing.T) {
+	testSetup(t)
+	defer testTeardown()
+
+	// set up the response handler
+	path := fmt.Sprintf(recipListsPathFormat, testClient.Config.ApiVersion)
+	testMux.HandleFunc(path+"/missing", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json; charset=utf8")
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"errors": [{"message": "resource not found"}]}`))
+	})
+
+	_, _, err := testClient.RecipientListEach("missing", func(r *Recipient) error {
+		t.Error("fn called for a missing list")
+		return nil
+	})
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("RecipientListEach returned %v, expected ErrNotFound", err)
+	}
+}
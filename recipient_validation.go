@@ -0,0 +1,53 @@
+package gosparkpost
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// https://developers.sparkpost.com/api/#/reference/recipient-validation
+var recipientValidationPathFormat = "/api/v%d/recipient-validation/single"
+
+// Verdicts returned in RecipientValidation.Result.
+const (
+	RecipientValid         = "valid"
+	RecipientNeutral       = "neutral"
+	RecipientRisky         = "risky"
+	RecipientUndeliverable = "undeliverable"
+)
+
+// RecipientValidation is the verdict returned from the SparkPost Recipient Validation API.
+type RecipientValidation struct {
+	// Valid is false if mail to the address can't be delivered; Result and Reason say why.
+	Valid  bool   `json:"valid"`
+	Result string `json:"result"`
+	Reason string `json:"reason,omitempty"`
+	// DidYouMean suggests a correction to an address with a mistyped domain.
+	DidYouMean string `json:"did_you_mean,omitempty"`
+
+	IsRole       bool `json:"is_role"`
+	IsDisposable bool `json:"is_disposable"`
+	IsFree       bool `json:"is_free"`
+	// DeliveryConfidence is the likelihood of delivery, from 0 to 100.
+	DeliveryConfidence int `json:"delivery_confidence"`
+}
+
+// ValidateRecipient checks whether mail to the email address would be delivered, without sending any.
+func (c *Client) ValidateRecipient(email string) (*RecipientValidation, *Response, error) {
+	if email == "" {
+		return nil, nil, fmt.Errorf("ValidateRecipient called with blank email")
+	}
+
+	path := fmt.Sprintf(recipientValidationPathFormat, c.Config.ApiVersion)
+	res, err := c.doJSON(context.Background(), "GET", fmt.Sprintf("%s%s/%s", c.Config.BaseUrl, path, url.PathEscape(email)), nil, "Recipient", "validate")
+	if err != nil {
+		return nil, res, err
+	}
+
+	v := &RecipientValidation{}
+	if err = res.DecodeResults(v); err != nil {
+		return nil, res, err
+	}
+	return v, res, nil
+}
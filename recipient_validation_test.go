@@ -0,0 +1,50 @@
+package gosparkpost
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestValidateRecipient(t *testing.T) {
+	testSetup(t)
+	defer testTeardown()
+
+	// set up the response handlers
+	path := fmt.Sprintf(recipientValidationPathFormat, testClient.Config.ApiVersion)
+	testMux.HandleFunc(path+"/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		w.Header().Set("Content-Type", "application/json; charset=utf8")
+		switch r.URL.Path {
+		case path + "/info@gmial.com":
+			w.Write([]byte(`{"results": {"valid": false, "result": "undeliverable", "reason": "Invalid Domain",
+				"is_role": true, "is_disposable": false, "is_free": false, "delivery_confidence": 0,
+				"did_you_mean": "info@gmail.com"}}`))
+		case path + "/someone+tag@example.com":
+			w.Write([]byte(`{"results": {"valid": true, "result": "valid", "is_role": false,
+				"is_disposable": false, "is_free": true, "delivery_confidence": 97}}`))
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	})
+
+	v, res, err := testClient.ValidateRecipient("info@gmial.com")
+	if err != nil {
+		testFailVerbose(t, res, "ValidateRecipient returned error: %v", err)
+	}
+	if v.Valid || v.Result != RecipientUndeliverable || v.Reason != "Invalid Domain" || !v.IsRole || v.DidYouMean != "info@gmail.com" {
+		t.Errorf("ValidateRecipient returned %+v", v)
+	}
+
+	v, res, err = testClient.ValidateRecipient("someone+tag@example.com")
+	if err != nil {
+		testFailVerbose(t, res, "ValidateRecipient returned error: %v", err)
+	}
+	if !v.Valid || v.Result != RecipientValid || !v.IsFree || v.DeliveryConfidence != 97 {
+		t.Errorf("ValidateRecipient returned %+v", v)
+	}
+
+	if _, _, err = testClient.ValidateRecipient(""); err == nil {
+		t.Error("ValidateRecipient accepted a blank email")
+	}
+}
@@ -0,0 +1,159 @@
+package gosparkpost
+
+import (
+	"context"
+	"fmt"
+)
+
+// https://developers.sparkpost.com/api/#/reference/relay-webhooks
+var relayWebhooksPathFormat = "/api/v%d/relay-webhooks"
+
+// RelayWebhook is the JSON structure accepted by and returned from the SparkPost Relay Webhooks API.
+// Mail sent to Match.Domain is parsed and POSTed as JSON to Target.
+type RelayWebhook struct {
+	ID        string     `json:"id,omitempty"`
+	Name      string     `json:"name,omitempty"`
+	Target    string     `json:"target,omitempty"`
+	AuthToken string     `json:"auth_token,omitempty"`
+	Match     RelayMatch `json:"match"`
+}
+
+// RelayMatch describes the inbound mail a RelayWebhook receives.
+type RelayMatch struct {
+	// Protocol is "SMTP", which is assumed if left blank.
+	Protocol string `json:"protocol,omitempty"`
+	// Domain is an inbound domain, already set up with the Inbound Domains API.
+	Domain string `json:"domain,omitempty"`
+}
+
+// RelayWebhookResults is the "results" object returned when a RelayWebhook is created.
+type RelayWebhookResults struct {
+	ID string `json:"id"`
+}
+
+// Validate runs sanity checks on a RelayWebhook struct.
+// This should catch most errors before attempting a doomed API call.
+func (r *RelayWebhook) Validate() error {
+	if r == nil {
+		return fmt.Errorf("Can't Validate a nil RelayWebhook")
+	}
+
+	// enforce required parameters
+	if r.Target == "" {
+		return fmt.Errorf("RelayWebhook requires a non-empty Target")
+	} else if r.Match.Domain == "" {
+		return fmt.Errorf("RelayWebhook requires a non-empty Match.Domain")
+	}
+
+	// enforce max lengths
+	if len(r.Name) > 1024 {
+		return fmt.Errorf("RelayWebhook name may not be longer than 1024 bytes")
+	}
+
+	return nil
+}
+
+// Create accepts a populated RelayWebhook object, validates it,
+// and performs an API call against the configured endpoint.
+func (c *Client) RelayWebhookCreate(r *RelayWebhook) (id string, res *Response, err error) {
+	if r == nil {
+		err = fmt.Errorf("Create called with nil RelayWebhook")
+		return
+	}
+
+	err = r.Validate()
+	if err != nil {
+		return
+	}
+
+	path := fmt.Sprintf(relayWebhooksPathFormat, c.Config.ApiVersion)
+	url := fmt.Sprintf("%s%s", c.Config.BaseUrl, path)
+	res, err = c.doJSON(context.Background(), "POST", url, r, "RelayWebhook", "create")
+	if res.conflict() {
+		// handle relay webhook-specific ones
+		err = &prettyError{msg: fmt.Sprintf("RelayWebhook for domain [%s] already exists", r.Match.Domain), cause: res.SPError()}
+		return
+	}
+	if err != nil {
+		return
+	}
+
+	var results RelayWebhookResults
+	if err = res.DecodeResults(&results); err != nil {
+		return id, res, err
+	}
+	id = results.ID
+	if id == "" {
+		err = res.unexpected("Unexpected response to RelayWebhook creation")
+	}
+	r.ID = id
+
+	return
+}
+
+// Update updates the RelayWebhook with the specified id.
+func (c *Client) RelayWebhookUpdate(r *RelayWebhook) (res *Response, err error) {
+	if r == nil || r.ID == "" {
+		err = fmt.Errorf("Update called with blank id")
+		return
+	}
+
+	err = r.Validate()
+	if err != nil {
+		return
+	}
+
+	path := fmt.Sprintf(relayWebhooksPathFormat, c.Config.ApiVersion)
+	url := fmt.Sprintf("%s%s/%s", c.Config.BaseUrl, path, r.ID)
+	res, err = c.doJSON(context.Background(), "PUT", url, r, "RelayWebhook", "update")
+	return
+}
+
+// List returns all RelayWebhooks in the system.
+func (c *Client) RelayWebhooks() ([]RelayWebhook, *Response, error) {
+	path := fmt.Sprintf(relayWebhooksPathFormat, c.Config.ApiVersion)
+	url := fmt.Sprintf("%s%s", c.Config.BaseUrl, path)
+	res, err := c.doJSON(context.Background(), "GET", url, nil, "RelayWebhook", "list")
+	if err != nil {
+		return nil, res, err
+	}
+
+	var list []RelayWebhook
+	if err = res.DecodeResults(&list); err != nil {
+		return nil, res, err
+	}
+	return list, res, nil
+}
+
+// RelayWebhook returns the RelayWebhook with the specified id.
+func (c *Client) RelayWebhook(id string) (*RelayWebhook, *Response, error) {
+	if id == "" {
+		return nil, nil, fmt.Errorf("Retrieve called with blank id")
+	}
+
+	path := fmt.Sprintf(relayWebhooksPathFormat, c.Config.ApiVersion)
+	url := fmt.Sprintf("%s%s/%s", c.Config.BaseUrl, path, id)
+	res, err := c.doJSON(context.Background(), "GET", url, nil, "RelayWebhook", "retrieve")
+	if err != nil {
+		return nil, res, err
+	}
+
+	r := &RelayWebhook{}
+	if err = res.DecodeResults(r); err != nil {
+		return nil, res, err
+	}
+	return r, res, nil
+}
+
+// Delete removes the RelayWebhook with the specified id.
+func (c *Client) RelayWebhookDelete(id string) (res *Response, err error) {
+	if id == "" {
+		err = fmt.Errorf("Delete called with blank id")
+		return
+	}
+
+	path := fmt.Sprintf(relayWebhooksPathFormat, c.Config.ApiVersion)
+	url := fmt.Sprintf("%s%s/%s", c.Config.BaseUrl, path, id)
+	res, err = c.doJSON(context.Background(), "DELETE", url, nil, "RelayWebhook", "delete")
+	return
+}
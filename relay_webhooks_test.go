@@ -0,0 +1,126 @@
+package gosparkpost
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+var relayWebhookList = `{
+  "results": [
+    {
+      "id": "12013026328707075",
+      "name": "Replies Webhook",
+      "target": "https://webhooks.customer.example/replies",
+      "auth_token": "",
+      "match": {"protocol": "SMTP", "domain": "email.example.com"}
+    }
+  ]
+}`
+
+func TestRelayWebhooks(t *testing.T) {
+	testSetup(t)
+	defer testTeardown()
+
+	// set up the response handlers
+	path := fmt.Sprintf(relayWebhooksPathFormat, testClient.Config.ApiVersion)
+	var created RelayWebhook
+	testMux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json; charset=utf8")
+		switch r.Method {
+		case "POST":
+			json.NewDecoder(r.Body).Decode(&created)
+			w.Write([]byte(`{"results": {"id": "12013026328707075"}}`))
+		case "GET":
+			w.Write([]byte(relayWebhookList))
+		default:
+			t.Errorf("unexpected method %s", r.Method)
+		}
+	})
+	var methods []string
+	testMux.HandleFunc(path+"/12013026328707075", func(w http.ResponseWriter, r *http.Request) {
+		methods = append(methods, r.Method)
+		w.Header().Set("Content-Type", "application/json; charset=utf8")
+		switch r.Method {
+		case "GET":
+			w.Write([]byte(`{"results": {"name": "Replies Webhook", "target": "https://webhooks.customer.example/replies",
+				"match": {"protocol": "SMTP", "domain": "email.example.com"}}}`))
+		case "PUT":
+			w.Write([]byte(`{"results": {"id": "12013026328707075"}}`))
+		case "DELETE":
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+
+	hook := &RelayWebhook{
+		Name:      "Replies Webhook",
+		Target:    "https://webhooks.customer.example/replies",
+		AuthToken: "5ebe2294ecd0e0f08eab7690d2a6ee69",
+		Match:     RelayMatch{Domain: "email.example.com"},
+	}
+	id, res, err := testClient.RelayWebhookCreate(hook)
+	if err != nil {
+		testFailVerbose(t, res, "RelayWebhookCreate returned error: %v", err)
+	}
+	if id != "12013026328707075" || hook.ID != id {
+		t.Errorf("RelayWebhookCreate returned id %q (webhook has %q)", id, hook.ID)
+	}
+	if created.AuthToken != hook.AuthToken || created.Match.Domain != "email.example.com" {
+		t.Errorf("server saw %+v", created)
+	}
+
+	list, res, err := testClient.RelayWebhooks()
+	if err != nil {
+		testFailVerbose(t, res, "RelayWebhooks returned error: %v", err)
+	}
+	if len(list) != 1 || list[0].Match.Protocol != "SMTP" {
+		t.Errorf("RelayWebhooks returned %+v", list)
+	}
+
+	got, res, err := testClient.RelayWebhook(id)
+	if err != nil {
+		testFailVerbose(t, res, "RelayWebhook returned error: %v", err)
+	}
+	if got == nil || got.Target != hook.Target {
+		t.Errorf("RelayWebhook returned %+v", got)
+	}
+
+	hook.Target = "https://webhooks.customer.example/replies/v2"
+	if res, err = testClient.RelayWebhookUpdate(hook); err != nil {
+		testFailVerbose(t, res, "RelayWebhookUpdate returned error: %v", err)
+	}
+	if res, err = testClient.RelayWebhookDelete(id); err != nil {
+		testFailVerbose(t, res, "RelayWebhookDelete returned error: %v", err)
+	}
+	if fmt.Sprint(methods) != "[GET PUT DELETE]" {
+		t.Errorf("server saw methods %v", methods)
+	}
+}
+
+func TestRelayWebhookCreate_errors(t *testing.T) {
+	testSetup(t)
+	defer testTeardown()
+
+	// set up the response handler
+	path := fmt.Sprintf(relayWebhooksPathFormat, testClient.Config.ApiVersion)
+	testMux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json; charset=utf8")
+		w.WriteHeader(http.StatusConflict)
+		w.Write([]byte(`{"errors": [{"message": "resource conflict", "code": "1602"}]}`))
+	})
+
+	if _, _, err := testClient.RelayWebhookCreate(&RelayWebhook{Target: "https://example.com"}); err == nil {
+		t.Error("RelayWebhookCreate accepted a webhook without a match domain")
+	}
+
+	_, _, err := testClient.RelayWebhookCreate(&RelayWebhook{Target: "https://example.com", Match: RelayMatch{Domain: "email.example.com"}})
+	var spErr *SPError
+	if !errors.As(err, &spErr) || spErr.StatusCode != 409 {
+		t.Errorf("RelayWebhookCreate returned %v, expected a 409 SPError", err)
+	}
+	if err.Error() != "RelayWebhook for domain [email.example.com] already exists" {
+		t.Errorf("RelayWebhookCreate returned %q", err)
+	}
+}
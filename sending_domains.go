@@ -0,0 +1,282 @@
+package gosparkpost
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"fmt"
+	"net/url"
+)
+
+// https://developers.sparkpost.com/api/#/reference/sending-domains
+var sendingDomainsPathFormat = "/api/v%d/sending-domains"
+
+// SendingDomain is the JSON structure accepted by and returned from the SparkPost Sending Domains API.
+type SendingDomain struct {
+	Domain         string               `json:"domain,omitempty"`
+	TrackingDomain string               `json:"tracking_domain,omitempty"`
+	Status         *SendingDomainStatus `json:"status,omitempty"`
+	DKIM           *DKIM                `json:"dkim,omitempty"`
+	// SharedWithSubaccounts and IsDefaultBounceDomain are pointers so an update can set them
+	// to false; nil leaves them as they are. See Bool.
+	SharedWithSubaccounts *bool `json:"shared_with_subaccounts,omitempty"`
+	// IsDefaultBounceDomain makes this the return path of mail which doesn't specify one.
+	// Only domains whose CNAME has been verified, by SendingDomainVerify, can be the default.
+	IsDefaultBounceDomain *bool `json:"is_default_bounce_domain,omitempty"`
+}
+
+// Bool returns a pointer to b, for optional fields such as SendingDomain.IsDefaultBounceDomain.
+func Bool(b bool) *bool {
+	return &b
+}
+
+// DKIM describes the key a SendingDomain's mail is signed with.
+// Private is only ever sent, never returned.
+type DKIM struct {
+	Private       string `json:"private,omitempty"`
+	Public        string `json:"public,omitempty"`
+	Selector      string `json:"selector,omitempty"`
+	Headers       string `json:"headers,omitempty"`
+	SigningDomain string `json:"signing_domain,omitempty"`
+}
+
+// DefaultDKIMKeyBits is the size of the keys made by GenerateDKIM when no size is given.
+const DefaultDKIMKeyBits = 2048
+
+// GenerateDKIM makes a new RSA key of the given size in bits, for signing with selector.
+// Zero bits means DefaultDKIMKeyBits.
+func GenerateDKIM(selector string, bits int) (*DKIM, error) {
+	if selector == "" {
+		return nil, fmt.Errorf("GenerateDKIM called with blank selector")
+	}
+	if bits == 0 {
+		bits = DefaultDKIMKeyBits
+	}
+
+	key, err := rsa.GenerateKey(rand.Reader, bits)
+	if err != nil {
+		return nil, err
+	}
+	public, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DKIM{
+		Private:  base64.StdEncoding.EncodeToString(x509.MarshalPKCS1PrivateKey(key)),
+		Public:   base64.StdEncoding.EncodeToString(public),
+		Selector: selector,
+	}, nil
+}
+
+// DNSRecord is a record that must be published in DNS, for example to verify a SendingDomain.
+type DNSRecord struct {
+	Name  string
+	Type  string
+	Value string
+}
+
+// TXTRecord returns the DNS record publishing the public key of d, for mail from domain.
+// The signing domain is used instead of domain, if there is one.
+func (d *DKIM) TXTRecord(domain string) DNSRecord {
+	if d.SigningDomain != "" {
+		domain = d.SigningDomain
+	}
+	return DNSRecord{
+		Name:  fmt.Sprintf("%s._domainkey.%s", d.Selector, domain),
+		Type:  "TXT",
+		Value: fmt.Sprintf("v=DKIM1; k=rsa; h=sha256; p=%s", d.Public),
+	}
+}
+
+// SendingDomainStatus holds the outcome of each verification check of a SendingDomain.
+// The string statuses are one of "valid", "invalid", "unverified" and "pending".
+type SendingDomainStatus struct {
+	OwnershipVerified         bool   `json:"ownership_verified"`
+	DKIMStatus                string `json:"dkim_status,omitempty"`
+	SPFStatus                 string `json:"spf_status,omitempty"`
+	CNAMEStatus               string `json:"cname_status,omitempty"`
+	MXStatus                  string `json:"mx_status,omitempty"`
+	AbuseAtStatus             string `json:"abuse_at_status,omitempty"`
+	PostmasterAtStatus        string `json:"postmaster_at_status,omitempty"`
+	VerificationMailboxStatus string `json:"verification_mailbox_status,omitempty"`
+	ComplianceStatus          string `json:"compliance_status,omitempty"`
+}
+
+// Ready reports whether mail can be sent from the domain: its ownership has been
+// verified, and it has passed compliance review.
+func (s *SendingDomainStatus) Ready() bool {
+	return s.OwnershipVerified && s.ComplianceStatus == "valid"
+}
+
+// BounceReady reports whether the domain can be used as a bounce (return path) domain,
+// which requires its CNAME record to have been verified.
+func (s *SendingDomainStatus) BounceReady() bool {
+	return s.CNAMEStatus == "valid"
+}
+
+// VerifyOptions selects the checks run by SendingDomainVerify.
+// AbuseAtToken and PostmasterAtToken complete a mailbox verification, using the
+// token from the email sent by an earlier AbuseAt or PostmasterAt check.
+type VerifyOptions struct {
+	DKIM              bool   `json:"dkim_verify,omitempty"`
+	SPF               bool   `json:"spf_verify,omitempty"`
+	CNAME             bool   `json:"cname_verify,omitempty"`
+	AbuseAt           bool   `json:"abuse_at_verify,omitempty"`
+	PostmasterAt      bool   `json:"postmaster_at_verify,omitempty"`
+	AbuseAtToken      string `json:"abuse_at_token,omitempty"`
+	PostmasterAtToken string `json:"postmaster_at_token,omitempty"`
+}
+
+// VerifyResults is the "results" object returned when a SendingDomain is verified.
+// DNS holds the records that were looked up, and why any didn't pass.
+type VerifyResults struct {
+	SendingDomainStatus
+	DNS *struct {
+		DKIMRecord string `json:"dkim_record,omitempty"`
+		SPFRecord  string `json:"spf_record,omitempty"`
+		DKIMError  string `json:"dkim_error,omitempty"`
+		SPFError   string `json:"spf_error,omitempty"`
+		CNAMEError string `json:"cname_error,omitempty"`
+	} `json:"dns,omitempty"`
+}
+
+func (c *Client) sendingDomainUrl(domain string) string {
+	path := fmt.Sprintf(sendingDomainsPathFormat, c.Config.ApiVersion)
+	return fmt.Sprintf("%s%s/%s", c.Config.BaseUrl, path, url.PathEscape(domain))
+}
+
+// Create accepts a populated SendingDomain object and performs an API call against the
+// configured endpoint. If no DKIM key is provided, SparkPost generates one, and it's set on d.
+func (c *Client) SendingDomainCreate(d *SendingDomain) (res *Response, err error) {
+	if d == nil || d.Domain == "" {
+		err = fmt.Errorf("Create called with blank domain")
+		return
+	}
+
+	create := *d
+	create.Status = nil
+
+	path := fmt.Sprintf(sendingDomainsPathFormat, c.Config.ApiVersion)
+	res, err = c.doJSON(context.Background(), "POST", c.Config.BaseUrl+path, create, "SendingDomain", "create")
+	if res.conflict() {
+		// handle sending domain-specific ones
+		err = &prettyError{msg: fmt.Sprintf("Sending domain [%s] already exists", d.Domain), cause: res.SPError()}
+		return
+	}
+	if err != nil {
+		return
+	}
+
+	var results SendingDomain
+	if err = res.DecodeResults(&results); err != nil {
+		return
+	}
+	if d.DKIM == nil && results.DKIM != nil {
+		d.DKIM = results.DKIM
+	}
+
+	return
+}
+
+// SetDefaultBounceDomain makes the specified sending domain the default bounce domain.
+// Its CNAME must already have been verified.
+func (c *Client) SetDefaultBounceDomain(domain string) (*Response, error) {
+	return c.SendingDomainUpdate(&SendingDomain{Domain: domain, IsDefaultBounceDomain: Bool(true)})
+}
+
+// SendingDomain returns the specified sending domain, including its verification status.
+func (c *Client) SendingDomain(domain string) (*SendingDomain, *Response, error) {
+	if domain == "" {
+		return nil, nil, fmt.Errorf("Retrieve called with blank domain")
+	}
+
+	res, err := c.doJSON(context.Background(), "GET", c.sendingDomainUrl(domain), nil, "SendingDomain", "retrieve")
+	if err != nil {
+		return nil, res, err
+	}
+
+	d := &SendingDomain{}
+	if err = res.DecodeResults(d); err != nil {
+		return nil, res, err
+	}
+	// the domain is only given by the path
+	if d.Domain == "" {
+		d.Domain = domain
+	}
+	return d, res, nil
+}
+
+// Verify runs the verification checks selected by opts against the specified sending domain,
+// and returns the resulting status of each check. Checks may take a while to pass once DNS
+// records are published, so onboarding flows will usually call this repeatedly.
+func (c *Client) SendingDomainVerify(domain string, opts *VerifyOptions) (*VerifyResults, *Response, error) {
+	if domain == "" {
+		return nil, nil, fmt.Errorf("Verify called with blank domain")
+	}
+	if opts == nil {
+		opts = &VerifyOptions{}
+	}
+
+	res, err := c.doJSON(context.Background(), "POST", c.sendingDomainUrl(domain)+"/verify", opts, "SendingDomain", "verify")
+	if err != nil {
+		return nil, res, err
+	}
+
+	v := &VerifyResults{}
+	if err = res.DecodeResults(v); err != nil {
+		return nil, res, err
+	}
+	return v, res, nil
+}
+
+// Update updates the sending domain with the specified Domain.
+func (c *Client) SendingDomainUpdate(d *SendingDomain) (res *Response, err error) {
+	if d == nil || d.Domain == "" {
+		err = fmt.Errorf("Update called with blank domain")
+		return
+	}
+
+	// the domain is given by the path, and status can't be updated
+	update := *d
+	update.Domain, update.Status = "", nil
+
+	res, err = c.doJSON(context.Background(), "PUT", c.sendingDomainUrl(d.Domain), update, "SendingDomain", "update")
+	return
+}
+
+// RotateDKIM replaces the DKIM key of the specified sending domain, and returns the TXT
+// record that must be published for the new key before DKIM verification can pass.
+// If key is nil, a new one is made by GenerateDKIM for selector. Using a new selector means
+// the record of the old key can stay published until mail signed with it has been delivered.
+func (c *Client) RotateDKIM(domain, selector string, key *DKIM) (*DNSRecord, *Response, error) {
+	if domain == "" {
+		return nil, nil, fmt.Errorf("RotateDKIM called with blank domain")
+	}
+
+	if key == nil {
+		var err error
+		if key, err = GenerateDKIM(selector, 0); err != nil {
+			return nil, nil, err
+		}
+	} else if key.Private == "" || key.Public == "" {
+		return nil, nil, fmt.Errorf("RotateDKIM requires both the private and public key")
+	} else if key.Selector == "" {
+		k := *key
+		k.Selector = selector
+		key = &k
+	}
+	if key.Selector == "" {
+		return nil, nil, fmt.Errorf("RotateDKIM called with blank selector")
+	}
+
+	res, err := c.SendingDomainUpdate(&SendingDomain{Domain: domain, DKIM: key})
+	if err != nil {
+		return nil, res, err
+	}
+
+	record := key.TXTRecord(domain)
+	return &record, res, nil
+}
@@ -0,0 +1,244 @@
+package gosparkpost
+
+import (
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+var sendingDomainRetrieve = `{
+  "results": {
+    "tracking_domain": "click.example1.com",
+    "status": {
+      "ownership_verified": false,
+      "spf_status": "unverified",
+      "abuse_at_status": "unverified",
+      "dkim_status": "unverified",
+      "cname_status": "unverified",
+      "mx_status": "pending",
+      "compliance_status": "pending",
+      "postmaster_at_status": "unverified",
+      "verification_mailbox_status": "unverified"
+    },
+    "dkim": {
+      "public": "MIGfMA0GCSqGSIb3DQEBAQUAA4GNADCBiQKBgQC+W6scd3XWwvC/hPRksfDYFi3ztgyS9OSqnnjtNQeDdTSD1DRx/xFar2wjmzxp2+SnJ5pspaF77VZveN3P/HVmXZVghr3asoV9WBx/uW1nDIUxU35L4juXiTwsMAbgMyh3NqIKTNKyMDy4P8vpEhtH1iv/BrwMdBjHDVCycB8WnwIDAQAB",
+      "selector": "hello_selector",
+      "headers": "from:to:subject:date"
+    },
+    "shared_with_subaccounts": false
+  }
+}`
+
+func TestSendingDomain(t *testing.T) {
+	testSetup(t)
+	defer testTeardown()
+
+	// set up the response handler
+	path := fmt.Sprintf(sendingDomainsPathFormat, testClient.Config.ApiVersion)
+	testMux.HandleFunc(path+"/example1.com", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		w.Header().Set("Content-Type", "application/json; charset=utf8")
+		w.Write([]byte(sendingDomainRetrieve))
+	})
+
+	d, res, err := testClient.SendingDomain("example1.com")
+	if err != nil {
+		testFailVerbose(t, res, "SendingDomain returned error: %v", err)
+	}
+	if d == nil || d.Domain != "example1.com" || d.TrackingDomain != "click.example1.com" {
+		t.Fatalf("SendingDomain returned %+v", d)
+	}
+	if d.Status == nil || d.Status.MXStatus != "pending" || d.Status.Ready() {
+		t.Errorf("unexpected status %+v", d.Status)
+	}
+	if d.DKIM == nil || d.DKIM.Selector != "hello_selector" {
+		t.Errorf("unexpected dkim %+v", d.DKIM)
+	}
+}
+
+func TestSendingDomainVerify(t *testing.T) {
+	testSetup(t)
+	defer testTeardown()
+
+	// set up the response handler
+	var body string
+	path := fmt.Sprintf(sendingDomainsPathFormat, testClient.Config.ApiVersion)
+	testMux.HandleFunc(path+"/example1.com/verify", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		b, _ := ioutil.ReadAll(r.Body)
+		body = string(b)
+		w.Header().Set("Content-Type", "application/json; charset=utf8")
+		w.Write([]byte(`{"results": {
+			"ownership_verified": true,
+			"dns": {"dkim_record": "k=rsa; h=sha256; p=MIGfMA0", "spf_record": "v=spf1 a mx ~all", "spf_error": "SPF record not found"},
+			"dkim_status": "valid",
+			"spf_status": "invalid",
+			"compliance_status": "valid",
+			"postmaster_at_status": "valid"
+		}}`))
+	})
+
+	v, res, err := testClient.SendingDomainVerify("example1.com", &VerifyOptions{DKIM: true, SPF: true, PostmasterAtToken: "rcayptmrczdnrnqfsxyrzljmtsxvjzxb"})
+	if err != nil {
+		testFailVerbose(t, res, "SendingDomainVerify returned error: %v", err)
+	}
+	if body != `{"dkim_verify":true,"spf_verify":true,"postmaster_at_token":"rcayptmrczdnrnqfsxyrzljmtsxvjzxb"}` {
+		t.Errorf("SendingDomainVerify sent %s", body)
+	}
+	if v == nil || v.DKIMStatus != "valid" || v.SPFStatus != "invalid" || !v.Ready() {
+		t.Fatalf("SendingDomainVerify returned %+v", v)
+	}
+	if v.DNS == nil || v.DNS.SPFError != "SPF record not found" {
+		t.Errorf("unexpected dns %+v", v.DNS)
+	}
+
+	if _, _, err = testClient.SendingDomainVerify("", nil); err == nil {
+		t.Error("SendingDomainVerify accepted a blank domain")
+	}
+}
+
+func TestGenerateDKIM(t *testing.T) {
+	key, err := GenerateDKIM("sel2017", 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	der, err := base64.StdEncoding.DecodeString(key.Private)
+	if err != nil {
+		t.Fatal(err)
+	}
+	private, err := x509.ParsePKCS1PrivateKey(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if private.N.BitLen() != 1024 {
+		t.Errorf("generated a %d bit key", private.N.BitLen())
+	}
+
+	record := key.TXTRecord("example1.com")
+	if record.Name != "sel2017._domainkey.example1.com" || record.Type != "TXT" {
+		t.Errorf("unexpected record %+v", record)
+	}
+	if !strings.HasPrefix(record.Value, "v=DKIM1; k=rsa; h=sha256; p=MI") {
+		t.Errorf("unexpected record value %q", record.Value)
+	}
+
+	key.SigningDomain = "signing.example1.com"
+	if name := key.TXTRecord("example1.com").Name; name != "sel2017._domainkey.signing.example1.com" {
+		t.Errorf("record with a signing domain was named %q", name)
+	}
+
+	if _, err = GenerateDKIM("", 0); err == nil {
+		t.Error("GenerateDKIM accepted a blank selector")
+	}
+}
+
+func TestRotateDKIM(t *testing.T) {
+	testSetup(t)
+	defer testTeardown()
+
+	// set up the response handler
+	var sent SendingDomain
+	var raw map[string]interface{}
+	path := fmt.Sprintf(sendingDomainsPathFormat, testClient.Config.ApiVersion)
+	testMux.HandleFunc(path+"/example1.com", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+		b, _ := ioutil.ReadAll(r.Body)
+		json.Unmarshal(b, &sent)
+		json.Unmarshal(b, &raw)
+		w.Header().Set("Content-Type", "application/json; charset=utf8")
+		w.Write([]byte(`{"results": {"message": "Successfully Updated Domain.", "domain": "example1.com"}}`))
+	})
+
+	key := &DKIM{Private: "cHJpdmF0ZQ==", Public: "cHVibGlj"}
+	record, res, err := testClient.RotateDKIM("example1.com", "sel2017", key)
+	if err != nil {
+		testFailVerbose(t, res, "RotateDKIM returned error: %v", err)
+	}
+	if sent.DKIM == nil || sent.DKIM.Private != "cHJpdmF0ZQ==" || sent.DKIM.Selector != "sel2017" {
+		t.Errorf("server saw %+v", sent.DKIM)
+	}
+	if _, ok := raw["domain"]; ok {
+		t.Errorf("domain was sent in the body: %v", raw)
+	}
+	if key.Selector != "" {
+		t.Error("RotateDKIM modified the provided key")
+	}
+	if record == nil || record.Value != "v=DKIM1; k=rsa; h=sha256; p=cHVibGlj" {
+		t.Errorf("RotateDKIM returned record %+v", record)
+	}
+
+	// a new key is generated if none is provided
+	if record, _, err = testClient.RotateDKIM("example1.com", "sel2018", nil); err != nil {
+		t.Fatal(err)
+	}
+	if sent.DKIM == nil || sent.DKIM.Private == "" || record.Name != "sel2018._domainkey.example1.com" {
+		t.Errorf("RotateDKIM sent %+v and returned %+v", sent.DKIM, record)
+	}
+
+	if _, _, err = testClient.RotateDKIM("example1.com", "", &DKIM{Private: "a", Public: "b"}); err == nil {
+		t.Error("RotateDKIM accepted a blank selector")
+	}
+}
+
+func TestSendingDomainCreate_bounce(t *testing.T) {
+	testSetup(t)
+	defer testTeardown()
+
+	// set up the response handlers
+	var created, updated map[string]interface{}
+	path := fmt.Sprintf(sendingDomainsPathFormat, testClient.Config.ApiVersion)
+	testMux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		json.NewDecoder(r.Body).Decode(&created)
+		w.Header().Set("Content-Type", "application/json; charset=utf8")
+		w.Write([]byte(`{"results": {"message": "Successfully Created domain.", "domain": "bounces.example1.com",
+			"dkim": {"public": "cHVibGlj", "selector": "scph0316", "signing_domain": "bounces.example1.com", "headers": "from:to:subject:date"}}}`))
+	})
+	testMux.HandleFunc(path+"/bounces.example1.com", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+		json.NewDecoder(r.Body).Decode(&updated)
+		w.Header().Set("Content-Type", "application/json; charset=utf8")
+		w.Write([]byte(`{"results": {"message": "Successfully Updated Domain.", "domain": "bounces.example1.com"}}`))
+	})
+
+	d := &SendingDomain{Domain: "bounces.example1.com", SharedWithSubaccounts: Bool(true)}
+	if res, err := testClient.SendingDomainCreate(d); err != nil {
+		testFailVerbose(t, res, "SendingDomainCreate returned error: %v", err)
+	}
+	if created["domain"] != "bounces.example1.com" || created["shared_with_subaccounts"] != true {
+		t.Errorf("server saw %v", created)
+	}
+	if _, ok := created["is_default_bounce_domain"]; ok {
+		t.Errorf("is_default_bounce_domain was sent: %v", created)
+	}
+	if d.DKIM == nil || d.DKIM.Selector != "scph0316" {
+		t.Errorf("generated DKIM key wasn't set: %+v", d.DKIM)
+	}
+
+	if res, err := testClient.SetDefaultBounceDomain("bounces.example1.com"); err != nil {
+		testFailVerbose(t, res, "SetDefaultBounceDomain returned error: %v", err)
+	}
+	if len(updated) != 1 || updated["is_default_bounce_domain"] != true {
+		t.Errorf("server saw %v", updated)
+	}
+
+	updated = nil
+	update := &SendingDomain{Domain: "bounces.example1.com", SharedWithSubaccounts: Bool(false), IsDefaultBounceDomain: Bool(false)}
+	if res, err := testClient.SendingDomainUpdate(update); err != nil {
+		testFailVerbose(t, res, "SendingDomainUpdate returned error: %v", err)
+	}
+	if len(updated) != 2 || updated["is_default_bounce_domain"] != false || updated["shared_with_subaccounts"] != false {
+		t.Errorf("server saw %v", updated)
+	}
+
+	status := &SendingDomainStatus{CNAMEStatus: "valid"}
+	if !status.BounceReady() {
+		t.Error("domain with a valid CNAME isn't ready for bounces")
+	}
+}
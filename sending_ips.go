@@ -0,0 +1,89 @@
+package gosparkpost
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// https://developers.sparkpost.com/api/#/reference/sending-ips
+var sendingIPsPathFormat = "/api/v%d/sending-ips"
+
+// SendingIP is the JSON structure returned from the SparkPost Sending IPs API.
+// Only dedicated IPs are listed.
+type SendingIP struct {
+	ExternalIP string `json:"external_ip"`
+	Hostname   string `json:"hostname,omitempty"`
+	IPPool     string `json:"ip_pool,omitempty"`
+
+	// AutoWarmupEnabled limits the volume sent from the IP while it warms up.
+	// AutoWarmupStage is how far along that is, and can't be updated.
+	AutoWarmupEnabled bool `json:"auto_warmup_enabled"`
+	AutoWarmupStage   int  `json:"auto_warmup_stage,omitempty"`
+	// AutoWarmupOverflowPool takes the mail over the warmup limit; the default is the shared pool.
+	AutoWarmupOverflowPool string `json:"auto_warmup_overflow_pool,omitempty"`
+}
+
+// sendingIPUpdate holds the SendingIP fields accepted by SendingIPUpdate.
+type sendingIPUpdate struct {
+	IPPool                 string `json:"ip_pool,omitempty"`
+	AutoWarmupEnabled      bool   `json:"auto_warmup_enabled"`
+	AutoWarmupOverflowPool string `json:"auto_warmup_overflow_pool,omitempty"`
+}
+
+// SendingIPs returns all dedicated sending IPs of the account.
+func (c *Client) SendingIPs() ([]SendingIP, *Response, error) {
+	path := fmt.Sprintf(sendingIPsPathFormat, c.Config.ApiVersion)
+	res, err := c.doJSON(context.Background(), "GET", c.Config.BaseUrl+path, nil, "SendingIP", "list")
+	if err != nil {
+		return nil, res, err
+	}
+
+	var list []SendingIP
+	if err = res.DecodeResults(&list); err != nil {
+		return nil, res, err
+	}
+	return list, res, nil
+}
+
+// SendingIP returns the specified sending IP.
+func (c *Client) SendingIP(ip string) (*SendingIP, *Response, error) {
+	if ip == "" {
+		return nil, nil, fmt.Errorf("Retrieve called with blank IP")
+	}
+
+	path := fmt.Sprintf(sendingIPsPathFormat, c.Config.ApiVersion)
+	res, err := c.doJSON(context.Background(), "GET", fmt.Sprintf("%s%s/%s", c.Config.BaseUrl, path, url.PathEscape(ip)), nil, "SendingIP", "retrieve")
+	if err != nil {
+		return nil, res, err
+	}
+
+	s := &SendingIP{}
+	if err = res.DecodeResults(s); err != nil {
+		return nil, res, err
+	}
+	return s, res, nil
+}
+
+// SendingIPUpdate moves the sending IP to s.IPPool and applies its warmup settings.
+// All of them are sent, so s should start out as the result of SendingIP.
+func (c *Client) SendingIPUpdate(s *SendingIP) (res *Response, err error) {
+	if s == nil {
+		err = fmt.Errorf("Update called with nil SendingIP")
+	} else if s.ExternalIP == "" {
+		err = fmt.Errorf("Update called with blank IP")
+	}
+	if err != nil {
+		return
+	}
+
+	update := sendingIPUpdate{
+		IPPool:                 s.IPPool,
+		AutoWarmupEnabled:      s.AutoWarmupEnabled,
+		AutoWarmupOverflowPool: s.AutoWarmupOverflowPool,
+	}
+
+	path := fmt.Sprintf(sendingIPsPathFormat, c.Config.ApiVersion)
+	res, err = c.doJSON(context.Background(), "PUT", fmt.Sprintf("%s%s/%s", c.Config.BaseUrl, path, url.PathEscape(s.ExternalIP)), update, "SendingIP", "update")
+	return
+}
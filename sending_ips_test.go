@@ -0,0 +1,86 @@
+package gosparkpost
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+)
+
+func TestSendingIPs(t *testing.T) {
+	testSetup(t)
+	defer testTeardown()
+
+	// set up the response handlers
+	path := fmt.Sprintf(sendingIPsPathFormat, testClient.Config.ApiVersion)
+	testMux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		w.Header().Set("Content-Type", "application/json; charset=utf8")
+		w.Write([]byte(`{"results": [
+			{"external_ip": "123.45.67.89", "hostname": "mta472a.sparkpostmail.com", "ip_pool": "default",
+			 "auto_warmup_enabled": true, "auto_warmup_stage": 5},
+			{"external_ip": "123.45.67.90", "hostname": "mta473a.sparkpostmail.com", "ip_pool": "marketing",
+			 "auto_warmup_enabled": false}
+		]}`))
+	})
+	var body string
+	testMux.HandleFunc(path+"/123.45.67.89", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json; charset=utf8")
+		if r.Method == "PUT" {
+			b, _ := ioutil.ReadAll(r.Body)
+			body = string(b)
+			w.Write([]byte(`{"results": {"message": "Updated IP."}}`))
+			return
+		}
+		w.Write([]byte(`{"results": {"external_ip": "123.45.67.89", "hostname": "mta472a.sparkpostmail.com",
+			"ip_pool": "default", "auto_warmup_enabled": true, "auto_warmup_stage": 5}}`))
+	})
+
+	list, res, err := testClient.SendingIPs()
+	if err != nil {
+		testFailVerbose(t, res, "SendingIPs returned error: %v", err)
+	}
+	if len(list) != 2 || list[1].IPPool != "marketing" || list[1].AutoWarmupEnabled {
+		t.Errorf("SendingIPs returned %+v", list)
+	}
+
+	ip, res, err := testClient.SendingIP("123.45.67.89")
+	if err != nil {
+		testFailVerbose(t, res, "SendingIP returned error: %v", err)
+	}
+	if ip == nil || !ip.AutoWarmupEnabled || ip.AutoWarmupStage != 5 {
+		t.Fatalf("SendingIP returned %+v", ip)
+	}
+
+	ip.IPPool = "marketing"
+	ip.AutoWarmupEnabled = false
+	if res, err = testClient.SendingIPUpdate(ip); err != nil {
+		testFailVerbose(t, res, "SendingIPUpdate returned error: %v", err)
+	}
+	if body != `{"ip_pool":"marketing","auto_warmup_enabled":false}` {
+		t.Errorf("SendingIPUpdate sent %s", body)
+	}
+
+	if _, err = testClient.SendingIPUpdate(&SendingIP{IPPool: "marketing"}); err == nil {
+		t.Error("SendingIPUpdate accepted a blank IP")
+	}
+}
+
+func TestSendingIP_notFound(t *testing.T) {
+	testSetup(t)
+	defer testTeardown()
+
+	// set up the response handler
+	path := fmt.Sprintf(sendingIPsPathFormat, testClient.Config.ApiVersion)
+	testMux.HandleFunc(path+"/10.0.0.1", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json; charset=utf8")
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"errors": [{"message": "resource not found", "code": "1600"}]}`))
+	})
+
+	ip, _, err := testClient.SendingIP("10.0.0.1")
+	if ip != nil || !errors.Is(err, ErrNotFound) {
+		t.Errorf("SendingIP returned %+v, %v; expected ErrNotFound", ip, err)
+	}
+}
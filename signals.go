@@ -0,0 +1,148 @@
+package gosparkpost
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// https://developers.sparkpost.com/api/#/reference/signals
+var signalsPathFormat = "/api/v%d/signals"
+
+// SignalsDateLayout is the format of the dates Signals endpoints accept and return.
+const SignalsDateLayout = "2006-01-02"
+
+// Facets Signals results can be broken down by, see SignalsParams.Facet.
+const (
+	SignalsByCampaign      = "campaign-id"
+	SignalsByIPPool        = "ip-pool"
+	SignalsBySendingDomain = "sending-domain"
+	SignalsBySendingIP     = "sending-ip"
+	SignalsBySubaccount    = "subaccount"
+)
+
+// SignalsParams holds the query parameters shared by the Signals endpoints.
+// Zero values are omitted from the query; the API defaults to the last 7 days.
+type SignalsParams struct {
+	// From and To are the first and last days of the history, in UTC.
+	From time.Time
+	To   time.Time
+
+	// Facet breaks the results down, and is one of the SignalsBy constants.
+	// Without it, a single result covers all mail.
+	Facet string
+	// Filter restricts the results to the facet values containing it.
+	Filter      string
+	Subaccounts []int
+
+	Limit   int
+	Offset  int
+	OrderBy string
+}
+
+func (p *SignalsParams) params() *Params {
+	params := NewParams()
+	if p == nil {
+		return params
+	}
+
+	for key, t := range map[string]time.Time{"from": p.From, "to": p.To} {
+		if !t.IsZero() {
+			params.Set(key, t.UTC().Format(SignalsDateLayout))
+		}
+	}
+	subaccounts := make([]string, len(p.Subaccounts))
+	for i, id := range p.Subaccounts {
+		subaccounts[i] = strconv.Itoa(id)
+	}
+	params.Set("filter", p.Filter).
+		List("subaccounts", subaccounts).
+		Set("order_by", p.OrderBy)
+	if p.Limit > 0 {
+		params.Int("limit", p.Limit)
+	}
+	if p.Offset > 0 {
+		params.Int("offset", p.Offset)
+	}
+	return params
+}
+
+// SignalsFacet identifies what a Signals result covers. Only the field matching
+// SignalsParams.Facet is set.
+type SignalsFacet struct {
+	CampaignID    string `json:"campaign_id,omitempty"`
+	IPPool        string `json:"ip_pool,omitempty"`
+	SendingDomain string `json:"sending_domain,omitempty"`
+	SendingIP     string `json:"sending_ip,omitempty"`
+	SubaccountID  int    `json:"sid,omitempty"`
+}
+
+// SpamTrapHits holds the spam trap hits of one facet value, a sign of a poorly maintained list.
+type SpamTrapHits struct {
+	SignalsFacet
+	CurrentTrapHits         int               `json:"current_trap_hits"`
+	CurrentRelativeTrapHits float64           `json:"current_relative_trap_hits"`
+	CurrentInjections       int               `json:"current_injections"`
+	History                 []SpamTrapHitsDay `json:"history"`
+}
+
+// SpamTrapHitsDay holds one day of SpamTrapHits.History.
+type SpamTrapHitsDay struct {
+	Date     string `json:"dt"`
+	TrapHits int    `json:"trap_hits"`
+	// RelativeTrapHits is the percentage of Injections which hit a spam trap.
+	RelativeTrapHits float64 `json:"relative_trap_hits"`
+	Injections       int     `json:"injections"`
+}
+
+// EngagementRecency holds the recipients of one facet value, grouped by the last time they engaged.
+type EngagementRecency struct {
+	SignalsFacet
+	History []EngagementRecencyDay `json:"history"`
+}
+
+// EngagementRecencyDay holds one day of EngagementRecency.History.
+type EngagementRecencyDay struct {
+	Date string `json:"dt"`
+	// Total is the number of recipients sent mail that day. The others count those
+	// which are new, never engaged, or last engaged within 14, 90 or 365 days.
+	Total          int `json:"c_total"`
+	New            int `json:"c_new"`
+	Unengaged      int `json:"c_uneng"`
+	Engaged14Days  int `json:"c_14d"`
+	Engaged90Days  int `json:"c_90d"`
+	Engaged365Days int `json:"c_365d"`
+}
+
+// SpamTrapHits returns the daily spam trap hits of mail matching p.
+// The number of facet values is in Response.TotalCount, for paging with p.Offset.
+func (c *Client) SpamTrapHits(p *SignalsParams) ([]*SpamTrapHits, *Response, error) {
+	var list []*SpamTrapHits
+	res, err := c.signals("spam-hits", p, &list)
+	return list, res, err
+}
+
+// EngagementRecency returns the daily engagement recency cohorts of recipients of mail matching p.
+// The number of facet values is in Response.TotalCount, for paging with p.Offset.
+func (c *Client) EngagementRecency(p *SignalsParams) ([]*EngagementRecency, *Response, error) {
+	var list []*EngagementRecency
+	res, err := c.signals("eng-recency", p, &list)
+	return list, res, err
+}
+
+func (c *Client) signals(extraPath string, p *SignalsParams, v interface{}) (*Response, error) {
+	path := fmt.Sprintf("%s/%s", fmt.Sprintf(signalsPathFormat, c.Config.ApiVersion), extraPath)
+	if p != nil && p.Facet != "" {
+		found := false
+		for _, f := range []string{SignalsByCampaign, SignalsByIPPool, SignalsBySendingDomain, SignalsBySendingIP, SignalsBySubaccount} {
+			if p.Facet == f {
+				found = true
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("Invalid signals facet [%s]", p.Facet)
+		}
+		path = fmt.Sprintf("%s/%s", path, p.Facet)
+	}
+	return c.metricsGet(path, p.params(), v)
+}
@@ -0,0 +1,90 @@
+package gosparkpost
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestSpamTrapHits(t *testing.T) {
+	testSetup(t)
+	defer testTeardown()
+
+	// set up the response handler
+	var query url.Values
+	path := fmt.Sprintf(signalsPathFormat, testClient.Config.ApiVersion)
+	testMux.HandleFunc(path+"/spam-hits/sending-domain", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		query = r.URL.Query()
+		w.Header().Set("Content-Type", "application/json; charset=utf8")
+		w.Write([]byte(`{"results": [{
+			"sending_domain": "mail.example.com",
+			"current_trap_hits": 3, "current_relative_trap_hits": 0.3, "current_injections": 1000,
+			"history": [
+				{"dt": "2018-03-01", "trap_hits": 1, "relative_trap_hits": 0.1, "injections": 1000},
+				{"dt": "2018-03-02", "trap_hits": 3, "relative_trap_hits": 0.3, "injections": 1000}
+			]
+		}], "total_count": 12}`))
+	})
+
+	hits, res, err := testClient.SpamTrapHits(&SignalsParams{
+		From:        time.Date(2018, 3, 1, 0, 0, 0, 0, time.UTC),
+		To:          time.Date(2018, 3, 2, 0, 0, 0, 0, time.UTC),
+		Facet:       SignalsBySendingDomain,
+		Filter:      "example.com",
+		Subaccounts: []int{0, 101},
+		Limit:       1,
+		Offset:      2,
+	})
+	if err != nil {
+		testFailVerbose(t, res, "SpamTrapHits returned error: %v", err)
+	}
+	if query.Encode() != "filter=example.com&from=2018-03-01&limit=1&offset=2&subaccounts=0%2C101&to=2018-03-02" {
+		t.Errorf("query was %s", query.Encode())
+	}
+	if res.TotalCount != 12 || len(hits) != 1 {
+		t.Fatalf("SpamTrapHits returned %d (of %d) results", len(hits), res.TotalCount)
+	}
+	h := hits[0]
+	if h.SendingDomain != "mail.example.com" || h.CurrentTrapHits != 3 || len(h.History) != 2 || h.History[1].RelativeTrapHits != 0.3 {
+		t.Errorf("SpamTrapHits returned %+v", h)
+	}
+
+	if _, _, err = testClient.SpamTrapHits(&SignalsParams{Facet: "domain"}); err == nil {
+		t.Error("SpamTrapHits accepted an unknown facet")
+	}
+}
+
+func TestEngagementRecency(t *testing.T) {
+	testSetup(t)
+	defer testTeardown()
+
+	// set up the response handler
+	var query string
+	path := fmt.Sprintf(signalsPathFormat, testClient.Config.ApiVersion)
+	testMux.HandleFunc(path+"/eng-recency", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		query = r.URL.RawQuery
+		w.Header().Set("Content-Type", "application/json; charset=utf8")
+		w.Write([]byte(`{"results": [{"history": [
+			{"dt": "2018-03-01", "c_total": 1000, "c_new": 50, "c_uneng": 200, "c_14d": 500, "c_90d": 150, "c_365d": 100}
+		]}], "total_count": 1}`))
+	})
+
+	cohorts, res, err := testClient.EngagementRecency(nil)
+	if err != nil {
+		testFailVerbose(t, res, "EngagementRecency returned error: %v", err)
+	}
+	if query != "" {
+		t.Errorf("query was %q", query)
+	}
+	if len(cohorts) != 1 || len(cohorts[0].History) != 1 {
+		t.Fatalf("EngagementRecency returned %+v", cohorts)
+	}
+	day := cohorts[0].History[0]
+	if day.Date != "2018-03-01" || day.Total != 1000 || day.Unengaged != 200 || day.Engaged14Days != 500 {
+		t.Errorf("EngagementRecency returned %+v", day)
+	}
+}
@@ -0,0 +1,114 @@
+// Package smtp sends mail through the SparkPost SMTP relay, for senders which can't use the
+// Transmissions API. Settings SparkPost applies to each message are passed in the X-MSYS-API
+// header, which is built from the same types the Transmissions API uses.
+package smtp
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	netsmtp "net/smtp"
+	"strconv"
+
+	sp "github.com/SparkPost/gosparkpost"
+)
+
+// Relay hosts for each SparkPost region, which accept mail on Port.
+const (
+	Host   = "smtp.sparkpostmail.com"
+	EUHost = "smtp.eu.sparkpostmail.com"
+	Port   = 587
+)
+
+// Username is the SMTP username SparkPost expects; the password is an API key
+// with the smtp/inject grant.
+const Username = "SMTP_Injection"
+
+// HeaderName is the name of the header holding an APIHeader.
+const HeaderName = "X-MSYS-API"
+
+// maxHeaderLine is the longest line RFC 5322 allows, including the header name.
+const maxHeaderLine = 998
+
+// APIHeader is the JSON structure of the X-MSYS-API header.
+type APIHeader struct {
+	CampaignID string        `json:"campaign_id,omitempty"`
+	Metadata   interface{}   `json:"metadata,omitempty"`
+	Tags       []string      `json:"tags,omitempty"`
+	Options    *sp.TxOptions `json:"options,omitempty"`
+}
+
+// NewHeader returns an APIHeader with the campaign, metadata and options of t.
+// Its recipients and content don't apply, since they're part of the SMTP session.
+func NewHeader(t *sp.Transmission) *APIHeader {
+	if t == nil {
+		return &APIHeader{}
+	}
+	return &APIHeader{CampaignID: t.CampaignID, Metadata: t.Metadata, Options: t.Options}
+}
+
+// Value returns the header value, which must fit on a single header line.
+func (h *APIHeader) Value() (string, error) {
+	if h.Options != nil && h.Options.StartTime != nil {
+		return "", fmt.Errorf("%s doesn't support Options.StartTime", HeaderName)
+	}
+
+	value, err := json.Marshal(h)
+	if err != nil {
+		return "", err
+	}
+	if len(HeaderName)+2+len(value) > maxHeaderLine {
+		return "", fmt.Errorf("%s header is %d bytes, longer than a header line may be", HeaderName, len(value))
+	}
+	return string(value), nil
+}
+
+// Client sends messages to the SparkPost SMTP relay.
+type Client struct {
+	// Addr is the host:port of the relay, Host on Port by default.
+	Addr string
+	// ApiKey needs the smtp/inject grant.
+	ApiKey string
+
+	// sendMail is net/smtp.SendMail, which tests replace.
+	sendMail func(addr string, a netsmtp.Auth, from string, to []string, msg []byte) error
+}
+
+// NewClient returns a Client using the US relay.
+func NewClient(apiKey string) *Client {
+	return &Client{ApiKey: apiKey}
+}
+
+// Send sends msg, a complete RFC 5322 message, from the envelope sender from to each of to.
+// If h is non-nil, it's added to the top of the message as the X-MSYS-API header.
+// The connection is upgraded with STARTTLS before the API key is sent.
+func (c *Client) Send(from string, to []string, msg []byte, h *APIHeader) error {
+	if c.ApiKey == "" {
+		return fmt.Errorf("smtp Client requires an ApiKey")
+	} else if len(to) == 0 {
+		return fmt.Errorf("Send called with no recipients")
+	}
+
+	if h != nil {
+		value, err := h.Value()
+		if err != nil {
+			return err
+		}
+		msg = append([]byte(HeaderName+": "+value+"\r\n"), msg...)
+	}
+
+	addr := c.Addr
+	if addr == "" {
+		addr = net.JoinHostPort(Host, strconv.Itoa(Port))
+	}
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return err
+	}
+
+	send := c.sendMail
+	if send == nil {
+		send = netsmtp.SendMail
+	}
+	return send(addr, netsmtp.PlainAuth("", Username, c.ApiKey, host), from, to, msg)
+}
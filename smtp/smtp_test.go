@@ -0,0 +1,91 @@
+package smtp
+
+import (
+	"net/mail"
+	netsmtp "net/smtp"
+	"strings"
+	"testing"
+
+	sp "github.com/SparkPost/gosparkpost"
+)
+
+func TestNewHeader(t *testing.T) {
+	h := NewHeader(&sp.Transmission{
+		CampaignID: "spring sale",
+		Metadata:   map[string]string{"user_id": "1234"},
+		Options: &sp.TxOptions{
+			TmplOptions: sp.TmplOptions{OpenTracking: true, Transactional: true},
+			InlineCSS:   true,
+		},
+		Recipients: []string{"ignored@example.com"},
+	})
+	h.Tags = []string{"promo"}
+
+	value, err := h.Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value != `{"campaign_id":"spring sale","metadata":{"user_id":"1234"},"tags":["promo"],"options":{"open_tracking":true,"transactional":true,"inline_css":true}}` {
+		t.Errorf("header was %s", value)
+	}
+
+	if value, err = NewHeader(nil).Value(); err != nil || value != "{}" {
+		t.Errorf("empty header was %q (%v)", value, err)
+	}
+
+	h.Options.StartTime = sp.StartNow()
+	if _, err = h.Value(); err == nil {
+		t.Error("Value accepted a StartTime")
+	}
+
+	h = &APIHeader{Metadata: map[string]string{"blob": strings.Repeat("x", maxHeaderLine)}}
+	if _, err = h.Value(); err == nil {
+		t.Error("Value accepted a header longer than a line")
+	}
+}
+
+func TestSend(t *testing.T) {
+	var gotAddr, gotFrom string
+	var gotTo []string
+	var gotMsg []byte
+	c := NewClient("0123456789abcdef")
+	c.sendMail = func(addr string, a netsmtp.Auth, from string, to []string, msg []byte) error {
+		gotAddr, gotFrom, gotTo, gotMsg = addr, from, to, msg
+		return nil
+	}
+
+	msg := "From: sender@example.com\r\nTo: rcpt@example.com\r\nSubject: Hi\r\n\r\nHello\r\n"
+	err := c.Send("bounces@example.com", []string{"rcpt@example.com"}, []byte(msg), &APIHeader{CampaignID: "welcome"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gotAddr != "smtp.sparkpostmail.com:587" || gotFrom != "bounces@example.com" || len(gotTo) != 1 {
+		t.Errorf("sent to %s from %s for %v", gotAddr, gotFrom, gotTo)
+	}
+	m, err := mail.ReadMessage(strings.NewReader(string(gotMsg)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := m.Header.Get(HeaderName); v != `{"campaign_id":"welcome"}` {
+		t.Errorf("%s header was %q", HeaderName, v)
+	}
+	if m.Header.Get("Subject") != "Hi" {
+		t.Errorf("message headers were %v", m.Header)
+	}
+
+	// without a header, the message is sent as is
+	c.Addr = EUHost + ":2525"
+	if err = c.Send("bounces@example.com", []string{"rcpt@example.com"}, []byte(msg), nil); err != nil {
+		t.Fatal(err)
+	}
+	if gotAddr != "smtp.eu.sparkpostmail.com:2525" || string(gotMsg) != msg {
+		t.Errorf("sent %q to %s", gotMsg, gotAddr)
+	}
+
+	if err = c.Send("bounces@example.com", nil, []byte(msg), nil); err == nil {
+		t.Error("Send accepted no recipients")
+	}
+	if err = (&Client{}).Send("bounces@example.com", gotTo, []byte(msg), nil); err == nil {
+		t.Error("Send accepted a Client without an ApiKey")
+	}
+}
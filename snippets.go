@@ -0,0 +1,161 @@
+package gosparkpost
+
+import (
+	"context"
+	"fmt"
+)
+
+// https://developers.sparkpost.com/api/#/reference/snippets
+var snippetsPathFormat = "/api/v%d/snippets"
+
+// Snippet is the JSON structure accepted by and returned from the SparkPost Snippets API.
+// Templates and transmissions include a snippet with {{ render_snippet( "id" ) }}.
+type Snippet struct {
+	ID                    string         `json:"id,omitempty"`
+	Name                  string         `json:"name,omitempty"`
+	Content               SnippetContent `json:"content"`
+	SharedWithSubaccounts bool           `json:"shared_with_subaccounts"`
+	CreatedAt             string         `json:"created_at,omitempty"`
+	LastUpdateTime        string         `json:"last_update_time,omitempty"`
+	SubaccountID          int            `json:"subaccount_id,omitempty"`
+}
+
+// SnippetContent holds the parts of a Snippet. At least one of them is required.
+type SnippetContent struct {
+	HTML    string `json:"html,omitempty"`
+	Text    string `json:"text,omitempty"`
+	AMPHTML string `json:"amp_html,omitempty"`
+}
+
+// SnippetResults is the "results" object returned when a Snippet is created.
+type SnippetResults struct {
+	ID string `json:"id"`
+}
+
+// Validate runs sanity checks on a Snippet struct.
+// This should catch most errors before attempting a doomed API call.
+func (s *Snippet) Validate() error {
+	if s == nil {
+		return fmt.Errorf("Can't Validate a nil Snippet")
+	}
+
+	// enforce required parameters
+	if s.ID == "" {
+		return fmt.Errorf("Snippet requires a non-empty ID")
+	} else if s.Content.HTML == "" && s.Content.Text == "" && s.Content.AMPHTML == "" {
+		return fmt.Errorf("Snippet requires HTML, Text or AMPHTML content")
+	}
+
+	// enforce max lengths
+	if len(s.ID) > 64 {
+		return fmt.Errorf("Snippet id may not be longer than 64 bytes")
+	} else if len(s.Name) > 1024 {
+		return fmt.Errorf("Snippet name may not be longer than 1024 bytes")
+	}
+
+	return nil
+}
+
+// Create accepts a populated Snippet object, validates it,
+// and performs an API call against the configured endpoint.
+func (c *Client) SnippetCreate(s *Snippet) (id string, res *Response, err error) {
+	if s == nil {
+		err = fmt.Errorf("Create called with nil Snippet")
+		return
+	}
+
+	err = s.Validate()
+	if err != nil {
+		return
+	}
+
+	path := fmt.Sprintf(snippetsPathFormat, c.Config.ApiVersion)
+	url := fmt.Sprintf("%s%s", c.Config.BaseUrl, path)
+	res, err = c.doJSON(context.Background(), "POST", url, s, "Snippet", "create")
+	if res.conflict() {
+		// handle snippet-specific ones
+		err = &prettyError{msg: fmt.Sprintf("Snippet with id [%s] already exists", s.ID), cause: res.SPError()}
+		return
+	}
+	if err != nil {
+		return
+	}
+
+	var results SnippetResults
+	if err = res.DecodeResults(&results); err != nil {
+		return id, res, err
+	}
+	id = results.ID
+	if id == "" {
+		err = res.unexpected("Unexpected response to Snippet creation")
+	}
+
+	return
+}
+
+// Update updates the Snippet with the specified id.
+// All fields are sent, so SharedWithSubaccounts is cleared unless it's set.
+func (c *Client) SnippetUpdate(s *Snippet) (res *Response, err error) {
+	err = s.Validate()
+	if err != nil {
+		return
+	}
+
+	// the id is in the path, and the timestamps can't be changed
+	body := *s
+	body.ID, body.CreatedAt, body.LastUpdateTime, body.SubaccountID = "", "", "", 0
+
+	path := fmt.Sprintf(snippetsPathFormat, c.Config.ApiVersion)
+	url := fmt.Sprintf("%s%s/%s", c.Config.BaseUrl, path, s.ID)
+	res, err = c.doJSON(context.Background(), "PUT", url, body, "Snippet", "update")
+	return
+}
+
+// List returns metadata for all Snippets in the system. Their Content is empty.
+func (c *Client) Snippets() ([]Snippet, *Response, error) {
+	path := fmt.Sprintf(snippetsPathFormat, c.Config.ApiVersion)
+	url := fmt.Sprintf("%s%s", c.Config.BaseUrl, path)
+	res, err := c.doJSON(context.Background(), "GET", url, nil, "Snippet", "list")
+	if err != nil {
+		return nil, res, err
+	}
+
+	var list []Snippet
+	if err = res.DecodeResults(&list); err != nil {
+		return nil, res, err
+	}
+	return list, res, nil
+}
+
+// Snippet returns the Snippet with the specified id.
+func (c *Client) Snippet(id string) (*Snippet, *Response, error) {
+	if id == "" {
+		return nil, nil, fmt.Errorf("Retrieve called with blank id")
+	}
+
+	path := fmt.Sprintf(snippetsPathFormat, c.Config.ApiVersion)
+	url := fmt.Sprintf("%s%s/%s", c.Config.BaseUrl, path, id)
+	res, err := c.doJSON(context.Background(), "GET", url, nil, "Snippet", "retrieve")
+	if err != nil {
+		return nil, res, err
+	}
+
+	s := &Snippet{}
+	if err = res.DecodeResults(s); err != nil {
+		return nil, res, err
+	}
+	return s, res, nil
+}
+
+// Delete removes the Snippet with the specified id.
+func (c *Client) SnippetDelete(id string) (res *Response, err error) {
+	if id == "" {
+		err = fmt.Errorf("Delete called with blank id")
+		return
+	}
+
+	path := fmt.Sprintf(snippetsPathFormat, c.Config.ApiVersion)
+	url := fmt.Sprintf("%s%s/%s", c.Config.BaseUrl, path, id)
+	res, err = c.doJSON(context.Background(), "DELETE", url, nil, "Snippet", "delete")
+	return
+}
@@ -0,0 +1,130 @@
+package gosparkpost
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+)
+
+func TestSnippets(t *testing.T) {
+	testSetup(t)
+	defer testTeardown()
+
+	// set up the response handlers
+	path := fmt.Sprintf(snippetsPathFormat, testClient.Config.ApiVersion)
+	var created Snippet
+	testMux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json; charset=utf8")
+		switch r.Method {
+		case "POST":
+			json.NewDecoder(r.Body).Decode(&created)
+			w.Write([]byte(`{"results": {"id": "footer"}}`))
+		case "GET":
+			w.Write([]byte(`{"results": [{"id": "footer", "name": "Footer", "shared_with_subaccounts": true,
+				"created_at": "2017-08-10T14:15:16+00:00", "last_update_time": "2017-08-10T14:15:16+00:00"}]}`))
+		default:
+			t.Errorf("unexpected method %s", r.Method)
+		}
+	})
+	var methods []string
+	var updated string
+	testMux.HandleFunc(path+"/footer", func(w http.ResponseWriter, r *http.Request) {
+		methods = append(methods, r.Method)
+		w.Header().Set("Content-Type", "application/json; charset=utf8")
+		switch r.Method {
+		case "GET":
+			w.Write([]byte(`{"results": {"id": "footer", "name": "Footer", "shared_with_subaccounts": true,
+				"content": {"html": "<p>Unsubscribe</p>", "text": "Unsubscribe"},
+				"created_at": "2017-08-10T14:15:16+00:00", "last_update_time": "2017-08-10T14:15:16+00:00"}}`))
+		case "PUT":
+			b, _ := ioutil.ReadAll(r.Body)
+			updated = string(b)
+			w.Write([]byte(`{"results": {"id": "footer"}}`))
+		case "DELETE":
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+
+	s := &Snippet{
+		ID:                    "footer",
+		Name:                  "Footer",
+		Content:               SnippetContent{HTML: "<p>Unsubscribe</p>", Text: "Unsubscribe"},
+		SharedWithSubaccounts: true,
+	}
+	id, res, err := testClient.SnippetCreate(s)
+	if err != nil {
+		testFailVerbose(t, res, "SnippetCreate returned error: %v", err)
+	}
+	if id != "footer" || created.Content.Text != "Unsubscribe" || !created.SharedWithSubaccounts {
+		t.Errorf("SnippetCreate returned %q; server saw %+v", id, created)
+	}
+
+	list, res, err := testClient.Snippets()
+	if err != nil {
+		testFailVerbose(t, res, "Snippets returned error: %v", err)
+	}
+	if len(list) != 1 || list[0].Name != "Footer" || !list[0].SharedWithSubaccounts {
+		t.Errorf("Snippets returned %+v", list)
+	}
+
+	got, res, err := testClient.Snippet("footer")
+	if err != nil {
+		testFailVerbose(t, res, "Snippet returned error: %v", err)
+	}
+	if got == nil || got.Content.HTML != "<p>Unsubscribe</p>" || got.CreatedAt == "" {
+		t.Fatalf("Snippet returned %+v", got)
+	}
+
+	got.SharedWithSubaccounts = false
+	if res, err = testClient.SnippetUpdate(got); err != nil {
+		testFailVerbose(t, res, "SnippetUpdate returned error: %v", err)
+	}
+	if updated != `{"name":"Footer","content":{"html":"\u003cp\u003eUnsubscribe\u003c/p\u003e","text":"Unsubscribe"},"shared_with_subaccounts":false}` {
+		t.Errorf("SnippetUpdate sent %s", updated)
+	}
+	if got.ID != "footer" || got.CreatedAt == "" {
+		t.Errorf("SnippetUpdate modified its argument: %+v", got)
+	}
+
+	if res, err = testClient.SnippetDelete("footer"); err != nil {
+		testFailVerbose(t, res, "SnippetDelete returned error: %v", err)
+	}
+	if fmt.Sprint(methods) != "[GET PUT DELETE]" {
+		t.Errorf("server saw methods %v", methods)
+	}
+}
+
+func TestSnippetCreate_errors(t *testing.T) {
+	testSetup(t)
+	defer testTeardown()
+
+	// set up the response handler
+	path := fmt.Sprintf(snippetsPathFormat, testClient.Config.ApiVersion)
+	testMux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json; charset=utf8")
+		w.WriteHeader(http.StatusConflict)
+		w.Write([]byte(`{"errors": [{"message": "resource conflict", "code": "1602"}]}`))
+	})
+
+	for _, s := range []*Snippet{
+		nil,
+		{Content: SnippetContent{Text: "Unsubscribe"}},
+		{ID: "footer"},
+	} {
+		if _, _, err := testClient.SnippetCreate(s); err == nil {
+			t.Errorf("SnippetCreate accepted %+v", s)
+		}
+	}
+
+	_, _, err := testClient.SnippetCreate(&Snippet{ID: "footer", Content: SnippetContent{Text: "Unsubscribe"}})
+	var spErr *SPError
+	if !errors.As(err, &spErr) || spErr.StatusCode != 409 {
+		t.Errorf("SnippetCreate returned %v, expected a 409 SPError", err)
+	}
+	if err.Error() != "Snippet with id [footer] already exists" {
+		t.Errorf("SnippetCreate returned %q", err)
+	}
+}
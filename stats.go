@@ -0,0 +1,69 @@
+package gosparkpost
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// RequestStats describes a single API request, as passed to a StatsHook.
+type RequestStats struct {
+	Method string
+	// Endpoint is a low-cardinality label for the API being called, for example "transmissions".
+	Endpoint string
+	// StatusCode is zero if no response was received.
+	StatusCode int
+	// Duration is the time until response headers were received, see also Response.Duration.
+	Duration time.Duration
+	// Attempts is the number of times the request was sent, see Response.Attempts.
+	Attempts int
+	Err      error
+	// CorrelationID is the correlation ID the request was sent with, see Client.WithCorrelationID.
+	CorrelationID string
+}
+
+// StatsHook is called after every API request made by a Client,
+// which makes it easy to export request counts and latencies.
+type StatsHook interface {
+	RequestDone(RequestStats)
+}
+
+// StatsFunc allows an ordinary function to be used as a StatsHook.
+type StatsFunc func(RequestStats)
+
+// RequestDone calls f(s).
+func (f StatsFunc) RequestDone(s RequestStats) {
+	f(s)
+}
+
+// Tracer starts a Span for every API request made by a Client.
+// It's shaped so that an OpenTelemetry tracer can be adapted in a few lines:
+// start a span from req.Context(), inject the propagation headers into req.Header,
+// and set the span's attributes and status in End.
+type Tracer interface {
+	StartSpan(req *http.Request, endpoint string) Span
+}
+
+// Span is a single traced API request. End is passed the request's status code,
+// duration and attempt count, to set as the span's attributes.
+type Span interface {
+	End(RequestStats)
+}
+
+// endpointLabel returns the first path segment after the API version,
+// so ids and query strings don't end up in metric labels.
+func endpointLabel(urlStr string) string {
+	u, err := url.Parse(urlStr)
+	if err != nil {
+		return "unknown"
+	}
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(parts) >= 3 && parts[0] == "api" && strings.HasPrefix(parts[1], "v") {
+		return parts[2]
+	}
+	if parts[0] == "" {
+		return "unknown"
+	}
+	return parts[0]
+}
@@ -0,0 +1,102 @@
+package gosparkpost
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestStats_requestDone(t *testing.T) {
+	testSetup(t)
+	defer testTeardown()
+
+	var seen []RequestStats
+	testClient.Config.Stats = StatsFunc(func(s RequestStats) {
+		seen = append(seen, s)
+	})
+
+	path := fmt.Sprintf(transmissionsPathFormat, testClient.Config.ApiVersion)
+	testMux.HandleFunc(path+"/12345", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json; charset=utf8")
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"errors":[{"message":"resource not found"}]}`))
+	})
+
+	testClient.Transmission("12345")
+
+	if len(seen) != 1 {
+		t.Fatalf("stats hook called %d times, expected 1", len(seen))
+	}
+	s := seen[0]
+	if s.Method != "GET" || s.Endpoint != "transmissions" || s.StatusCode != 404 || s.Attempts != 1 || s.Err != nil {
+		t.Errorf("unexpected stats: %+v", s)
+	}
+}
+
+type testTracer struct {
+	spans []*testSpan
+}
+
+type testSpan struct {
+	endpoint string
+	stats    *RequestStats
+}
+
+func (tr *testTracer) StartSpan(req *http.Request, endpoint string) Span {
+	req.Header.Set("Traceparent", "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01")
+	span := &testSpan{endpoint: endpoint}
+	tr.spans = append(tr.spans, span)
+	return span
+}
+
+func (s *testSpan) End(stats RequestStats) {
+	s.stats = &stats
+}
+
+func TestTracer_span(t *testing.T) {
+	testSetup(t)
+	defer testTeardown()
+
+	tracer := &testTracer{}
+	testClient.Config.Tracer = tracer
+
+	path := fmt.Sprintf(templatesPathFormat, testClient.Config.ApiVersion)
+	testMux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Traceparent") == "" {
+			t.Error("trace headers weren't propagated")
+		}
+		w.Header().Set("Content-Type", "application/json; charset=utf8")
+		w.Write([]byte(`{"results":[]}`))
+	})
+
+	if _, res, err := testClient.Templates(); err != nil {
+		testFailVerbose(t, res, "Templates returned error: %v", err)
+	}
+
+	if len(tracer.spans) != 1 {
+		t.Fatalf("%d spans started, expected 1", len(tracer.spans))
+	}
+	span := tracer.spans[0]
+	if span.endpoint != "templates" {
+		t.Errorf("span endpoint is %q, expected templates", span.endpoint)
+	}
+	if span.stats == nil {
+		t.Fatal("span wasn't ended")
+	} else if span.stats.StatusCode != 200 || span.stats.Attempts != 1 {
+		t.Errorf("unexpected span stats: %+v", *span.stats)
+	}
+}
+
+func TestEndpointLabel(t *testing.T) {
+	for in, out := range map[string]string{
+		"https://api.sparkpost.com/api/v1/transmissions/123":             "transmissions",
+		"https://api.sparkpost.com/api/v1/metrics/deliverability?from=x": "metrics",
+		"https://api.sparkpost.com/api/v1/suppression-list/a%40b.com":    "suppression-list",
+		"https://api.sparkpost.com/":                                     "unknown",
+		"https://api.sparkpost.com/other":                                "other",
+	} {
+		if got := endpointLabel(in); got != out {
+			t.Errorf("endpointLabel(%q) = %q, expected %q", in, got, out)
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package gosparkpost
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// EachResult decodes the "results" array of a JSON response one element at a time,
+// calling fn with each element as it's parsed, so large responses don't have to be
+// held in memory all at once. Any "errors" are decoded into Response.Errors, and other
+// top-level keys are skipped. Response.Body isn't populated.
+// If fn returns an error, decoding stops and that error is returned.
+func (r *Response) EachResult(fn func(json.RawMessage) error) error {
+	return r.eachEnvelope(func(dec *json.Decoder) error {
+		return eachElement(dec, fn)
+	})
+}
+
+// eachResultsField is like EachResult for responses whose "results" is an object:
+// the array under field is streamed to fn, and the remaining keys of the object
+// are returned so the caller can decode them.
+func (r *Response) eachResultsField(field string, fn func(json.RawMessage) error) (map[string]json.RawMessage, error) {
+	rest := map[string]json.RawMessage{}
+	err := r.eachEnvelope(func(dec *json.Decoder) error {
+		if err := expectDelim(dec, '{'); err != nil {
+			return err
+		}
+		for dec.More() {
+			tok, err := dec.Token()
+			if err != nil {
+				return err
+			}
+			key, _ := tok.(string)
+
+			if key == field {
+				err = eachElement(dec, fn)
+			} else {
+				var raw json.RawMessage
+				err = dec.Decode(&raw)
+				rest[key] = raw
+			}
+			if err != nil {
+				return err
+			}
+		}
+		return expectDelim(dec, '}')
+	})
+	return rest, err
+}
+
+// eachEnvelope walks the top-level keys of a JSON response, handing the decoder
+// to results when the "results" key is reached.
+func (r *Response) eachEnvelope(results func(*json.Decoder) error) error {
+	if r.HTTP == nil {
+		return fmt.Errorf("EachResult got nil http.Response")
+	}
+	defer r.HTTP.Body.Close()
+
+	dec := json.NewDecoder(r.HTTP.Body)
+	if err := expectDelim(dec, '{'); err != nil {
+		return err
+	}
+
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		key, _ := tok.(string)
+
+		switch key {
+		case "results":
+			if err = results(dec); err != nil {
+				return err
+			}
+
+		case "errors":
+			if err = dec.Decode(&r.Errors); err != nil {
+				return err
+			}
+
+		default:
+			var skip json.RawMessage
+			if err = dec.Decode(&skip); err != nil {
+				return err
+			}
+		}
+	}
+
+	return expectDelim(dec, '}')
+}
+
+// eachElement calls fn for each element of the array at the decoder's position.
+// A null array has no elements.
+func eachElement(dec *json.Decoder, fn func(json.RawMessage) error) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if tok == nil {
+		return nil
+	}
+	if d, ok := tok.(json.Delim); !ok || d != '[' {
+		return fmt.Errorf("Failed to parse API response: expected [%s], got [%v]", json.Delim('['), tok)
+	}
+
+	for dec.More() {
+		var raw json.RawMessage
+		if err = dec.Decode(&raw); err != nil {
+			return err
+		}
+		if err = fn(raw); err != nil {
+			return err
+		}
+	}
+	return expectDelim(dec, ']')
+}
+
+func expectDelim(dec *json.Decoder, want json.Delim) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if d, ok := tok.(json.Delim); !ok || d != want {
+		return fmt.Errorf("Failed to parse API response: expected [%s], got [%v]", want, tok)
+	}
+	return nil
+}
@@ -1,7 +1,7 @@
 package gosparkpost
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
 )
 
@@ -33,6 +33,14 @@ type Subaccount struct {
 	ComplianceStatus string   `json:"compliance_status,omitempty"`
 }
 
+// SubaccountResults is the "results" object returned when a Subaccount is created.
+type SubaccountResults struct {
+	ID       int    `json:"subaccount_id"`
+	Key      string `json:"key"`
+	Label    string `json:"label"`
+	ShortKey string `json:"short_key"`
+}
+
 // Create accepts a populated Subaccount object, validates it,
 // and performs an API call against the configured endpoint.
 func (c *Client) SubaccountCreate(s *Subaccount) (res *Response, err error) {
@@ -58,56 +66,24 @@ func (c *Client) SubaccountCreate(s *Subaccount) (res *Response, err error) {
 		s.Grants = availableGrants
 	}
 
-	jsonBytes, err := json.Marshal(s)
-	if err != nil {
-		return
-	}
-
 	path := fmt.Sprintf(subaccountsPathFormat, c.Config.ApiVersion)
 	url := fmt.Sprintf("%s%s", c.Config.BaseUrl, path)
-	res, err = c.HttpPost(url, jsonBytes)
+	res, err = c.doJSON(context.Background(), "POST", url, s, "Subaccount", "create")
 	if err != nil {
 		return
 	}
 
-	if err = res.AssertJson(); err != nil {
-		return
+	var results SubaccountResults
+	if err = res.DecodeResults(&results); err != nil {
+		return res, err
 	}
-
-	err = res.ParseResponse()
-	if err != nil {
-		return
+	if results.ID == 0 || results.ShortKey == "" {
+		err = res.unexpected("Unexpected response to Subaccount creation")
 	}
-
-	if res.HTTP.StatusCode == 200 {
-		var ok bool
-		var results map[string]interface{}
-		if results, ok = res.Results.(map[string]interface{}); !ok {
-			return res, fmt.Errorf("Unexpected response to Subaccount creation (results)")
-		}
-		f, ok := results["subaccount_id"].(float64)
-		if !ok {
-			err = fmt.Errorf("Unexpected response to Subaccount creation")
-		}
-		s.ID = int(f)
-		s.ShortKey, ok = results["short_key"].(string)
-		if !ok {
-			err = fmt.Errorf("Unexpected response to Subaccount creation")
-		}
-
-	} else if len(res.Errors) > 0 {
-		// handle common errors
-		err = res.PrettyError("Subaccount", "create")
-		if err != nil {
-			return
-		}
-
-		if res.HTTP.StatusCode == 422 { // subaccount syntax error
-			eobj := res.Errors[0]
-			err = fmt.Errorf("%s: %s\n%s", eobj.Code, eobj.Message, eobj.Description)
-		} else { // everything else
-			err = fmt.Errorf("%d: %s", res.HTTP.StatusCode, string(res.Body))
-		}
+	s.ID = results.ID
+	s.ShortKey = results.ShortKey
+	if results.Key != "" {
+		s.Key = results.Key
 	}
 
 	return
@@ -137,44 +113,13 @@ func (c *Client) SubaccountUpdate(s *Subaccount) (res *Response, err error) {
 		return
 	}
 
-	jsonBytes, err := json.Marshal(s)
-	if err != nil {
-		return
-	}
-
-	path := fmt.Sprintf(templatesPathFormat, c.Config.ApiVersion)
-	url := fmt.Sprintf("%s%s/%s", c.Config.BaseUrl, path, s.ID)
-
-	res, err = c.HttpPut(url, jsonBytes)
-	if err != nil {
-		return
-	}
-
-	if err = res.AssertJson(); err != nil {
-		return
-	}
-
-	err = res.ParseResponse()
-	if err != nil {
-		return
-	}
-
-	if res.HTTP.StatusCode == 200 {
-		return
-
-	} else if len(res.Errors) > 0 {
-		// handle common errors
-		err = res.PrettyError("Subaccount", "update")
-		if err != nil {
-			return
-		}
+	path := fmt.Sprintf(subaccountsPathFormat, c.Config.ApiVersion)
+	url := fmt.Sprintf("%s%s/%d", c.Config.BaseUrl, path, s.ID)
 
-		// handle template-specific ones
-		if res.HTTP.StatusCode == 409 {
-			err = fmt.Errorf("Subaccount with id [%s] is in use by msg generation", s.ID)
-		} else { // everything else
-			err = fmt.Errorf("%d: %s", res.HTTP.StatusCode, string(res.Body))
-		}
+	res, err = c.doJSON(context.Background(), "PUT", url, s, "Subaccount", "update")
+	if res.conflict() {
+		// handle subaccount-specific ones
+		err = &prettyError{msg: fmt.Sprintf("Subaccount with id [%d] is in use by msg generation", s.ID), cause: res.SPError()}
 	}
 
 	return
@@ -184,96 +129,26 @@ func (c *Client) SubaccountUpdate(s *Subaccount) (res *Response, err error) {
 func (c *Client) Subaccounts() (subaccounts []Subaccount, res *Response, err error) {
 	path := fmt.Sprintf(subaccountsPathFormat, c.Config.ApiVersion)
 	url := fmt.Sprintf("%s%s", c.Config.BaseUrl, path)
-	res, err = c.HttpGet(url)
-	if err != nil {
-		return
-	}
-
-	err = res.AssertJson()
+	res, err = c.doJSON(context.Background(), "GET", url, nil, "Subaccount", "list")
 	if err != nil {
 		return
 	}
 
-	if res.HTTP.StatusCode == 200 {
-		var body []byte
-		body, err = res.ReadBody()
-		if err != nil {
-			return
-		}
-		slist := map[string][]Subaccount{}
-		err = json.Unmarshal(body, &slist)
-		if err != nil {
-			return
-		} else if list, ok := slist["results"]; ok {
-			subaccounts = list
-			return
-		}
-		err = fmt.Errorf("Unexpected response to Subaccount list")
-		return
-
-	} else {
-		err = res.ParseResponse()
-		if err != nil {
-			return
-		}
-		if len(res.Errors) > 0 {
-			err = res.PrettyError("Subaccount", "list")
-			if err != nil {
-				return
-			}
-		}
-		err = fmt.Errorf("%d: %s", res.HTTP.StatusCode, string(res.Body))
-		return
-	}
-
+	err = res.DecodeResults(&subaccounts)
 	return
 }
 
 func (c *Client) Subaccount(id int) (subaccount *Subaccount, res *Response, err error) {
 	path := fmt.Sprintf(subaccountsPathFormat, c.Config.ApiVersion)
 	u := fmt.Sprintf("%s%s/%d", c.Config.BaseUrl, path, id)
-	res, err = c.HttpGet(u)
-	if err != nil {
-		return
-	}
-
-	err = res.AssertJson()
+	res, err = c.doJSON(context.Background(), "GET", u, nil, "Subaccount", "retrieve")
 	if err != nil {
 		return
 	}
 
-	if res.HTTP.StatusCode == 200 {
-		if res.HTTP.StatusCode == 200 {
-			var body []byte
-			body, err = res.ReadBody()
-			if err != nil {
-				return
-			}
-			slist := map[string]Subaccount{}
-			err = json.Unmarshal(body, &slist)
-			if err != nil {
-				return
-			} else if s, ok := slist["results"]; ok {
-				subaccount = &s
-				return
-			}
-			err = fmt.Errorf("Unexpected response to Subaccount")
-			return
-		}
-	} else {
-		err = res.ParseResponse()
-		if err != nil {
-			return
-		}
-		if len(res.Errors) > 0 {
-			err = res.PrettyError("Subaccount", "retrieve")
-			if err != nil {
-				return
-			}
-		}
-		err = fmt.Errorf("%d: %s", res.HTTP.StatusCode, string(res.Body))
-		return
+	subaccount = &Subaccount{}
+	if err = res.DecodeResults(subaccount); err != nil {
+		subaccount = nil
 	}
-
 	return
 }
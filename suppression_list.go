@@ -1,9 +1,14 @@
 package gosparkpost
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/url"
+	"strings"
+	"sync"
+	"time"
 )
 
 // https://developers.sparkpost.com/api/#/reference/suppression-list
@@ -19,15 +24,56 @@ type SuppressionEntry struct {
 	Transactional    bool   `json:"transactional,omitempty"`
 	NonTransactional bool   `json:"non_transactional,omitempty"`
 	Source           string `json:"source,omitempty"`
-	Type             string `json:type,omitempty`
+	Type             string `json:"type,omitempty"`
 	Description      string `json:"description,omitempty"`
 	Updated          string `json:"updated,omitempty"`
 	Created          string `json:"created,omitempty"`
 }
 
+// SuppressionSearchOptions holds the filters accepted by the suppression list search endpoint.
+// Zero values are omitted from the query.
+type SuppressionSearchOptions struct {
+	// From and To limit results to entries last updated in that window.
+	From time.Time
+	To   time.Time
+	// Types is any of "transactional" and "non_transactional".
+	Types []string
+	// Sources is any of "Spam Complaint", "List Unsubscribe", "Bounce Rule",
+	// "Unsubscribe Link", "Manually Added" and "Compliance".
+	Sources     []string
+	Description string
+	Limit       int
+	// PerPage is the number of entries in each page of results.
+	PerPage int
+	// Cursor selects cursor-based paging; "initial" requests the first page.
+	// The cursors of later pages come from the next link of each page.
+	Cursor string
+}
+
+// Map returns the search parameters, for use with SuppressionSearch and SuppressionSearchEach.
+func (o *SuppressionSearchOptions) Map() map[string]string {
+	if o == nil {
+		return nil
+	}
+	p := NewParams().TimeRange(o.From, o.To).
+		List("types", o.Types).
+		List("sources", o.Sources).
+		Set("description", o.Description)
+	if o.Limit > 0 {
+		p.Int("limit", o.Limit)
+	}
+	if o.PerPage > 0 {
+		p.Int("per_page", o.PerPage)
+	}
+	return p.Set("cursor", o.Cursor).Map()
+}
+
 type SuppressionListWrapper struct {
 	Results    []*SuppressionEntry `json:"results,omitempty"`
 	Recipients []SuppressionEntry  `json:"recipients,omitempty"`
+	// Links and TotalCount are returned by searches, for paging through the results.
+	Links      Links `json:"links,omitempty"`
+	TotalCount int   `json:"total_count,omitempty"`
 }
 
 func (c *Client) SuppressionList() (*SuppressionListWrapper, *Response, error) {
@@ -42,108 +88,224 @@ func (c *Client) SuppressionRetrieve(recipientEmail string) (*SuppressionListWra
 	return suppressionGet(c, finalUrl)
 }
 
-func (c *Client) SuppressionSearch(parameters map[string]string) (*SuppressionListWrapper, *Response, error) {
-	var finalUrl string
+// SuppressionStatus returns the suppression entries for recipientEmail, one per suppression type.
+// A recipient that isn't suppressed has no entries, and no error is returned.
+func (c *Client) SuppressionStatus(recipientEmail string) ([]*SuppressionEntry, *Response, error) {
+	if recipientEmail == "" {
+		return nil, nil, fmt.Errorf("SuppressionStatus called with blank email")
+	}
+
 	path := fmt.Sprintf(suppressionListsPathFormat, c.Config.ApiVersion)
+	finalUrl := fmt.Sprintf("%s%s/%s", c.Config.BaseUrl, path, url.PathEscape(recipientEmail))
 
-	if parameters == nil || len(parameters) == 0 {
-		finalUrl = fmt.Sprintf("%s%s", c.Config.BaseUrl, path)
-	} else {
-		params := url.Values{}
-		for k, v := range parameters {
-			params.Add(k, v)
+	list, res, err := suppressionGet(c, finalUrl)
+	if err != nil {
+		if res != nil && res.HTTP != nil && res.HTTP.StatusCode == 404 {
+			return nil, res, nil
 		}
-
-		finalUrl = fmt.Sprintf("%s%s?%s", c.Config.BaseUrl, path, params.Encode())
+		return nil, res, err
 	}
+	return list.Results, res, nil
+}
+
+func (c *Client) SuppressionSearch(parameters map[string]string) (*SuppressionListWrapper, *Response, error) {
+	path := fmt.Sprintf(suppressionListsPathFormat, c.Config.ApiVersion)
+	finalUrl := buildUrl(c, path, parameters)
 
 	return suppressionGet(c, finalUrl)
 }
 
-func (c *Client) SuppressionDelete(recipientEmail string) (res *Response, err error) {
+// SuppressionSearchEach is like SuppressionSearch, but decodes the response as it's read,
+// calling fn for each entry instead of building the full list in memory.
+// If fn returns an error, no more entries are read and that error is returned.
+func (c *Client) SuppressionSearchEach(parameters map[string]string, fn func(*SuppressionEntry) error) (*Response, error) {
 	path := fmt.Sprintf(suppressionListsPathFormat, c.Config.ApiVersion)
-	finalUrl := fmt.Sprintf("%s%s/%s", c.Config.BaseUrl, path, recipientEmail)
+	finalUrl := buildUrl(c, path, parameters)
 
-	res, err = c.HttpDelete(finalUrl)
+	res, err := c.HttpGet(finalUrl)
 	if err != nil {
 		return res, err
 	}
 
-	if res.HTTP.StatusCode >= 200 && res.HTTP.StatusCode <= 299 {
+	if err = res.AssertJson(); err != nil {
 		return res, err
+	}
 
-	} else if len(res.Errors) > 0 {
-		// handle common errors
-		err = res.PrettyError("SuppressionEntry", "delete")
+	if !res.success() {
+		if err = res.ParseResponse(); err != nil {
+			return res, err
+		}
+		err = res.PrettyError("SuppressionEntry", "search")
 		if err != nil {
 			return res, err
 		}
-
-		err = fmt.Errorf("%d: %s", res.HTTP.StatusCode, string(res.Body))
+		return res, res.SPError()
 	}
 
+	err = res.EachResult(func(raw json.RawMessage) error {
+		entry := &SuppressionEntry{}
+		if err := unmarshalJSON(raw, entry, c.Config.StrictDecoding); err != nil {
+			return err
+		}
+		return fn(entry)
+	})
+	if err == nil && len(res.Errors) > 0 {
+		err = res.SPError()
+	}
 	return res, err
 }
 
+func (c *Client) SuppressionDelete(recipientEmail string) (res *Response, err error) {
+	path := fmt.Sprintf(suppressionListsPathFormat, c.Config.ApiVersion)
+	finalUrl := fmt.Sprintf("%s%s/%s", c.Config.BaseUrl, path, recipientEmail)
+
+	return c.doJSON(context.Background(), "DELETE", finalUrl, nil, "SuppressionEntry", "delete")
+}
+
+// SuppressionUpsert adds or updates the suppression entry for a single recipient,
+// identified by entry.Recipient or entry.Email.
+func (c *Client) SuppressionUpsert(entry SuppressionEntry) (*Response, error) {
+	recipient := entry.Recipient
+	if recipient == "" {
+		recipient = entry.Email
+	}
+	if recipient == "" {
+		return nil, fmt.Errorf("SuppressionUpsert called with blank recipient")
+	}
+	// the recipient is given by the path
+	entry.Recipient, entry.Email = "", ""
+
+	path := fmt.Sprintf(suppressionListsPathFormat, c.Config.ApiVersion)
+	finalUrl := fmt.Sprintf("%s%s/%s", c.Config.BaseUrl, path, url.PathEscape(recipient))
+	return suppressionPut(c, finalUrl, entry)
+}
+
+// SuppressionInsertOrUpdate adds or updates the suppression entries for many recipients at once.
 func (c *Client) SuppressionInsertOrUpdate(entries []SuppressionEntry) (*Response, error) {
 	if entries == nil {
 		return nil, fmt.Errorf("send `entries` cannot be nil here")
 	}
 
 	path := fmt.Sprintf(suppressionListsPathFormat, c.Config.ApiVersion)
-	list := SuppressionListWrapper{nil, entries}
+	list := SuppressionListWrapper{Recipients: entries}
 
 	return suppressionPut(c, c.Config.BaseUrl+path, list)
 }
 
-func suppressionPut(c *Client, finalUrl string, recipients SuppressionListWrapper) (*Response, error) {
-	jsonBytes, err := json.Marshal(recipients)
-	if err != nil {
-		return nil, err
+// DefaultSuppressionBatchSize is the number of entries SuppressionBulkUpsert sends per
+// request unless told otherwise, which keeps payloads within the API's size limit.
+const DefaultSuppressionBatchSize = 10000
+
+// DefaultSuppressionConcurrency is the number of batches SuppressionBulkUpsert sends at once
+// unless told otherwise.
+const DefaultSuppressionConcurrency = 4
+
+// SuppressionBatchError describes a batch of entries[Start:End] which SuppressionBulkUpsert
+// failed to send.
+type SuppressionBatchError struct {
+	Start int
+	End   int
+	Err   error
+}
+
+func (e *SuppressionBatchError) Error() string {
+	return fmt.Sprintf("entries [%d:%d]: %s", e.Start, e.End, e.Err)
+}
+
+func (e *SuppressionBatchError) Unwrap() error { return e.Err }
+
+// SuppressionBulkError is returned by SuppressionBulkUpsert when any batch fails.
+// Batches are ordered by their position in the entries, and the ones not listed succeeded.
+type SuppressionBulkError struct {
+	Batches int
+	Failed  []*SuppressionBatchError
+}
+
+func (e *SuppressionBulkError) Error() string {
+	msgs := make([]string, len(e.Failed))
+	for i, f := range e.Failed {
+		msgs[i] = f.Error()
 	}
+	return fmt.Sprintf("%d of %d suppression batches failed:\n%s", len(e.Failed), e.Batches, strings.Join(msgs, "\n"))
+}
 
-	res, err := c.HttpPut(finalUrl, jsonBytes)
-	if err != nil {
-		return res, err
+// Is reports whether the error of any failed batch matches target, so errors.Is can look at them.
+func (e *SuppressionBulkError) Is(target error) bool {
+	for _, f := range e.Failed {
+		if errors.Is(f, target) {
+			return true
+		}
 	}
+	return false
+}
 
-	if err = res.AssertJson(); err != nil {
-		return res, err
+// As finds the first failed batch whose error matches target, so errors.As can look at them.
+func (e *SuppressionBulkError) As(target interface{}) bool {
+	for _, f := range e.Failed {
+		if errors.As(f, target) {
+			return true
+		}
 	}
+	return false
+}
 
-	err = res.ParseResponse()
-	if err != nil {
-		return res, err
+// SuppressionBulkUpsert adds or updates any number of suppression entries. They're split
+// into batches of batchSize entries, and at most concurrency batches are sent at once.
+// Zero values mean DefaultSuppressionBatchSize and DefaultSuppressionConcurrency.
+// Every batch is attempted; if any fail, a *SuppressionBulkError lists them.
+func (c *Client) SuppressionBulkUpsert(entries []SuppressionEntry, batchSize, concurrency int) error {
+	if batchSize <= 0 {
+		batchSize = DefaultSuppressionBatchSize
+	}
+	if concurrency <= 0 {
+		concurrency = DefaultSuppressionConcurrency
 	}
 
-	if res.HTTP.StatusCode == 200 {
+	batches := (len(entries) + batchSize - 1) / batchSize
+	failed := make([]*SuppressionBatchError, batches)
+	starts := make(chan int)
+
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency && i < batches; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for start := range starts {
+				end := start + batchSize
+				if end > len(entries) {
+					end = len(entries)
+				}
+				if _, err := c.SuppressionInsertOrUpdate(entries[start:end]); err != nil {
+					failed[start/batchSize] = &SuppressionBatchError{Start: start, End: end, Err: err}
+				}
+			}
+		}()
+	}
+	for start := 0; start < len(entries); start += batchSize {
+		starts <- start
+	}
+	close(starts)
+	wg.Wait()
 
-	} else if len(res.Errors) > 0 {
-		// handle common errors
-		err = res.PrettyError("Transmission", "create")
-		if err != nil {
-			return res, err
+	bulkErr := &SuppressionBulkError{Batches: batches}
+	for _, f := range failed {
+		if f != nil {
+			bulkErr.Failed = append(bulkErr.Failed, f)
 		}
-
-		err = fmt.Errorf("%d: %s", res.HTTP.StatusCode, string(res.Body))
 	}
+	if len(bulkErr.Failed) > 0 {
+		return bulkErr
+	}
+	return nil
+}
 
-	return res, err
+func suppressionPut(c *Client, finalUrl string, body interface{}) (*Response, error) {
+	return c.doJSON(context.Background(), "PUT", finalUrl, body, "SuppressionEntry", "upsert")
 }
 
 func suppressionGet(c *Client, finalUrl string) (*SuppressionListWrapper, *Response, error) {
 	// Send off our request
-	res, err := c.HttpGet(finalUrl)
-	if err != nil {
-		return nil, res, err
-	}
-
-	// Assert that we got a JSON Content-Type back
-	if err = res.AssertJson(); err != nil {
-		return nil, res, err
-	}
-
-	err = res.ParseResponse()
+	res, err := c.doJSON(context.Background(), "GET", finalUrl, nil, "", "")
 	if err != nil {
 		return nil, res, err
 	}
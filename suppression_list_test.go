@@ -1,9 +1,14 @@
 package gosparkpost
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io/ioutil"
 	"net/http"
+	"sync"
 	"testing"
+	"time"
 )
 
 var suppressionNotFound string = `{
@@ -30,15 +35,15 @@ func TestSuppression_Get_notFound(t *testing.T) {
 
 	// hit our local handler
 	s, res, err := testClient.SuppressionList()
-	if err != nil {
-		testFailVerbose(t, res, "SuppressionList GET returned error: %v", err)
+	if err == nil {
+		testFailVerbose(t, res, "SuppressionList GET didn't return an error")
+	} else if !errors.Is(err, ErrNotFound) {
+		testFailVerbose(t, res, "SuppressionList GET returned %v, expected ErrNotFound", err)
 	}
 
 	// basic content test
-	if s.Results != nil {
-		testFailVerbose(t, res, "SuppressionList GET returned non-nil Results (error expected)")
-	} else if len(s.Results) != 0 {
-		testFailVerbose(t, res, "SuppressionList GET returned %d results, expected %d", len(s.Results), 0)
+	if s != nil {
+		testFailVerbose(t, res, "SuppressionList GET returned non-nil wrapper (error expected)")
 	} else if len(res.Errors) != 1 {
 		testFailVerbose(t, res, "SuppressionList GET returned %d errors, expected %d", len(res.Errors), 1)
 	} else if res.Errors[0].Message != "Recipient could not be found" {
@@ -47,6 +52,29 @@ func TestSuppression_Get_notFound(t *testing.T) {
 	}
 }
 
+func TestSuppressionDelete_error(t *testing.T) {
+	testSetup(t)
+	defer testTeardown()
+
+	// set up the response handler
+	path := fmt.Sprintf(suppressionListsPathFormat, testClient.Config.ApiVersion)
+	testMux.HandleFunc(path+"/rcpt_1@example.com", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		w.Header().Set("Content-Type", "application/json; charset=utf8")
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		w.Write([]byte(`{"errors": [{"message": "invalid recipient", "code": "1200"}]}`))
+	})
+
+	res, err := testClient.SuppressionDelete("rcpt_1@example.com")
+	var spErr *SPError
+	if !errors.As(err, &spErr) {
+		testFailVerbose(t, res, "SuppressionDelete returned %v, expected an SPError", err)
+	}
+	if spErr.Code != "1200" || err.Error() != "422: 1200: invalid recipient" {
+		t.Errorf("SuppressionDelete returned %q (code %q)", err, spErr.Code)
+	}
+}
+
 var combinedSuppressionList string = `{
   "results": [
     {
@@ -151,3 +179,237 @@ func TestSuppression_Get_separateList(t *testing.T) {
 		t.Errorf("SuppressionList GET Unmarshal error; saw [%v] expected [rcpt_1@example.com]", s.Results[0].Recipient)
 	}
 }
+
+// Test streaming through separate suppression list results
+func TestSuppression_SearchEach(t *testing.T) {
+	testSetup(t)
+	defer testTeardown()
+
+	// set up the response handler
+	path := fmt.Sprintf(suppressionListsPathFormat, testClient.Config.ApiVersion)
+	testMux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		w.Header().Set("Content-Type", "application/json; charset=utf8")
+		w.Write([]byte(separateSuppressionList))
+	})
+
+	// hit our local handler
+	var seen []string
+	res, err := testClient.SuppressionSearchEach(nil, func(e *SuppressionEntry) error {
+		seen = append(seen, e.Type)
+		return nil
+	})
+	if err != nil {
+		testFailVerbose(t, res, "SuppressionSearchEach returned error: %v", err)
+	}
+
+	if len(seen) != 2 {
+		testFailVerbose(t, res, "SuppressionSearchEach saw %d entries, expected %d", len(seen), 2)
+	} else if seen[0] != "non_transactional" || seen[1] != "transactional" {
+		testFailVerbose(t, res, "SuppressionSearchEach saw types %v", seen)
+	}
+}
+
+func TestSuppression_SearchEachStatus(t *testing.T) {
+	testSetup(t)
+	defer testTeardown()
+
+	// set up the response handler
+	status, body := http.StatusNonAuthoritativeInfo, separateSuppressionList
+	path := fmt.Sprintf(suppressionListsPathFormat, testClient.Config.ApiVersion)
+	testMux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json; charset=utf8")
+		w.WriteHeader(status)
+		w.Write([]byte(body))
+	})
+
+	// any 2xx response is decoded
+	n := 0
+	count := func(e *SuppressionEntry) error { n++; return nil }
+	res, err := testClient.SuppressionSearchEach(nil, count)
+	if err != nil || n != 2 {
+		testFailVerbose(t, res, "SuppressionSearchEach returned %v after %d entries", err, n)
+	}
+
+	// an errors array in a successful response is returned as an SPError
+	status, body = http.StatusOK, `{"results": [], "errors": [{"message": "From must be before to", "code": "1200"}]}`
+	_, err = testClient.SuppressionSearchEach(nil, count)
+	var spErr *SPError
+	if !errors.As(err, &spErr) || spErr.Code != "1200" {
+		t.Errorf("SuppressionSearchEach returned %v, expected an SPError", err)
+	}
+}
+
+func TestSuppressionSearchOptions(t *testing.T) {
+	opts := &SuppressionSearchOptions{
+		From:    time.Date(2016, 1, 1, 12, 0, 0, 0, time.UTC),
+		Types:   []string{"transactional", "non_transactional"},
+		Sources: []string{"Bounce Rule"},
+		Limit:   10,
+	}
+	out := ParamsFromMap(opts.Map()).Encode()
+	if out != "from=2016-01-01T12%3A00&limit=10&sources=Bounce+Rule&types=transactional%2Cnon_transactional" {
+		t.Errorf("SuppressionSearchOptions encoded as %q", out)
+	}
+
+	var none *SuppressionSearchOptions
+	if m := none.Map(); len(m) != 0 {
+		t.Errorf("nil SuppressionSearchOptions mapped to %v", m)
+	}
+}
+
+func TestSuppressionStatus(t *testing.T) {
+	testSetup(t)
+	defer testTeardown()
+
+	// set up the response handler
+	path := fmt.Sprintf(suppressionListsPathFormat, testClient.Config.ApiVersion)
+	testMux.HandleFunc(path+"/rcpt_1@example.com", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		w.Header().Set("Content-Type", "application/json; charset=utf8")
+		w.Write([]byte(separateSuppressionList))
+	})
+	testMux.HandleFunc(path+"/rcpt_2@example.com", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json; charset=utf8")
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(suppressionNotFound))
+	})
+
+	entries, res, err := testClient.SuppressionStatus("rcpt_1@example.com")
+	if err != nil {
+		testFailVerbose(t, res, "SuppressionStatus returned error: %v", err)
+	}
+	if len(entries) != 2 || entries[1].Source != "Bounce Rule" {
+		t.Errorf("SuppressionStatus returned %+v", entries)
+	}
+
+	// recipients that aren't suppressed aren't an error
+	entries, res, err = testClient.SuppressionStatus("rcpt_2@example.com")
+	if err != nil || len(entries) != 0 {
+		testFailVerbose(t, res, "SuppressionStatus returned %v, %v for an unsuppressed recipient", entries, err)
+	}
+}
+
+func TestSuppressionUpsert(t *testing.T) {
+	testSetup(t)
+	defer testTeardown()
+
+	// set up the response handler
+	var body string
+	path := fmt.Sprintf(suppressionListsPathFormat, testClient.Config.ApiVersion)
+	testMux.HandleFunc(path+"/rcpt_1@example.com", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+		b, _ := ioutil.ReadAll(r.Body)
+		body = string(b)
+		w.Header().Set("Content-Type", "application/json; charset=utf8")
+		w.Write([]byte(`{"results": {"message": "Suppression List successfully updated"}}`))
+	})
+	testMux.HandleFunc(path+"/rcpt_2@example.com", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json; charset=utf8")
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"errors": [{"message": "Invalid type"}]}`))
+	})
+
+	res, err := testClient.SuppressionUpsert(SuppressionEntry{
+		Email: "rcpt_1@example.com", Type: "non_transactional", Description: "Unsubscribed"})
+	if err != nil {
+		testFailVerbose(t, res, "SuppressionUpsert returned error: %v", err)
+	}
+	if body != `{"type":"non_transactional","description":"Unsubscribed"}` {
+		t.Errorf("SuppressionUpsert sent %s", body)
+	}
+
+	if _, err = testClient.SuppressionUpsert(SuppressionEntry{Recipient: "rcpt_2@example.com", Type: "bogus"}); err == nil {
+		t.Error("SuppressionUpsert didn't return an error")
+	}
+	if _, err = testClient.SuppressionUpsert(SuppressionEntry{Type: "transactional"}); err == nil {
+		t.Error("SuppressionUpsert accepted a blank recipient")
+	}
+}
+
+func TestSuppressionBulkUpsert(t *testing.T) {
+	testSetup(t)
+	defer testTeardown()
+
+	// set up the response handler, rejecting any batch containing a bad entry
+	var mu sync.Mutex
+	var sizes []int
+	var inFlight, maxInFlight int
+	path := fmt.Sprintf(suppressionListsPathFormat, testClient.Config.ApiVersion)
+	testMux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+		mu.Lock()
+		inFlight++
+		if inFlight > maxInFlight {
+			maxInFlight = inFlight
+		}
+		mu.Unlock()
+		time.Sleep(5 * time.Millisecond)
+
+		var body SuppressionListWrapper
+		json.NewDecoder(r.Body).Decode(&body)
+		mu.Lock()
+		inFlight--
+		sizes = append(sizes, len(body.Recipients))
+		mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json; charset=utf8")
+		for _, e := range body.Recipients {
+			if e.Email == "bad" {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{"errors": [{"message": "invalid email"}]}`))
+				return
+			}
+		}
+		w.Write([]byte(`{"results": {"message": "Suppression List successfully updated"}}`))
+	})
+
+	entries := make([]SuppressionEntry, 25)
+	for i := range entries {
+		entries[i] = SuppressionEntry{Email: fmt.Sprintf("rcpt_%d@example.com", i), Type: "transactional"}
+	}
+	if err := testClient.SuppressionBulkUpsert(entries, 10, 2); err != nil {
+		t.Fatalf("SuppressionBulkUpsert returned error: %v", err)
+	}
+	if len(sizes) != 3 || sizes[0]+sizes[1]+sizes[2] != 25 {
+		t.Errorf("SuppressionBulkUpsert sent batches of %v", sizes)
+	}
+	if maxInFlight > 2 {
+		t.Errorf("SuppressionBulkUpsert sent %d batches at once, expected at most 2", maxInFlight)
+	}
+
+	entries[12].Email = "bad"
+	entries[24].Email = "bad"
+	err := testClient.SuppressionBulkUpsert(entries, 10, 0)
+	var bulkErr *SuppressionBulkError
+	if !errors.As(err, &bulkErr) {
+		t.Fatalf("SuppressionBulkUpsert returned %v, expected a SuppressionBulkError", err)
+	}
+	if bulkErr.Batches != 3 || len(bulkErr.Failed) != 2 {
+		t.Fatalf("unexpected bulk error %+v", bulkErr)
+	}
+	if f := bulkErr.Failed[0]; f.Start != 10 || f.End != 20 {
+		t.Errorf("first failed batch was [%d:%d]", f.Start, f.End)
+	}
+	if f := bulkErr.Failed[1]; f.Start != 20 || f.End != 25 {
+		t.Errorf("second failed batch was [%d:%d]", f.Start, f.End)
+	}
+	var spErr *SPError
+	if !errors.As(err, &spErr) || spErr.StatusCode != 400 {
+		t.Errorf("bulk error didn't unwrap to the batch SPError: %v", err)
+	}
+	var batchErr *SuppressionBatchError
+	if !errors.As(err, &batchErr) || batchErr != bulkErr.Failed[0] {
+		t.Errorf("bulk error didn't unwrap to its first failed batch: %v", err)
+	}
+	if !errors.Is(err, bulkErr.Failed[1]) {
+		t.Errorf("bulk error didn't match its second failed batch: %v", err)
+	}
+	if errors.Is(err, ErrNotFound) {
+		t.Errorf("bulk error matched ErrNotFound: %v", err)
+	}
+
+	if err = testClient.SuppressionBulkUpsert(nil, 0, 0); err != nil {
+		t.Errorf("SuppressionBulkUpsert of no entries returned %v", err)
+	}
+}
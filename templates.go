@@ -1,6 +1,7 @@
 package gosparkpost
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"reflect"
@@ -24,6 +25,11 @@ type Template struct {
 	Options     *TmplOptions `json:"options,omitempty"`
 }
 
+// TemplateResults is the "results" object returned when a Template is created.
+type TemplateResults struct {
+	ID string `json:"id"`
+}
+
 // Content is what you'll send to your Recipients.
 // Knowledge of SparkPost's substitution/templating capabilities will come in handy here.
 // https://www.sparkpost.com/api#/introduction/substitutions-reference
@@ -70,6 +76,16 @@ type PreviewOptions struct {
 	SubstitutionData map[string]interface{} `json:"substitution_data"`
 }
 
+// PreviewContent is the rendered content returned when a Template is previewed.
+type PreviewContent struct {
+	From    From              `json:"from"`
+	Subject string            `json:"subject,omitempty"`
+	ReplyTo string            `json:"reply_to,omitempty"`
+	Text    string            `json:"text,omitempty"`
+	HTML    string            `json:"html,omitempty"`
+	Headers map[string]string `json:"headers,omitempty"`
+}
+
 // ParseFrom parses the various allowable Content.From values.
 func ParseFrom(from interface{}) (f From, err error) {
 	// handle the allowed types
@@ -196,57 +212,28 @@ func (c *Client) TemplateCreate(t *Template) (id string, res *Response, err erro
 		return
 	}
 
-	jsonBytes, err := json.Marshal(t)
-	if err != nil {
-		return
-	}
-
 	path := fmt.Sprintf(templatesPathFormat, c.Config.ApiVersion)
 	url := fmt.Sprintf("%s%s", c.Config.BaseUrl, path)
-	res, err = c.HttpPost(url, jsonBytes)
+	res, err = c.doJSON(context.Background(), "POST", url, t, "Template", "create")
 	if err != nil {
 		return
 	}
 
-	if err = res.AssertJson(); err != nil {
-		return
+	var results TemplateResults
+	if err = res.DecodeResults(&results); err != nil {
+		return id, res, err
 	}
-
-	err = res.ParseResponse()
-	if err != nil {
-		return
-	}
-
-	if res.HTTP.StatusCode == 200 {
-		var ok bool
-		var results map[string]interface{}
-		if results, ok = res.Results.(map[string]interface{}); !ok {
-			return id, res, fmt.Errorf("Unexpected response to Template creation (results)")
-		}
-		id, ok = results["id"].(string)
-		if !ok {
-			err = fmt.Errorf("Unexpected response to Template creation")
-		}
-
-	} else if len(res.Errors) > 0 {
-		// handle common errors
-		err = res.PrettyError("Template", "create")
-		if err != nil {
-			return
-		}
-
-		if res.HTTP.StatusCode == 422 { // template syntax error
-			eobj := res.Errors[0]
-			err = fmt.Errorf("%s: %s\n%s", eobj.Code, eobj.Message, eobj.Description)
-		} else { // everything else
-			err = fmt.Errorf("%d: %s", res.HTTP.StatusCode, string(res.Body))
-		}
+	id = results.ID
+	if id == "" {
+		err = res.unexpected("Unexpected response to Template creation")
 	}
 
 	return
 }
 
-// Update updates a draft/published template with the specified id
+// Update updates a draft/published template with the specified id.
+// When Template.Published is true, the update_published flag is set and the
+// published version is updated directly, otherwise the draft is updated.
 func (c *Client) TemplateUpdate(t *Template) (res *Response, err error) {
 	if t.ID == "" {
 		err = fmt.Errorf("Update called with blank id")
@@ -258,91 +245,68 @@ func (c *Client) TemplateUpdate(t *Template) (res *Response, err error) {
 		return
 	}
 
-	jsonBytes, err := json.Marshal(t)
-	if err != nil {
+	return c.templateUpdate(t.ID, t, t.Published, "update")
+}
+
+// Publish promotes the current draft of the Template with the specified id
+// to be its published version.
+func (c *Client) TemplatePublish(id string) (res *Response, err error) {
+	if id == "" {
+		err = fmt.Errorf("Publish called with blank id")
 		return
 	}
 
+	return c.templateUpdate(id, json.RawMessage(`{"published":true}`), false, "publish")
+}
+
+func (c *Client) templateUpdate(id string, body interface{}, updatePublished bool, verb string) (res *Response, err error) {
 	path := fmt.Sprintf(templatesPathFormat, c.Config.ApiVersion)
-	url := fmt.Sprintf("%s%s/%s?update_published=%t", c.Config.BaseUrl, path, t.ID, t.Published)
+	url := NewParams().Bool("update_published", updatePublished).Url(fmt.Sprintf("%s%s/%s", c.Config.BaseUrl, path, id))
 
-	res, err = c.HttpPut(url, jsonBytes)
-	if err != nil {
-		return
+	res, err = c.doJSON(context.Background(), "PUT", url, body, "Template", verb)
+	if res.conflict() {
+		// handle template-specific ones
+		err = &prettyError{msg: fmt.Sprintf("Template with id [%s] is in use by msg generation", id), cause: res.SPError()}
 	}
 
-	if err = res.AssertJson(); err != nil {
-		return
+	return
+}
+
+// Retrieve returns the Template with the specified id.
+// The draft version is returned when draft is true, the published version otherwise.
+func (c *Client) TemplateRetrieve(id string, draft bool) (*Template, *Response, error) {
+	if id == "" {
+		return nil, nil, fmt.Errorf("Retrieve called with blank id")
 	}
 
-	err = res.ParseResponse()
+	path := fmt.Sprintf(templatesPathFormat, c.Config.ApiVersion)
+	url := NewParams().Bool("draft", draft).Url(fmt.Sprintf("%s%s/%s", c.Config.BaseUrl, path, id))
+	res, err := c.doJSON(context.Background(), "GET", url, nil, "Template", "retrieve")
 	if err != nil {
-		return
+		return nil, res, err
 	}
 
-	if res.HTTP.StatusCode == 200 {
-		return
-
-	} else if len(res.Errors) > 0 {
-		// handle common errors
-		err = res.PrettyError("Template", "update")
-		if err != nil {
-			return
-		}
-
-		// handle template-specific ones
-		if res.HTTP.StatusCode == 409 {
-			err = fmt.Errorf("Template with id [%s] is in use by msg generation", t.ID)
-		} else { // everything else
-			err = fmt.Errorf("%d: %s", res.HTTP.StatusCode, string(res.Body))
-		}
+	t := &Template{}
+	if err = res.DecodeResults(t); err != nil {
+		return nil, res, err
 	}
-
-	return
+	return t, res, nil
 }
 
 // List returns metadata for all Templates in the system.
 func (c *Client) Templates() ([]Template, *Response, error) {
 	path := fmt.Sprintf(templatesPathFormat, c.Config.ApiVersion)
 	url := fmt.Sprintf("%s%s", c.Config.BaseUrl, path)
-	res, err := c.HttpGet(url)
+	res, err := c.doJSON(context.Background(), "GET", url, nil, "Template", "list")
 	if err != nil {
-		return nil, nil, err
-	}
-
-	if err = res.AssertJson(); err != nil {
 		return nil, res, err
 	}
 
-	if res.HTTP.StatusCode == 200 {
-		var body []byte
-		body, err = res.ReadBody()
-		if err != nil {
-			return nil, res, err
-		}
-		tlist := map[string][]Template{}
-		if err = json.Unmarshal(body, &tlist); err != nil {
-			return nil, res, err
-		} else if list, ok := tlist["results"]; ok {
-			return list, res, nil
-		}
-		return nil, res, fmt.Errorf("Unexpected response to Template list")
-
-	} else {
-		err = res.ParseResponse()
-		if err != nil {
-			return nil, res, err
-		}
-		if len(res.Errors) > 0 {
-			err = res.PrettyError("Template", "list")
-			if err != nil {
-				return nil, res, err
-			}
-		}
-		return nil, res, fmt.Errorf("%d: %s", res.HTTP.StatusCode, string(res.Body))
+	var list []Template
+	if err = res.DecodeResults(&list); err != nil {
+		return nil, res, err
 	}
-
-	return nil, res, err
+	return list, res, nil
 }
 
 // Delete removes the Template with the specified id.
@@ -354,86 +318,59 @@ func (c *Client) TemplateDelete(id string) (res *Response, err error) {
 
 	path := fmt.Sprintf(templatesPathFormat, c.Config.ApiVersion)
 	url := fmt.Sprintf("%s%s/%s", c.Config.BaseUrl, path, id)
-	res, err = c.HttpDelete(url)
-	if err != nil {
-		return
-	}
-
-	if err = res.AssertJson(); err != nil {
-		return
-	}
-
-	err = res.ParseResponse()
-	if err != nil {
-		return
-	}
-
-	if res.HTTP.StatusCode == 200 {
-		return
-
-	} else if len(res.Errors) > 0 {
-		// handle common errors
-		err = res.PrettyError("Template", "delete")
-		if err != nil {
-			return
-		}
-
+	res, err = c.doJSON(context.Background(), "DELETE", url, nil, "Template", "delete")
+	if res.conflict() {
 		// handle template-specific ones
-		if res.HTTP.StatusCode == 409 {
-			err = fmt.Errorf("Template with id [%s] is in use by msg generation", id)
-		} else { // everything else
-			err = fmt.Errorf("%d: %s", res.HTTP.StatusCode, string(res.Body))
-		}
+		err = &prettyError{msg: fmt.Sprintf("Template with id [%s] is in use by msg generation", id), cause: res.SPError()}
 	}
 
 	return
 }
 
+// Preview renders the Template with the specified id using the provided substitution data.
 func (c *Client) TemplatePreview(id string, payload *PreviewOptions) (res *Response, err error) {
 	if id == "" {
 		err = fmt.Errorf("Preview called with blank id")
 		return
 	}
 
-	if payload == nil {
-		payload = &PreviewOptions{}
-	}
+	path := fmt.Sprintf(templatesPathFormat, c.Config.ApiVersion)
+	url := fmt.Sprintf("%s%s/%s/preview", c.Config.BaseUrl, path, id)
+	return c.templatePreview(url, payload)
+}
 
-	jsonBytes, err := json.Marshal(payload)
-	if err != nil {
-		return
+// TemplatePreviewContent renders the Template with the specified id using substitutionData,
+// and returns the rendered content. The draft version is rendered when draft is true.
+func (c *Client) TemplatePreviewContent(id string, substitutionData map[string]interface{}, draft bool) (*PreviewContent, *Response, error) {
+	if id == "" {
+		return nil, nil, fmt.Errorf("Preview called with blank id")
 	}
 
 	path := fmt.Sprintf(templatesPathFormat, c.Config.ApiVersion)
-	url := fmt.Sprintf("%s%s/%s/preview", c.Config.BaseUrl, path, id)
-	res, err = c.HttpPost(url, jsonBytes)
+	url := NewParams().Bool("draft", draft).Url(fmt.Sprintf("%s%s/%s/preview", c.Config.BaseUrl, path, id))
+	res, err := c.templatePreview(url, &PreviewOptions{SubstitutionData: substitutionData})
 	if err != nil {
-		return
+		return nil, res, err
 	}
 
-	if err = res.AssertJson(); err != nil {
-		return
+	content := &PreviewContent{}
+	if err = res.DecodeResults(content); err != nil {
+		return nil, res, err
 	}
+	return content, res, nil
+}
 
-	err = res.ParseResponse()
-	if err != nil {
-		return
+func (c *Client) templatePreview(url string, payload *PreviewOptions) (res *Response, err error) {
+	// SparkPost requires substitution_data, so send an empty object rather than
+	// filling in the caller's PreviewOptions
+	body := PreviewOptions{}
+	if payload != nil {
+		body = *payload
 	}
-
-	if len(res.Errors) > 0 {
-		// handle common errors
-		err = res.PrettyError("Template", "preview")
-		if err != nil {
-			return
-		}
-
-		if res.HTTP.StatusCode == 422 { // preview payload error
-			eobj := res.Errors[0]
-			err = fmt.Errorf("%s: %s\n%s", eobj.Code, eobj.Message, eobj.Description)
-		} else { // everything else
-			err = fmt.Errorf("%d: %s", res.HTTP.StatusCode, string(res.Body))
-		}
+	if body.SubstitutionData == nil {
+		body.SubstitutionData = map[string]interface{}{}
 	}
 
+	res, err = c.doJSON(context.Background(), "POST", url, body, "Template", "preview")
 	return
 }
@@ -0,0 +1,192 @@
+package gosparkpost
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+)
+
+var templateRetrieveSuccess = `{
+  "results": {
+    "id": "summer_sale",
+    "name": "Summer Sale!",
+    "description": "",
+    "published": true,
+    "options": {"open_tracking": true, "click_tracking": true},
+    "last_update_time": "2014-05-22T15:12:59+00:00",
+    "content": {
+      "from": {"email": "marketing@bounces.company.example", "name": "Example Company Marketing"},
+      "subject": "Summer deals for {{name}}",
+      "text": "Check out these deals {{name}}!",
+      "html": "<b>Check out these deals {{name}}!</b>"
+    }
+  }
+}`
+
+func TestTemplateRetrieve(t *testing.T) {
+	testSetup(t)
+	defer testTeardown()
+
+	// set up the response handler
+	var query string
+	path := fmt.Sprintf(templatesPathFormat, testClient.Config.ApiVersion)
+	testMux.HandleFunc(path+"/summer_sale", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		query = r.URL.RawQuery
+		w.Header().Set("Content-Type", "application/json; charset=utf8")
+		w.Write([]byte(templateRetrieveSuccess))
+	})
+
+	tmpl, res, err := testClient.TemplateRetrieve("summer_sale", false)
+	if err != nil {
+		testFailVerbose(t, res, "TemplateRetrieve returned error: %v", err)
+	}
+	if query != "draft=false" {
+		t.Errorf("query string was %q", query)
+	}
+	if tmpl == nil {
+		t.Fatal("TemplateRetrieve returned nil Template")
+	}
+	if tmpl.ID != "summer_sale" || !tmpl.Published || tmpl.Content.Subject != "Summer deals for {{name}}" {
+		t.Errorf("unexpected template %+v", tmpl)
+	}
+	if tmpl.Options == nil || !tmpl.Options.OpenTracking || tmpl.Options.Transactional {
+		t.Errorf("unexpected options %+v", tmpl.Options)
+	}
+	if f, err := ParseFrom(tmpl.Content.From); err != nil || f.Email != "marketing@bounces.company.example" {
+		t.Errorf("unexpected from %+v (%v)", f, err)
+	}
+
+	if _, _, err = testClient.TemplateRetrieve("summer_sale", true); err != nil {
+		t.Error(err)
+	}
+	if query != "draft=true" {
+		t.Errorf("draft query string was %q", query)
+	}
+}
+
+func TestTemplateRetrieve_notFound(t *testing.T) {
+	testSetup(t)
+	defer testTeardown()
+
+	// set up the response handler
+	path := fmt.Sprintf(templatesPathFormat, testClient.Config.ApiVersion)
+	testMux.HandleFunc(path+"/missing", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json; charset=utf8")
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"errors": [{"message": "resource not found", "code": "1600"}]}`))
+	})
+
+	tmpl, _, err := testClient.TemplateRetrieve("missing", false)
+	if tmpl != nil {
+		t.Errorf("TemplateRetrieve returned %+v, expected nil", tmpl)
+	}
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("TemplateRetrieve returned %v, expected ErrNotFound", err)
+	}
+
+	if _, _, err = testClient.TemplateRetrieve("", false); err == nil {
+		t.Error("TemplateRetrieve accepted a blank id")
+	}
+}
+
+func TestTemplatePreviewContent(t *testing.T) {
+	testSetup(t)
+	defer testTeardown()
+
+	// set up the response handler
+	var query, body string
+	path := fmt.Sprintf(templatesPathFormat, testClient.Config.ApiVersion)
+	testMux.HandleFunc(path+"/summer_sale/preview", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		query = r.URL.RawQuery
+		b, _ := ioutil.ReadAll(r.Body)
+		body = string(b)
+		w.Header().Set("Content-Type", "application/json; charset=utf8")
+		w.Write([]byte(`{"results": {
+			"from": {"email": "marketing@bounces.company.example", "name": "Example Company Marketing"},
+			"subject": "Summer deals for Natalie",
+			"reply_to": "Summer deals <summer_deals@company.example>",
+			"text": "Check out these deals Natalie!",
+			"html": "<b>Check out these deals Natalie!</b>",
+			"headers": {"X-Customer-Campaign-ID": "Summer2014"}
+		}}`))
+	})
+
+	content, res, err := testClient.TemplatePreviewContent("summer_sale", map[string]interface{}{"name": "Natalie"}, true)
+	if err != nil {
+		testFailVerbose(t, res, "TemplatePreviewContent returned error: %v", err)
+	}
+	if query != "draft=true" {
+		t.Errorf("query string was %q", query)
+	}
+	if body != `{"substitution_data":{"name":"Natalie"}}` {
+		t.Errorf("request body was %s", body)
+	}
+	if content == nil {
+		t.Fatal("TemplatePreviewContent returned nil content")
+	}
+	if content.From.Email != "marketing@bounces.company.example" || content.Subject != "Summer deals for Natalie" {
+		t.Errorf("unexpected content %+v", content)
+	}
+	if content.Headers["X-Customer-Campaign-ID"] != "Summer2014" {
+		t.Errorf("unexpected headers %v", content.Headers)
+	}
+
+	// substitution data is always sent as an object
+	if _, _, err = testClient.TemplatePreviewContent("summer_sale", nil, false); err != nil {
+		t.Error(err)
+	}
+	if query != "draft=false" || body != `{"substitution_data":{}}` {
+		t.Errorf("published preview sent %q %s", query, body)
+	}
+
+	// the caller's options are left as they were
+	opts := &PreviewOptions{}
+	if _, err = testClient.TemplatePreview("summer_sale", opts); err != nil {
+		t.Error(err)
+	}
+	if body != `{"substitution_data":{}}` || opts.SubstitutionData != nil {
+		t.Errorf("TemplatePreview sent %s, and left SubstitutionData %v", body, opts.SubstitutionData)
+	}
+}
+
+func TestTemplatePublish(t *testing.T) {
+	testSetup(t)
+	defer testTeardown()
+
+	// set up the response handler
+	var query, body string
+	path := fmt.Sprintf(templatesPathFormat, testClient.Config.ApiVersion)
+	testMux.HandleFunc(path+"/summer_sale", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+		query = r.URL.RawQuery
+		b, _ := ioutil.ReadAll(r.Body)
+		body = string(b)
+		w.Header().Set("Content-Type", "application/json; charset=utf8")
+		w.Write([]byte(`{"results": {"id": "summer_sale"}}`))
+	})
+
+	res, err := testClient.TemplatePublish("summer_sale")
+	if err != nil {
+		testFailVerbose(t, res, "TemplatePublish returned error: %v", err)
+	}
+	if query != "update_published=false" || body != `{"published":true}` {
+		t.Errorf("TemplatePublish sent %q %s", query, body)
+	}
+
+	tmpl := &Template{ID: "summer_sale", Published: true, Content: Content{
+		Subject: "Summer deals", Text: "deals", From: "marketing@company.example"}}
+	if res, err = testClient.TemplateUpdate(tmpl); err != nil {
+		testFailVerbose(t, res, "TemplateUpdate returned error: %v", err)
+	}
+	if query != "update_published=true" {
+		t.Errorf("TemplateUpdate query string was %q", query)
+	}
+
+	if _, err = testClient.TemplatePublish(""); err == nil {
+		t.Error("TemplatePublish accepted a blank id")
+	}
+}
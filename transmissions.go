@@ -1,9 +1,9 @@
 package gosparkpost
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"net/url"
 	"reflect"
 	"strings"
 	"time"
@@ -31,15 +31,62 @@ type Transmission struct {
 	NumInvalidRecipients *int `json:"num_invalid_recipients,omitempty"`
 }
 
+// TransmissionResults is the "results" object returned when a Transmission is sent.
+type TransmissionResults struct {
+	ID       string `json:"id"`
+	Accepted int    `json:"total_accepted_recipients"`
+	Rejected int    `json:"total_rejected_recipients"`
+}
+
+// RFC3339 is a time formatted as SparkPost expects for TxOptions.StartTime.
+// The zero value stands for "now".
 type RFC3339 time.Time
 
+// MaxScheduleAhead is how far in the future a Transmission's StartTime may be.
+const MaxScheduleAhead = 31 * 24 * time.Hour
+
+// MaxStartTimeSkew is how far in the past a Transmission's StartTime may be, to allow for
+// clocks that differ a little from SparkPost's. Use StartNow to send immediately.
+const MaxStartTimeSkew = 5 * time.Minute
+
+// StartTime returns a StartTime for t.
+func StartTime(t time.Time) *RFC3339 {
+	r := RFC3339(t)
+	return &r
+}
+
+// StartNow returns a StartTime which sends the Transmission immediately.
+func StartNow() *RFC3339 {
+	return &RFC3339{}
+}
+
 func (r *RFC3339) MarshalJSON() ([]byte, error) {
 	if r == nil {
 		return json.Marshal(nil)
 	}
+	if time.Time(*r).IsZero() {
+		return json.Marshal("now")
+	}
 	return json.Marshal(time.Time(*r).Format(time.RFC3339))
 }
 
+func (r *RFC3339) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	if s == "now" || s == "" {
+		*r = RFC3339{}
+		return nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return err
+	}
+	*r = RFC3339(t)
+	return nil
+}
+
 // Options specifies settings to apply to this Transmission.
 // If not specified, and present in TmplOptions, those values will be used.
 type TxOptions struct {
@@ -150,8 +197,6 @@ func ParseContent(content interface{}) (err error) {
 	default:
 		return fmt.Errorf("Unsupported Transmission.Content type [%s]", reflect.TypeOf(rVal))
 	}
-
-	return
 }
 
 // Validate runs sanity checks of a Transmission struct.
@@ -175,6 +220,17 @@ func (t *Transmission) Validate() error {
 		return fmt.Errorf("Transmission description may not be longer than 1024 bytes")
 	}
 
+	// SparkPost only accepts schedules up to MaxScheduleAhead in the future
+	if t.Options != nil && t.Options.StartTime != nil {
+		start := time.Time(*t.Options.StartTime)
+		now := time.Now()
+		if !start.IsZero() && start.After(now.Add(MaxScheduleAhead)) {
+			return fmt.Errorf("Transmission start time may not be more than %d days ahead", int(MaxScheduleAhead.Hours()/24))
+		} else if !start.IsZero() && start.Before(now.Add(-MaxStartTimeSkew)) {
+			return fmt.Errorf("Transmission start time may not be in the past")
+		}
+	}
+
 	// validate members from other packages
 	recips, err := ParseRecipients(t.Recipients)
 	if err != nil {
@@ -207,46 +263,20 @@ func (c *Client) Send(t *Transmission) (id string, res *Response, err error) {
 		return
 	}
 
-	jsonBytes, err := json.Marshal(t)
-	if err != nil {
-		return
-	}
-
 	path := fmt.Sprintf(transmissionsPathFormat, c.Config.ApiVersion)
 	u := fmt.Sprintf("%s%s", c.Config.BaseUrl, path)
-	res, err = c.HttpPost(u, jsonBytes)
+	res, err = c.doJSON(context.Background(), "POST", u, t, "Transmission", "create")
 	if err != nil {
 		return
 	}
 
-	if err = res.AssertJson(); err != nil {
-		return
-	}
-
-	err = res.ParseResponse()
-	if err != nil {
-		return
+	var results TransmissionResults
+	if err = res.DecodeResults(&results); err != nil {
+		return id, res, err
 	}
-
-	if res.HTTP.StatusCode == 200 {
-		var ok bool
-		var results map[string]interface{}
-		if results, ok = res.Results.(map[string]interface{}); !ok {
-			return id, res, fmt.Errorf("Unexpected response to Transmission creation (results)")
-		}
-		id, ok = results["id"].(string)
-		if !ok {
-			err = fmt.Errorf("Unexpected response to Transmission creation")
-		}
-
-	} else if len(res.Errors) > 0 {
-		// handle common errors
-		err = res.PrettyError("Transmission", "create")
-		if err != nil {
-			return
-		}
-
-		err = fmt.Errorf("%d: %s", res.HTTP.StatusCode, string(res.Body))
+	id = results.ID
+	if id == "" {
+		err = res.unexpected("Unexpected response to Transmission creation")
 	}
 
 	return
@@ -259,50 +289,21 @@ func (c *Client) Transmission(id string) (*Transmission, *Response, error) {
 	}
 	path := fmt.Sprintf(transmissionsPathFormat, c.Config.ApiVersion)
 	u := fmt.Sprintf("%s%s/%s", c.Config.BaseUrl, path, id)
-	res, err := c.HttpGet(u)
+	res, err := c.doJSON(context.Background(), "GET", u, nil, "Transmission", "retrieve")
 	if err != nil {
-		return nil, nil, err
-	}
-
-	if err = res.AssertJson(); err != nil {
 		return nil, res, err
 	}
 
-	if res.HTTP.StatusCode == 200 {
-		var body []byte
-		body, err = res.ReadBody()
-		if err != nil {
-			return nil, res, err
-		}
-
-		// Unwrap the returned Transmission
-		tmp := map[string]map[string]Transmission{}
-		if err = json.Unmarshal(body, &tmp); err != nil {
-			return nil, res, err
-		} else if results, ok := tmp["results"]; ok {
-			if tr, ok := results["transmission"]; ok {
-				return &tr, res, nil
-			} else {
-				return nil, res, fmt.Errorf("Unexpected results structure in response")
-			}
-		}
-		return nil, res, fmt.Errorf("Unexpected response to Transmission.Retrieve")
-
-	} else {
-		err = res.ParseResponse()
-		if err != nil {
-			return nil, res, err
-		}
-		if len(res.Errors) > 0 {
-			err = res.PrettyError("Transmission", "retrieve")
-			if err != nil {
-				return nil, res, err
-			}
-		}
-		return nil, res, fmt.Errorf("%d: %s", res.HTTP.StatusCode, string(res.Body))
+	// Unwrap the returned Transmission
+	var results struct {
+		Transmission *Transmission `json:"transmission"`
 	}
-
-	return nil, res, err
+	if err = res.DecodeResults(&results); err != nil {
+		return nil, res, err
+	} else if results.Transmission == nil {
+		return nil, res, res.unexpected("Unexpected results structure in response")
+	}
+	return results.Transmission, res, nil
 }
 
 // Delete attempts to remove the Transmission with the specified id.
@@ -317,89 +318,77 @@ func (c *Client) TransmissionDelete(id string) (*Response, error) {
 
 	path := fmt.Sprintf(transmissionsPathFormat, c.Config.ApiVersion)
 	u := fmt.Sprintf("%s%s/%s", c.Config.BaseUrl, path, id)
-	res, err := c.HttpDelete(u)
-	if err != nil {
-		return nil, err
-	}
-
-	if err = res.AssertJson(); err != nil {
-		return res, err
-	}
+	return c.transmissionDelete(u)
+}
 
-	if err = res.ParseResponse(); err != nil {
-		return res, err
+// DeleteByCampaign removes all the Transmissions in the specified campaign which are
+// scheduled for future generation, so a mis-scheduled campaign can be cancelled.
+func (c *Client) TransmissionDeleteByCampaign(campaignID string) (*Response, error) {
+	if campaignID == "" {
+		return nil, fmt.Errorf("DeleteByCampaign called with blank campaign id")
 	}
 
-	if res.HTTP.StatusCode == 200 {
-		return res, nil
-
-	} else if len(res.Errors) > 0 {
-		// handle common errors
-		err = res.PrettyError("Transmission", "delete")
-		if err != nil {
-			return res, err
-		}
-
-		return res, fmt.Errorf("%d: %s", res.HTTP.StatusCode, string(res.Body))
-	}
+	path := fmt.Sprintf(transmissionsPathFormat, c.Config.ApiVersion)
+	u := NewParams().Set("campaign_id", campaignID).Url(c.Config.BaseUrl + path)
+	return c.transmissionDelete(u)
+}
 
-	return res, nil
+func (c *Client) transmissionDelete(u string) (*Response, error) {
+	return c.doJSON(context.Background(), "DELETE", u, nil, "Transmission", "delete")
 }
 
 // List returns Transmission summary information for matching Transmissions.
 // To skip filtering by campaign or template id, use a nil param.
 func (c *Client) Transmissions(campaignID, templateID *string) ([]Transmission, *Response, error) {
+	var list []Transmission
+	res, err := c.transmissionList(campaignID, templateID, &list)
+	if err != nil {
+		return nil, res, err
+	}
+	return list, res, nil
+}
+
+// TransmissionSummary is the summary of a Transmission returned when listing them.
+type TransmissionSummary struct {
+	ID          string `json:"id"`
+	State       string `json:"state"`
+	CampaignID  string `json:"campaign_id"`
+	Description string `json:"description"`
+	Content     struct {
+		TemplateID string `json:"template_id"`
+	} `json:"content"`
+}
+
+// TransmissionSummaries is like Transmissions, but decodes the summaries into typed structs.
+// Scheduled Transmissions which haven't started yet have the State "submitted".
+func (c *Client) TransmissionSummaries(campaignID, templateID *string) ([]TransmissionSummary, *Response, error) {
+	var list []TransmissionSummary
+	res, err := c.transmissionList(campaignID, templateID, &list)
+	if err != nil {
+		return nil, res, err
+	}
+	return list, res, nil
+}
+
+// transmissionsUrl returns the url to list Transmissions, filtered by campaign and template id.
+func (c *Client) transmissionsUrl(campaignID, templateID *string) string {
 	// If a query parameter is present and empty, that searches for blank IDs, as opposed
 	// to when it is omitted entirely, which returns everything.
-	qp := make([]string, 0, 2)
+	qp := map[string]string{}
 	if campaignID != nil {
-		qp = append(qp, fmt.Sprintf("campaign_id=%s", url.QueryEscape(*campaignID)))
+		qp["campaign_id"] = *campaignID
 	}
 	if templateID != nil {
-		qp = append(qp, fmt.Sprintf("template_id=%s", url.QueryEscape(*templateID)))
-	}
-
-	qstr := ""
-	if len(qp) > 0 {
-		qstr = strings.Join(qp, "&")
+		qp["template_id"] = *templateID
 	}
 	path := fmt.Sprintf(transmissionsPathFormat, c.Config.ApiVersion)
-	u := fmt.Sprintf("%s%s?%s", c.Config.BaseUrl, path, qstr)
+	return ParamsFromMap(qp).Url(c.Config.BaseUrl + path)
+}
 
-	res, err := c.HttpGet(u)
+func (c *Client) transmissionList(campaignID, templateID *string, list interface{}) (*Response, error) {
+	res, err := c.doJSON(context.Background(), "GET", c.transmissionsUrl(campaignID, templateID), nil, "Transmission", "list")
 	if err != nil {
-		return nil, nil, err
-	}
-
-	if err = res.AssertJson(); err != nil {
-		return nil, res, err
-	}
-
-	if res.HTTP.StatusCode == 200 {
-		var body []byte
-		body, err = res.ReadBody()
-		if err != nil {
-			return nil, res, err
-		}
-		tlist := map[string][]Transmission{}
-		if err = json.Unmarshal(body, &tlist); err != nil {
-			return nil, res, err
-		} else if list, ok := tlist["results"]; ok {
-			return list, res, nil
-		}
-		return nil, res, fmt.Errorf("Unexpected response to Transmission list")
-
-	} else {
-		err = res.ParseResponse()
-		if err != nil {
-			return nil, res, err
-		}
-		if len(res.Errors) > 0 {
-			err = res.PrettyError("Transmission", "list")
-			if err != nil {
-				return nil, res, err
-			}
-		}
-		return nil, res, fmt.Errorf("%d: %s", res.HTTP.StatusCode, string(res.Body))
+		return res, err
 	}
+	return res, res.DecodeResults(list)
 }
@@ -0,0 +1,129 @@
+package gosparkpost
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestTransmissionSummaries(t *testing.T) {
+	testSetup(t)
+	defer testTeardown()
+
+	// set up the response handler
+	var query string
+	path := fmt.Sprintf(transmissionsPathFormat, testClient.Config.ApiVersion)
+	testMux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		query = r.URL.RawQuery
+		w.Header().Set("Content-Type", "application/json; charset=utf8")
+		w.Write([]byte(`{"results": [{"content": {"template_id": "winter_sale"}, "id": "11713562166689858",
+			"campaign_id": "thanksgiving", "description": "", "state": "submitted"}]}`))
+	})
+
+	campaignID, templateID := "thanksgiving", ""
+	list, res, err := testClient.TransmissionSummaries(&campaignID, &templateID)
+	if err != nil {
+		testFailVerbose(t, res, "TransmissionSummaries returned error: %v", err)
+	}
+	// an empty template id filters for transmissions without one
+	if query != "campaign_id=thanksgiving&template_id=" {
+		t.Errorf("query string was %q", query)
+	}
+	if len(list) != 1 {
+		t.Fatalf("TransmissionSummaries returned %d results, expected 1", len(list))
+	}
+	if tr := list[0]; tr.ID != "11713562166689858" || tr.State != "submitted" || tr.Content.TemplateID != "winter_sale" {
+		t.Errorf("unexpected summary %+v", tr)
+	}
+
+	if _, _, err = testClient.TransmissionSummaries(nil, nil); err != nil {
+		t.Error(err)
+	}
+	if query != "" {
+		t.Errorf("unfiltered query string was %q", query)
+	}
+}
+
+func TestTransmissionDeleteByCampaign(t *testing.T) {
+	testSetup(t)
+	defer testTeardown()
+
+	// set up the response handler
+	var query string
+	path := fmt.Sprintf(transmissionsPathFormat, testClient.Config.ApiVersion)
+	testMux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		query = r.URL.RawQuery
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	res, err := testClient.TransmissionDeleteByCampaign("black friday")
+	if err != nil {
+		testFailVerbose(t, res, "TransmissionDeleteByCampaign returned error: %v", err)
+	}
+	if query != "campaign_id=black+friday" {
+		t.Errorf("query string was %q", query)
+	}
+
+	if _, err = testClient.TransmissionDeleteByCampaign(""); err == nil {
+		t.Error("TransmissionDeleteByCampaign accepted a blank campaign id")
+	}
+}
+
+func TestStartTime(t *testing.T) {
+	start := time.Date(2016, 5, 1, 10, 30, 0, 0, time.FixedZone("", -4*60*60))
+	for idx, test := range []struct {
+		in  *RFC3339
+		out string
+	}{
+		{StartTime(start), `"2016-05-01T10:30:00-04:00"`},
+		{StartNow(), `"now"`},
+		{nil, `null`},
+	} {
+		out, err := json.Marshal(test.in)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(out) != test.out {
+			t.Errorf("StartTime (%d) marshaled to %s, want %s", idx, out, test.out)
+		}
+		if test.in == nil {
+			continue
+		}
+		var back RFC3339
+		if err = json.Unmarshal(out, &back); err != nil {
+			t.Fatal(err)
+		}
+		if !time.Time(back).Equal(time.Time(*test.in)) {
+			t.Errorf("StartTime (%d) unmarshaled to %v", idx, time.Time(back))
+		}
+	}
+
+	tx := &Transmission{
+		Recipients: []string{"to@example.com"},
+		Content:    Content{From: "from@example.com", Subject: "subject", Text: "text"},
+		Options:    &TxOptions{StartTime: StartTime(time.Now().Add(MaxScheduleAhead + time.Hour))},
+	}
+	if err := tx.Validate(); err == nil {
+		t.Error("Validate accepted a start time too far ahead")
+	}
+	tx.Options.StartTime = StartTime(time.Now().Add(24 * time.Hour))
+	if err := tx.Validate(); err != nil {
+		t.Errorf("Validate rejected a start time tomorrow: %v", err)
+	}
+	tx.Options.StartTime = StartNow()
+	if err := tx.Validate(); err != nil {
+		t.Errorf("Validate rejected a start time of now: %v", err)
+	}
+	tx.Options.StartTime = StartTime(time.Now().Add(-time.Hour))
+	if err := tx.Validate(); err == nil {
+		t.Error("Validate accepted a start time an hour ago")
+	}
+	tx.Options.StartTime = StartTime(time.Now().Add(-time.Minute))
+	if err := tx.Validate(); err != nil {
+		t.Errorf("Validate rejected a start time within MaxStartTimeSkew: %v", err)
+	}
+}
@@ -1,16 +1,18 @@
 package gosparkpost
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-
-	URL "net/url"
+	"reflect"
+	"strings"
 )
 
 // https://www.sparkpost.com/api#/reference/message-events
 var webhookListPathFormat = "/api/v%d/webhooks"
 var webhookQueryPathFormat = "/api/v%d/webhooks/%s"
 var webhookStatusPathFormat = "/api/v%d/webhooks/%s/batch-status"
+var webhookValidatePathFormat = "/api/v%d/webhooks/%s/validate"
 
 type WebhookItem struct {
 	ID       string   `json:"id,omitempty"`
@@ -45,6 +47,59 @@ type WebhookItem struct {
 	} `json:"links,omitempty"`
 }
 
+// WebhookResults is the "results" object returned when a webhook is created.
+type WebhookResults struct {
+	ID string `json:"id"`
+}
+
+// MarshalJSON omits AuthRequestDetails and AuthCredentials when they're empty,
+// so webhooks without authentication can be created and updated.
+func (w WebhookItem) MarshalJSON() ([]byte, error) {
+	type item WebhookItem
+	out := struct {
+		item
+		AuthRequestDetails interface{} `json:"auth_request_details,omitempty"`
+		AuthCredentials    interface{} `json:"auth_credentials,omitempty"`
+	}{item: item(w)}
+	if !reflect.ValueOf(w.AuthRequestDetails).IsZero() {
+		out.AuthRequestDetails = w.AuthRequestDetails
+	}
+	if !reflect.ValueOf(w.AuthCredentials).IsZero() {
+		out.AuthCredentials = w.AuthCredentials
+	}
+	return json.Marshal(out)
+}
+
+// Validate runs sanity checks on a WebhookItem struct.
+// This should catch most errors before attempting a doomed API call.
+func (w *WebhookItem) Validate() error {
+	if w == nil {
+		return fmt.Errorf("Can't Validate a nil WebhookItem")
+	}
+
+	// enforce required parameters
+	if w.Name == "" {
+		return fmt.Errorf("Webhook requires a non-empty Name")
+	} else if w.Target == "" {
+		return fmt.Errorf("Webhook requires a non-empty Target")
+	} else if len(w.Events) == 0 {
+		return fmt.Errorf("Webhook requires at least one of Events")
+	}
+
+	return nil
+}
+
+// WebhookValidation is the "results" object returned when a webhook is validated.
+// Response describes how the webhook's target answered the test batch.
+type WebhookValidation struct {
+	Msg      string `json:"msg"`
+	Response struct {
+		Status  int               `json:"status"`
+		Headers map[string]string `json:"headers,omitempty"`
+		Body    string            `json:"body"`
+	} `json:"response"`
+}
+
 type WebhookStatus struct {
 	BatchID      string `json:"batch_id,omitempty"`
 	Ts           string `json:"ts,omitempty"`
@@ -52,6 +107,11 @@ type WebhookStatus struct {
 	ResponseCode string `json:"response_code,omitempty"`
 }
 
+// Failed reports whether the webhook's target didn't accept the batch with a 2xx response.
+func (s *WebhookStatus) Failed() bool {
+	return !strings.HasPrefix(s.ResponseCode, "2")
+}
+
 type WebhookListWrapper struct {
 	Results []*WebhookItem `json:"results,omitempty"`
 	Errors  []interface{}  `json:"errors,omitempty"`
@@ -71,23 +131,11 @@ type WebhookStatusWrapper struct {
 }
 
 func buildUrl(c *Client, url string, parameters map[string]string) string {
-
-	if parameters == nil || len(parameters) == 0 {
-		url = fmt.Sprintf("%s%s", c.Config.BaseUrl, url)
-	} else {
-		params := URL.Values{}
-		for k, v := range parameters {
-			params.Add(k, v)
-		}
-
-		url = fmt.Sprintf("%s%s?%s", c.Config.BaseUrl, url, params.Encode())
-	}
-
-	return url
+	return ParamsFromMap(parameters).Url(c.Config.BaseUrl + url)
 }
 
 // https://developers.sparkpost.com/api/#/reference/webhooks/batch-status/retrieve-status-information
-func (c *Client) WebhookStatus(id string, parameters map[string]string) (*WebhookStatusWrapper, error) {
+func (c *Client) WebhookStatus(id string, parameters map[string]string) (*WebhookStatusWrapper, *Response, error) {
 
 	var finalUrl string
 	path := fmt.Sprintf(webhookStatusPathFormat, c.Config.ApiVersion, id)
@@ -97,8 +145,27 @@ func (c *Client) WebhookStatus(id string, parameters map[string]string) (*Webhoo
 	return doWebhookStatusRequest(c, finalUrl)
 }
 
+// WebhookBatchStatus returns the delivery status of the most recent batches sent
+// to the webhook with the specified id, at most limit of them. A limit of zero
+// leaves it to the API's default.
+func (c *Client) WebhookBatchStatus(id string, limit int) ([]*WebhookStatus, *Response, error) {
+	if id == "" {
+		return nil, nil, fmt.Errorf("WebhookBatchStatus called with blank id")
+	}
+
+	p := NewParams()
+	if limit > 0 {
+		p.Int("limit", limit)
+	}
+	wrapper, res, err := c.WebhookStatus(id, p.Map())
+	if err != nil {
+		return nil, res, err
+	}
+	return wrapper.Results, res, nil
+}
+
 // https://developers.sparkpost.com/api/#/reference/webhooks/retrieve/retrieve-webhook-details
-func (c *Client) QueryWebhook(id string, parameters map[string]string) (*WebhookQueryWrapper, error) {
+func (c *Client) QueryWebhook(id string, parameters map[string]string) (*WebhookQueryWrapper, *Response, error) {
 
 	var finalUrl string
 	path := fmt.Sprintf(webhookQueryPathFormat, c.Config.ApiVersion, id)
@@ -109,7 +176,7 @@ func (c *Client) QueryWebhook(id string, parameters map[string]string) (*Webhook
 }
 
 // https://developers.sparkpost.com/api/#/reference/webhooks/list/list-all-webhooks
-func (c *Client) ListWebhooks(parameters map[string]string) (*WebhookListWrapper, error) {
+func (c *Client) ListWebhooks(parameters map[string]string) (*WebhookListWrapper, *Response, error) {
 
 	var finalUrl string
 	path := fmt.Sprintf(webhookListPathFormat, c.Config.ApiVersion)
@@ -119,11 +186,87 @@ func (c *Client) ListWebhooks(parameters map[string]string) (*WebhookListWrapper
 	return doWebhooksListRequest(c, finalUrl)
 }
 
-func doWebhooksListRequest(c *Client, finalUrl string) (*WebhookListWrapper, error) {
+// https://developers.sparkpost.com/api/#/reference/webhooks/create/create-a-webhook
+func (c *Client) CreateWebhook(w *WebhookItem) (id string, res *Response, err error) {
+	if err = w.Validate(); err != nil {
+		return
+	}
+
+	path := fmt.Sprintf(webhookListPathFormat, c.Config.ApiVersion)
+	res, err = c.doJSON(context.Background(), "POST", c.Config.BaseUrl+path, w, "Webhook", "create")
+	if err != nil {
+		return
+	}
+
+	var results WebhookResults
+	if err = res.DecodeResults(&results); err != nil {
+		return id, res, err
+	}
+	id = results.ID
+	if id == "" {
+		err = res.unexpected("Unexpected response to Webhook creation")
+	}
+	w.ID = id
+
+	return
+}
+
+// https://developers.sparkpost.com/api/#/reference/webhooks/update-and-delete/update-a-webhook
+func (c *Client) UpdateWebhook(w *WebhookItem) (res *Response, err error) {
+	if w == nil || w.ID == "" {
+		err = fmt.Errorf("UpdateWebhook called with blank id")
+		return
+	}
+
+	if err = w.Validate(); err != nil {
+		return
+	}
+
+	path := fmt.Sprintf(webhookQueryPathFormat, c.Config.ApiVersion, w.ID)
+	res, err = c.doJSON(context.Background(), "PUT", c.Config.BaseUrl+path, w, "Webhook", "update")
+	return
+}
+
+// https://developers.sparkpost.com/api/#/reference/webhooks/update-and-delete/delete-a-webhook
+func (c *Client) DeleteWebhook(id string) (res *Response, err error) {
+	if id == "" {
+		err = fmt.Errorf("DeleteWebhook called with blank id")
+		return
+	}
+
+	path := fmt.Sprintf(webhookQueryPathFormat, c.Config.ApiVersion, id)
+	res, err = c.doJSON(context.Background(), "DELETE", c.Config.BaseUrl+path, nil, "Webhook", "delete")
+	return
+}
+
+// https://developers.sparkpost.com/api/#/reference/webhooks/validate/validate-a-webhook
+// ValidateWebhook sends message to the target of the webhook with the specified id,
+// as though it were a batch of events. A nil message sends an empty one.
+func (c *Client) ValidateWebhook(id string, message interface{}) (*WebhookValidation, *Response, error) {
+	if id == "" {
+		return nil, nil, fmt.Errorf("ValidateWebhook called with blank id")
+	}
+	if message == nil {
+		message = map[string]interface{}{}
+	}
+
+	path := fmt.Sprintf(webhookValidatePathFormat, c.Config.ApiVersion, id)
+	res, err := c.doJSON(context.Background(), "POST", c.Config.BaseUrl+path, map[string]interface{}{"message": message}, "Webhook", "validate")
+	if err != nil {
+		return nil, res, err
+	}
+
+	v := &WebhookValidation{}
+	if err = res.DecodeResults(v); err != nil {
+		return nil, res, err
+	}
+	return v, res, nil
+}
 
-	bodyBytes, err := doRequest(c, finalUrl)
+func doWebhooksListRequest(c *Client, finalUrl string) (*WebhookListWrapper, *Response, error) {
+	bodyBytes, res, err := doRequest(c, finalUrl)
 	if err != nil {
-		return nil, err
+		return nil, res, err
 	}
 
 	// Parse expected response structure
@@ -131,57 +274,58 @@ func doWebhooksListRequest(c *Client, finalUrl string) (*WebhookListWrapper, err
 	err = json.Unmarshal(bodyBytes, &resMap)
 
 	if err != nil {
-		return nil, err
+		return nil, res, err
 	}
 
-	return &resMap, err
+	return &resMap, res, err
 }
 
-func doWebhooksQueryRequest(c *Client, finalUrl string) (*WebhookQueryWrapper, error) {
-	bodyBytes, err := doRequest(c, finalUrl)
+func doWebhooksQueryRequest(c *Client, finalUrl string) (*WebhookQueryWrapper, *Response, error) {
+	bodyBytes, res, err := doRequest(c, finalUrl)
+	if err != nil {
+		return nil, res, err
+	}
 
 	// Parse expected response structure
 	var resMap WebhookQueryWrapper
 	err = json.Unmarshal(bodyBytes, &resMap)
 
 	if err != nil {
-		return nil, err
+		return nil, res, err
 	}
 
-	return &resMap, err
+	return &resMap, res, err
 }
 
-func doWebhookStatusRequest(c *Client, finalUrl string) (*WebhookStatusWrapper, error) {
-	bodyBytes, err := doRequest(c, finalUrl)
+func doWebhookStatusRequest(c *Client, finalUrl string) (*WebhookStatusWrapper, *Response, error) {
+	bodyBytes, res, err := doRequest(c, finalUrl)
+	if err != nil {
+		return nil, res, err
+	}
 
 	// Parse expected response structure
 	var resMap WebhookStatusWrapper
 	err = json.Unmarshal(bodyBytes, &resMap)
 
 	if err != nil {
-		return nil, err
+		return nil, res, err
 	}
 
-	return &resMap, err
+	return &resMap, res, err
 }
 
-func doRequest(c *Client, finalUrl string) ([]byte, error) {
+func doRequest(c *Client, finalUrl string) ([]byte, *Response, error) {
 	// Send off our request
-	res, err := c.HttpGet(finalUrl)
+	res, err := c.doJSON(context.Background(), "GET", finalUrl, nil, "", "")
 	if err != nil {
-		return nil, err
-	}
-
-	// Assert that we got a JSON Content-Type back
-	if err = res.AssertJson(); err != nil {
-		return nil, err
+		return nil, res, err
 	}
 
 	// Get the Content
 	bodyBytes, err := res.ReadBody()
 	if err != nil {
-		return nil, err
+		return nil, res, err
 	}
 
-	return bodyBytes, err
+	return bodyBytes, res, err
 }
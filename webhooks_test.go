@@ -0,0 +1,235 @@
+package gosparkpost
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+)
+
+var webhookList string = `{
+  "results": [
+    {
+      "id": "12affc24-f183-11e3-9234-3c15c2c818c2",
+      "name": "Example webhook",
+      "target": "http://client.example.com/example-webhook",
+      "events": ["delivery", "injection", "open", "click"],
+      "auth_type": "none"
+    }
+  ]
+}`
+
+func TestWebhooks_List(t *testing.T) {
+	testSetup(t)
+	defer testTeardown()
+
+	// set up the response handler
+	path := fmt.Sprintf(webhookListPathFormat, testClient.Config.ApiVersion)
+	testMux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		w.Header().Set("Content-Type", "application/json; charset=utf8")
+		w.Write([]byte(webhookList))
+	})
+
+	// hit our local handler
+	list, res, err := testClient.ListWebhooks(nil)
+	if err != nil {
+		testFailVerbose(t, res, "ListWebhooks GET returned error: %v", err)
+	}
+
+	if res == nil || res.HTTP.StatusCode != 200 {
+		t.Fatalf("ListWebhooks didn't return the HTTP response")
+	} else if len(list.Results) != 1 {
+		testFailVerbose(t, res, "ListWebhooks GET returned %d results, expected %d", len(list.Results), 1)
+	} else if list.Results[0].Name != "Example webhook" {
+		testFailVerbose(t, res, "ListWebhooks GET Unmarshal error; saw [%v] expected [Example webhook]", list.Results[0].Name)
+	}
+}
+
+func TestWebhooks_ListTimezone(t *testing.T) {
+	testSetup(t)
+	defer testTeardown()
+
+	// set up the response handler
+	var query string
+	path := fmt.Sprintf(webhookListPathFormat, testClient.Config.ApiVersion)
+	testMux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.RawQuery
+		w.Header().Set("Content-Type", "application/json; charset=utf8")
+		w.Write([]byte(webhookList))
+	})
+
+	if _, res, err := testClient.ListWebhooks(map[string]string{"timezone": "America/New_York"}); err != nil {
+		testFailVerbose(t, res, "ListWebhooks GET returned error: %v", err)
+	}
+	if query != "timezone=America%2FNew_York" {
+		t.Errorf("query string was %q", query)
+	}
+}
+
+func TestWebhooks_Create(t *testing.T) {
+	testSetup(t)
+	defer testTeardown()
+
+	// set up the response handler
+	var bodies []map[string]interface{}
+	path := fmt.Sprintf(webhookListPathFormat, testClient.Config.ApiVersion)
+	testMux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		bodies = append(bodies, body)
+		w.Header().Set("Content-Type", "application/json; charset=utf8")
+		w.Write([]byte(`{"results": {"id": "12affc24-f183-11e3-9234-3c15c2c818c2", "links": []}}`))
+	})
+
+	hook := &WebhookItem{
+		Name:     "Example webhook",
+		Target:   "http://client.example.com/example-webhook",
+		Events:   []string{"delivery", "injection"},
+		AuthType: "none",
+	}
+	id, res, err := testClient.CreateWebhook(hook)
+	if err != nil {
+		testFailVerbose(t, res, "CreateWebhook returned error: %v", err)
+	}
+	if id != "12affc24-f183-11e3-9234-3c15c2c818c2" || hook.ID != id {
+		t.Errorf("CreateWebhook returned id %q (webhook has %q)", id, hook.ID)
+	}
+
+	hook.ID = ""
+	hook.AuthType = "basic"
+	hook.AuthCredentials.Username = "user"
+	hook.AuthCredentials.Password = "pass"
+	if _, _, err = testClient.CreateWebhook(hook); err != nil {
+		t.Error(err)
+	}
+
+	if len(bodies) != 2 {
+		t.Fatalf("server saw %d requests", len(bodies))
+	}
+	if _, ok := bodies[0]["auth_credentials"]; ok {
+		t.Errorf("empty auth_credentials were sent: %v", bodies[0])
+	}
+	if _, ok := bodies[0]["auth_request_details"]; ok {
+		t.Errorf("empty auth_request_details were sent: %v", bodies[0])
+	}
+	if creds, _ := bodies[1]["auth_credentials"].(map[string]interface{}); creds["username"] != "user" {
+		t.Errorf("auth_credentials weren't sent: %v", bodies[1])
+	}
+
+	if _, _, err = testClient.CreateWebhook(&WebhookItem{Name: "no target", Events: []string{"open"}}); err == nil {
+		t.Error("CreateWebhook accepted a webhook without a target")
+	}
+}
+
+func TestWebhooks_UpdateDelete(t *testing.T) {
+	testSetup(t)
+	defer testTeardown()
+
+	// set up the response handler
+	var methods []string
+	path := fmt.Sprintf(webhookQueryPathFormat, testClient.Config.ApiVersion, "12affc24")
+	testMux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		methods = append(methods, r.Method)
+		if r.Method == "DELETE" {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json; charset=utf8")
+		w.Write([]byte(`{"results": {"id": "12affc24", "links": []}}`))
+	})
+
+	hook := &WebhookItem{ID: "12affc24", Name: "Renamed", Target: "https://example.com/hook", Events: []string{"bounce"}}
+	if res, err := testClient.UpdateWebhook(hook); err != nil {
+		testFailVerbose(t, res, "UpdateWebhook returned error: %v", err)
+	}
+	if res, err := testClient.DeleteWebhook("12affc24"); err != nil {
+		testFailVerbose(t, res, "DeleteWebhook returned error: %v", err)
+	}
+	if fmt.Sprint(methods) != "[PUT DELETE]" {
+		t.Errorf("server saw methods %v", methods)
+	}
+
+	if _, err := testClient.UpdateWebhook(&WebhookItem{Name: "x"}); err == nil {
+		t.Error("UpdateWebhook accepted a blank id")
+	}
+	if _, err := testClient.DeleteWebhook(""); err == nil {
+		t.Error("DeleteWebhook accepted a blank id")
+	}
+}
+
+func TestWebhooks_Validate(t *testing.T) {
+	testSetup(t)
+	defer testTeardown()
+
+	// set up the response handler
+	var body string
+	path := fmt.Sprintf(webhookValidatePathFormat, testClient.Config.ApiVersion, "12affc24")
+	testMux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		b, _ := ioutil.ReadAll(r.Body)
+		body = string(b)
+		w.Header().Set("Content-Type", "application/json; charset=utf8")
+		w.Write([]byte(`{"results": {"msg": "Test POST to endpoint succeeded",
+			"response": {"status": 200, "headers": {"Content-Type": "text/plain"}, "body": "ok"}}}`))
+	})
+
+	v, res, err := testClient.ValidateWebhook("12affc24", map[string]string{"msys": "{}"})
+	if err != nil {
+		testFailVerbose(t, res, "ValidateWebhook returned error: %v", err)
+	}
+	if body != `{"message":{"msys":"{}"}}` {
+		t.Errorf("ValidateWebhook sent %s", body)
+	}
+	if v == nil || v.Response.Status != 200 || v.Response.Body != "ok" || v.Response.Headers["Content-Type"] != "text/plain" {
+		t.Errorf("unexpected validation %+v", v)
+	}
+
+	if _, _, err = testClient.ValidateWebhook("12affc24", nil); err != nil {
+		t.Error(err)
+	}
+	if body != `{"message":{}}` {
+		t.Errorf("ValidateWebhook sent %s for a nil message", body)
+	}
+}
+
+func TestWebhooks_BatchStatus(t *testing.T) {
+	testSetup(t)
+	defer testTeardown()
+
+	// set up the response handler
+	var query string
+	path := fmt.Sprintf(webhookStatusPathFormat, testClient.Config.ApiVersion, "12affc24")
+	testMux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		query = r.URL.RawQuery
+		w.Header().Set("Content-Type", "application/json; charset=utf8")
+		w.Write([]byte(`{"results": [
+			{"batch_id": "032d330540298f54f0e8bcc1373f3cfd", "ts": "2014-07-30T21:38:08.000Z", "attempts": 7, "response_code": "200"},
+			{"batch_id": "13c6764994a8f6b4e29906d5712ca7d", "ts": "2014-07-30T20:38:08.000Z", "attempts": 2, "response_code": "500"}
+		]}`))
+	})
+
+	list, res, err := testClient.WebhookBatchStatus("12affc24", 2)
+	if err != nil {
+		testFailVerbose(t, res, "WebhookBatchStatus returned error: %v", err)
+	}
+	if query != "limit=2" {
+		t.Errorf("query string was %q", query)
+	}
+	if len(list) != 2 {
+		t.Fatalf("WebhookBatchStatus returned %d results, expected 2", len(list))
+	}
+	if list[0].Failed() || !list[1].Failed() || list[1].Attempts != 2 {
+		t.Errorf("unexpected statuses %+v %+v", list[0], list[1])
+	}
+
+	if _, _, err = testClient.WebhookBatchStatus("12affc24", 0); err != nil {
+		t.Error(err)
+	}
+	if query != "" {
+		t.Errorf("query string without a limit was %q", query)
+	}
+}